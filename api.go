@@ -0,0 +1,123 @@
+package validation
+
+import (
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// IsEmail reports whether s is a valid email address with an ASCII domain.
+func IsEmail(s string) bool {
+	return checkEmail(s, false)
+}
+
+// IsURL reports whether s is an absolute URL or an absolute path.
+func IsURL(s string) bool {
+	return checkURL(s)
+}
+
+// IsDomain reports whether s is a domain name with at least two labels and a
+// top-level label that is not all-numeric, like is_domain.
+func IsDomain(s string) bool {
+	return domainRules().check(s) == nil
+}
+
+// IsHostname reports whether s is a hostname of one or more DNS labels whose
+// last label is not all-numeric, like is_hostname.
+func IsHostname(s string) bool {
+	return rfc1123HostnameRules().check(s) == nil
+}
+
+// IsIDNEmail reports whether s is a valid email address, accepting
+// internationalized domains such as user@münchen.de.
+func IsIDNEmail(s string) bool {
+	return checkEmail(s, true)
+}
+
+// IsIDNDomain reports whether s is a domain name with at least two labels,
+// accepting Unicode labels that have a valid punycode form.
+func IsIDNDomain(s string) bool {
+	rules := domainRules()
+	rules.allowIDN = true
+	return rules.check(s) == nil
+}
+
+// IsIDNHostname reports whether s is a hostname of one or more DNS labels,
+// accepting Unicode labels that have a valid punycode form.
+func IsIDNHostname(s string) bool {
+	rules := rfc1123HostnameRules()
+	rules.allowIDN = true
+	return rules.check(s) == nil
+}
+
+// IsBlank reports whether s is empty or contains only whitespace.
+func IsBlank(s string) bool {
+	return strings.TrimSpace(s) == ""
+}
+
+// MatchesRegex reports whether s matches the RE2 pattern.
+func MatchesRegex(s, pattern string) (bool, error) {
+	re, err := patternCache.compile(pattern)
+	if err != nil {
+		return false, err
+	}
+	return re.MatchString(s), nil
+}
+
+// MinLength reports whether s is at least min bytes long.
+func MinLength(s string, min int) bool {
+	return len(s) >= min
+}
+
+// MaxLength reports whether s is at most max bytes long.
+func MaxLength(s string, max int) bool {
+	return len(s) <= max
+}
+
+// InRange reports whether min <= n <= max.
+func InRange(n, min, max float64) bool {
+	return n >= min && n <= max
+}
+
+// Schema is a compiled schema for validating Go values with ValidateSchema.
+type Schema struct {
+	s *schema
+}
+
+// CompileSchema compiles field definitions for use from Go. Definitions take
+// the same forms as in validation.schema: rule strings such as
+// "required|email|max:255" or maps of field options, e.g.
+//
+//	validation.CompileSchema(map[string]any{
+//	    "email": "required|email",
+//	    "age":   map[string]any{"type": "integer", "min": 18},
+//	})
+//
+// Validators registered from Lua or with RegisterGoValidator need a Lua state
+// and cannot be used.
+func CompileSchema(def map[string]any) (Schema, error) {
+	s, err := compileSchema(nil, toLua(nil, def).(*lua.LTable))
+	if err != nil {
+		return Schema{}, err
+	}
+	return Schema{s: s}, nil
+}
+
+// ValidateSchema validates data against a schema compiled with CompileSchema
+// and returns error messages (in English) by field path, or nil if data is
+// valid. Nested maps and slices are validated like tables and arrays.
+func ValidateSchema(data map[string]any, s Schema) map[string]string {
+	if s.s == nil {
+		return nil
+	}
+	errs := s.s.validate(nil, toLua(nil, data).(*lua.LTable))
+	if len(errs) == 0 {
+		return nil
+	}
+	st := newModuleState()
+	messages := make(map[string]string, len(errs))
+	for _, e := range errs {
+		messages[e.path] = e.render(st)
+	}
+	return messages
+}
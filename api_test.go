@@ -0,0 +1,155 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestGoValidators(t *testing.T) {
+	tests := []struct {
+		name     string
+		got      bool
+		expected bool
+	}{
+		{"IsEmail valid", IsEmail("ada@example.com"), true},
+		{"IsEmail invalid", IsEmail("ada@"), false},
+		{"IsURL", IsURL("https://example.com/path"), true},
+		{"IsDomain single label", IsDomain("localhost"), false},
+		{"IsHostname single label", IsHostname("localhost"), true},
+		{"IsBlank", IsBlank(" \t"), true},
+		{"MinLength", MinLength("abc", 4), false},
+		{"MaxLength", MaxLength("abc", 3), true},
+		{"InRange", InRange(5, 1, 10), true},
+		{"InRange outside", InRange(11, 1, 10), false},
+	}
+
+	for _, test := range tests {
+		if test.got != test.expected {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, test.got)
+		}
+	}
+
+	if _, err := MatchesRegex("abc", "("); err == nil {
+		t.Error("Expected error for invalid pattern")
+	}
+
+	failures := ValidatePassword("secret", PasswordPolicy{MinLength: 8, RequireDigit: true})
+	if !reflect.DeepEqual(failures, []string{"min_length", "require_digit"}) {
+		t.Errorf("Expected min_length and require_digit failures, got %v", failures)
+	}
+}
+
+func TestValidateSchema(t *testing.T) {
+	s, err := CompileSchema(map[string]any{
+		"email": "required|email",
+		"age":   map[string]any{"type": "integer", "min": 18},
+		"tags":  map[string]any{"items": "string|max:3"},
+		"address": map[string]any{"fields": map[string]any{
+			"zip": map[string]any{"type": "string", "required": true, "pattern": "^[0-9]{5}$"},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("CompileSchema failed: %v", err)
+	}
+
+	valid := map[string]any{
+		"email":   "ada@example.com",
+		"age":     36,
+		"tags":    []string{"go", "lua"},
+		"address": map[string]any{"zip": "12345"},
+	}
+	if errs := ValidateSchema(valid, s); errs != nil {
+		t.Errorf("Expected valid data, got %v", errs)
+	}
+
+	invalid := map[string]any{
+		"age":     int64(12),
+		"tags":    []any{"go", "rust!"},
+		"address": map[string]any{},
+	}
+	expected := map[string]string{
+		"email":       "email is required",
+		"age":         "age must be at least 18",
+		"tags[2]":     "tags[2] must be at most 3 characters",
+		"address.zip": "address.zip is required",
+	}
+	if errs := ValidateSchema(invalid, s); !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Expected %v, got %v", expected, errs)
+	}
+
+	if _, err := CompileSchema(map[string]any{"id": "required|is_sku"}); err == nil {
+		t.Error("Expected error for validator that needs a Lua state")
+	}
+}
+
+func TestIDNFormats(t *testing.T) {
+	if !IsIDNEmail("user@münchen.de") || IsEmail("user@münchen.de") {
+		t.Error("Expected only IsIDNEmail to accept an internationalized domain")
+	}
+	if !IsIDNDomain("пример.рф") || IsDomain("пример.рф") {
+		t.Error("Expected only IsIDNDomain to accept a Cyrillic domain")
+	}
+	if IsIDNHostname("xn--abc-") {
+		t.Error("Expected IsIDNHostname to reject malformed punycode")
+	}
+}
+
+func TestDomainRulesAgree(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	for _, input := range []string{"example.123", "192.168.0.1", "example.com"} {
+		L.SetGlobal("input", lua.LString(input))
+		if err := L.DoString(`
+			local validation = require("validation")
+			local format = validation.schema({
+				domain = {type = "string", format = "domain"},
+				hostname = {type = "string", format = "hostname"},
+			})
+			local tags = validation.schema_from_tags({domain = "fqdn", hostname = "hostname_rfc1123"})
+			local _, format_errors = format:validate({domain = input, hostname = input})
+			local _, tag_errors = tags:validate({domain = input, hostname = input})
+			format_errors, tag_errors = format_errors or {}, tag_errors or {}
+			return validation.is_domain(input), format_errors.domain == nil, tag_errors.domain == nil,
+				validation.is_hostname(input), format_errors.hostname == nil, tag_errors.hostname == nil
+		`); err != nil {
+			t.Fatalf("%s: %v", input, err)
+		}
+
+		domain, hostname := IsDomain(input), IsHostname(input)
+		s, err := CompileSchema(map[string]any{
+			"domain":   map[string]any{"type": "string", "format": "domain"},
+			"hostname": map[string]any{"type": "string", "format": "hostname"},
+		})
+		if err != nil {
+			t.Fatalf("CompileSchema failed: %v", err)
+		}
+		errs := ValidateSchema(map[string]any{"domain": input, "hostname": input}, s)
+		if _, failed := errs["domain"]; failed == domain {
+			t.Errorf("%s: IsDomain is %v but the Go schema disagrees", input, domain)
+		}
+		if _, failed := errs["hostname"]; failed == hostname {
+			t.Errorf("%s: IsHostname is %v but the Go schema disagrees", input, hostname)
+		}
+
+		for i, name := range []string{"is_domain", "domain format", "fqdn tag"} {
+			if got := lua.LVAsBool(L.Get(i - 6)); got != domain {
+				t.Errorf("%s: IsDomain is %v but %s is %v", input, domain, name, got)
+			}
+		}
+		for i, name := range []string{"is_hostname", "hostname format", "hostname_rfc1123 tag"} {
+			if got := lua.LVAsBool(L.Get(i - 3)); got != hostname {
+				t.Errorf("%s: IsHostname is %v but %s is %v", input, hostname, name, got)
+			}
+		}
+		L.SetTop(0)
+	}
+
+	if IsDomain("example.123") {
+		t.Error("Expected IsDomain to reject a numeric top-level label")
+	}
+}
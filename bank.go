@@ -0,0 +1,112 @@
+package validation
+
+import (
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// ibanLengths holds the IBAN length of each country in the SWIFT IBAN
+// registry, keyed by ISO 3166-1 alpha-2 code.
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22,
+	"BH": 22, "BI": 27, "BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24,
+	"DE": 22, "DJ": 27, "DK": 18, "DO": 28, "EE": 20, "EG": 29, "ES": 24, "FI": 18,
+	"FK": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23, "GL": 18, "GR": 27,
+	"GT": 28, "HN": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26,
+	"IT": 27, "JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20,
+	"LU": 20, "LV": 21, "LY": 25, "MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20,
+	"MR": 27, "MT": 31, "MU": 30, "NI": 28, "NL": 18, "NO": 15, "OM": 23, "PK": 24,
+	"PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33, "SA": 24,
+	"SC": 31, "SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25,
+	"SV": 28, "TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20,
+	"YE": 30,
+}
+
+// isUpperAlnum reports whether s consists of ASCII upper-case letters and
+// digits only.
+func isUpperAlnum(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !(s[i] >= 'A' && s[i] <= 'Z' || s[i] >= '0' && s[i] <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// mod97 computes the ISO 7064 MOD 97-10 remainder of an alphanumeric
+// string, with letters counted as two-digit numbers from A=10 to Z=35.
+func mod97(s string) int {
+	remainder := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 'A' {
+			remainder = (remainder*100 + int(c-'A'+10)) % 97
+		} else {
+			remainder = (remainder*10 + int(c-'0')) % 97
+		}
+	}
+	return remainder
+}
+
+// IsIBAN reports whether s is an International Bank Account Number with the
+// length registered for its country and valid check digits. Spaces, as in
+// the printed form "DE89 3704 0044 0532 0130 00", and lower-case letters are
+// accepted.
+func IsIBAN(s string) bool {
+	iban := strings.ToUpper(strings.ReplaceAll(s, " ", ""))
+	length, ok := ibanLengths[iban[:min(2, len(iban))]]
+	if !ok || len(iban) != length || !isUpperAlnum(iban) || !isDigits(iban[2:4]) {
+		return false
+	}
+	return mod97(iban[4:]+iban[:4]) == 1
+}
+
+// isIBAN checks if a string is an IBAN with a valid length and checksum
+// Usage: validation.is_iban(str) -> boolean
+func isIBAN(L *lua.LState) int {
+	L.Push(lua.LBool(IsIBAN(L.CheckString(1))))
+	return 1
+}
+
+// isUpperAlpha reports whether s consists of ASCII upper-case letters only.
+func isUpperAlpha(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'A' || s[i] > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// IsBIC reports whether s is a Business Identifier Code (SWIFT code) under
+// ISO 9362: a 4-letter institution code, an ISO 3166-1 country code, a
+// 2-character location code and an optional 3-character branch code.
+// Location codes cannot start with 0 or 1 or end with the letter O, and
+// branch codes starting with X are reserved except for XXX, the primary
+// office. Lower-case letters are accepted.
+func IsBIC(s string) bool {
+	bic := strings.ToUpper(s)
+	if len(bic) != 8 && len(bic) != 11 || !isUpperAlnum(bic) {
+		return false
+	}
+	institution, country, location := bic[:4], bic[4:6], bic[6:8]
+	if !isUpperAlpha(institution) || !countryCodes2[country] {
+		return false
+	}
+	if location[0] == '0' || location[0] == '1' || location[1] == 'O' {
+		return false
+	}
+	if len(bic) == 11 {
+		branch := bic[8:]
+		return branch[0] != 'X' || branch == "XXX"
+	}
+	return true
+}
+
+// isBIC checks if a string is a BIC/SWIFT code
+// Usage: validation.is_bic(str) -> boolean
+func isBIC(L *lua.LState) int {
+	L.Push(lua.LBool(IsBIC(L.CheckString(1))))
+	return 1
+}
@@ -0,0 +1,87 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsIBAN(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		iban     string
+		expected bool
+	}{
+		{"DE89370400440532013000", true},
+		{"DE89 3704 0044 0532 0130 00", true},
+		{"de89370400440532013000", true},
+		{"GB82WEST12345698765432", true},
+		{"FR1420041010050500013M02606", true},
+		{"NL91ABNA0417164300", true},
+		{"NO9386011117947", true},
+		{"AZ21NABZ00000000137010001944", true},
+		{"DE88370400440532013000", false},
+		{"DE8937040044053201300", false},
+		{"XX89370400440532013000", false},
+		{"DE89-3704-0044-0532-0130-00", false},
+		{"DE", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.iban, func(t *testing.T) {
+			err := L.DoString(`return require("validation").is_iban("` + tt.iban + `")`)
+			if err != nil {
+				t.Fatalf("is_iban failed: %v", err)
+			}
+			result := L.Get(-1).(lua.LBool)
+			L.Pop(1)
+			if bool(result) != tt.expected {
+				t.Errorf("Expected %v for %s, got %v", tt.expected, tt.iban, result)
+			}
+		})
+	}
+}
+
+func TestIsBIC(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		bic      string
+		expected bool
+	}{
+		{"DEUTDEFF", true},
+		{"DEUTDEFF500", true},
+		{"NEDSZAJJXXX", true},
+		{"deutdeff", true},
+		{"DEUTDEF", false},
+		{"DEUTDEFF50", false},
+		{"DEU1DEFF", false},
+		{"DEUTZZFF", false},
+		{"DEUTDE0F", false},
+		{"DEUTDEFO", false},
+		{"DEUTDEFFX12", false},
+		{"DEUT DEFF", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.bic, func(t *testing.T) {
+			err := L.DoString(`return require("validation").is_bic("` + tt.bic + `")`)
+			if err != nil {
+				t.Fatalf("is_bic failed: %v", err)
+			}
+			result := L.Get(-1).(lua.LBool)
+			L.Pop(1)
+			if bool(result) != tt.expected {
+				t.Errorf("Expected %v for %s, got %v", tt.expected, tt.bic, result)
+			}
+		})
+	}
+}
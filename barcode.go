@@ -0,0 +1,106 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// isbnSeparators are the characters allowed between the parts of an ISBN.
+var isbnSeparators = strings.NewReplacer("-", "", " ", "")
+
+// gtinValid reports whether a string of digits ends in a valid GS1 check
+// digit, as used by EAN, UPC and ISBN-13: digits are weighted 3 and 1
+// alternately from the right, starting with the digit before the check digit.
+func gtinValid(digits string) bool {
+	sum := 0
+	for i := len(digits) - 2; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-2-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return (10-sum%10)%10 == int(digits[len(digits)-1]-'0')
+}
+
+// isISBN10 reports whether s is ten characters, nine digits and a digit or X,
+// whose weighted sum is divisible by 11.
+func isISBN10(s string) bool {
+	if len(s) != 10 || !isDigits(s[:9]) {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += (10 - i) * int(s[i]-'0')
+	}
+	switch check := s[9]; {
+	case check == 'X' || check == 'x':
+		sum += 10
+	case check >= '0' && check <= '9':
+		sum += int(check - '0')
+	default:
+		return false
+	}
+	return sum%11 == 0
+}
+
+// isISBN13 reports whether s is a 13-digit ISBN with a 978 or 979 prefix and
+// a valid check digit.
+func isISBN13(s string) bool {
+	return len(s) == 13 && isDigits(s) &&
+		(strings.HasPrefix(s, "978") || strings.HasPrefix(s, "979")) && gtinValid(s)
+}
+
+// IsISBN reports whether s is an ISBN with a valid check digit. version
+// selects ISBN-10 or ISBN-13; 0 accepts either. Hyphens and spaces between
+// the parts are ignored.
+func IsISBN(s string, version int) bool {
+	s = isbnSeparators.Replace(s)
+	switch version {
+	case 10:
+		return isISBN10(s)
+	case 13:
+		return isISBN13(s)
+	}
+	return isISBN10(s) || isISBN13(s)
+}
+
+// isISBN checks if a string is an ISBN-10 or ISBN-13 with a valid check digit
+// Usage: validation.is_isbn(str, version?) -> boolean
+func isISBN(L *lua.LState) int {
+	str := L.CheckString(1)
+	version := L.OptInt(2, 0)
+	if version != 0 && version != 10 && version != 13 {
+		L.ArgError(2, fmt.Sprintf("unsupported ISBN version %d", version))
+	}
+	L.Push(lua.LBool(IsISBN(str, version)))
+	return 1
+}
+
+// IsEAN reports whether s is an EAN-8 or EAN-13 barcode number with a valid
+// check digit.
+func IsEAN(s string) bool {
+	return (len(s) == 8 || len(s) == 13) && isDigits(s) && gtinValid(s)
+}
+
+// IsUPC reports whether s is a 12-digit UPC-A barcode number with a valid
+// check digit.
+func IsUPC(s string) bool {
+	return len(s) == 12 && isDigits(s) && gtinValid(s)
+}
+
+// isEAN checks if a string is an EAN-8 or EAN-13 number with a valid check digit
+// Usage: validation.is_ean(str) -> boolean
+func isEAN(L *lua.LState) int {
+	L.Push(lua.LBool(IsEAN(L.CheckString(1))))
+	return 1
+}
+
+// isUPC checks if a string is a UPC-A number with a valid check digit
+// Usage: validation.is_upc(str) -> boolean
+func isUPC(L *lua.LState) int {
+	L.Push(lua.LBool(IsUPC(L.CheckString(1))))
+	return 1
+}
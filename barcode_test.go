@@ -0,0 +1,94 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsISBN(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		args     string
+		expected bool
+	}{
+		{`"0306406152"`, true},
+		{`"0-306-40615-2"`, true},
+		{`"080442957X"`, true},
+		{`"080442957x"`, true},
+		{`"9780306406157"`, true},
+		{`"978-0-306-40615-7"`, true},
+		{`"979 10 90636 07 1"`, true},
+		{`"0306406153"`, false},
+		{`"9780306406158"`, false},
+		{`"9770306406154"`, false},
+		{`"03064061X2"`, false},
+		{`"0306406152", 10`, true},
+		{`"0306406152", 13`, false},
+		{`"9780306406157", 13`, true},
+		{`"9780306406157", 10`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			err := L.DoString(`return require("validation").is_isbn(` + tt.args + `)`)
+			if err != nil {
+				t.Fatalf("is_isbn failed: %v", err)
+			}
+			result := L.Get(-1).(lua.LBool)
+			L.Pop(1)
+			if bool(result) != tt.expected {
+				t.Errorf("Expected %v for %s, got %v", tt.expected, tt.args, result)
+			}
+		})
+	}
+
+	err := L.DoString(`require("validation").is_isbn("0306406152", 11)`)
+	if err == nil || !strings.Contains(err.Error(), "unsupported ISBN version 11") {
+		t.Errorf("Expected unsupported version error, got %v", err)
+	}
+}
+
+func TestIsEANAndUPC(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		call     string
+		expected bool
+	}{
+		{`is_ean("4006381333931")`, true},
+		{`is_ean("96385074")`, true},
+		{`is_ean("9780306406157")`, true},
+		{`is_ean("4006381333932")`, false},
+		{`is_ean("96385075")`, false},
+		{`is_ean("036000291452")`, false},
+		{`is_ean("4006 3813 3393 1")`, false},
+		{`is_upc("036000291452")`, true},
+		{`is_upc("042100005264")`, true},
+		{`is_upc("036000291453")`, false},
+		{`is_upc("4006381333931")`, false},
+		{`is_upc("03600029145a")`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.call, func(t *testing.T) {
+			err := L.DoString(`return require("validation").` + tt.call)
+			if err != nil {
+				t.Fatalf("%s failed: %v", tt.call, err)
+			}
+			result := L.Get(-1).(lua.LBool)
+			L.Pop(1)
+			if bool(result) != tt.expected {
+				t.Errorf("Expected %v for %s, got %v", tt.expected, tt.call, result)
+			}
+		})
+	}
+}
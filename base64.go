@@ -0,0 +1,104 @@
+package validation
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// defaultMaxBase64Size is the largest decoded size, in bytes, accepted
+// unless a limit is given.
+const defaultMaxBase64Size = 10 << 20
+
+// base64Options selects the alphabet and padding accepted by decodeBase64.
+type base64Options struct {
+	urlSafe      bool
+	allowPadding bool
+	maxSize      int
+}
+
+var defaultBase64Options = base64Options{allowPadding: true, maxSize: defaultMaxBase64Size}
+
+// decodeBase64 strictly decodes s: line breaks and non-zero trailing bits
+// are rejected, and padding, when present, must be complete. Input that
+// would decode to more than opts.maxSize bytes is rejected before decoding.
+func decodeBase64(s string, opts base64Options) ([]byte, error) {
+	if s == "" {
+		return nil, fmt.Errorf("empty input")
+	}
+	if strings.ContainsAny(s, "\r\n") {
+		return nil, fmt.Errorf("line breaks are not allowed")
+	}
+
+	padded := strings.HasSuffix(s, "=")
+	if padded && !opts.allowPadding {
+		return nil, fmt.Errorf("padding is not allowed")
+	}
+	enc := base64.StdEncoding
+	if opts.urlSafe {
+		enc = base64.URLEncoding
+	}
+	if !padded {
+		enc = enc.WithPadding(base64.NoPadding)
+	}
+	enc = enc.Strict()
+
+	if opts.maxSize > 0 && enc.DecodedLen(len(s)) > opts.maxSize+2 {
+		return nil, fmt.Errorf("decoded size exceeds %d bytes", opts.maxSize)
+	}
+	data, err := enc.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if opts.maxSize > 0 && len(data) > opts.maxSize {
+		return nil, fmt.Errorf("decoded size exceeds %d bytes", opts.maxSize)
+	}
+	return data, nil
+}
+
+// IsBase64 reports whether s is standard base64, with or without padding,
+// that decodes to at most 10 MiB.
+func IsBase64(s string) bool {
+	_, err := decodeBase64(s, defaultBase64Options)
+	return err == nil
+}
+
+// IsBase64URL reports whether s is URL-safe base64, with or without
+// padding, that decodes to at most 10 MiB.
+func IsBase64URL(s string) bool {
+	opts := defaultBase64Options
+	opts.urlSafe = true
+	_, err := decodeBase64(s, opts)
+	return err == nil
+}
+
+// base64OptionsFrom reads base64 options from a Lua table.
+func base64OptionsFrom(opts *lua.LTable) base64Options {
+	o := defaultBase64Options
+	o.urlSafe = lua.LVAsBool(opts.RawGetString("url_safe"))
+	if v, ok := opts.RawGetString("allow_padding").(lua.LBool); ok {
+		o.allowPadding = bool(v)
+	}
+	if n, ok := opts.RawGetString("max_size").(lua.LNumber); ok {
+		o.maxSize = int(n)
+	}
+	return o
+}
+
+// isBase64 checks if a string decodes as base64 and returns the decoded size
+// Usage: validation.is_base64(str, {url_safe=false, allow_padding=true, max_size=10485760}) -> boolean, size?
+func isBase64(L *lua.LState) int {
+	str := L.CheckString(1)
+	opts := base64OptionsFrom(L.OptTable(2, L.NewTable()))
+
+	data, err := decodeBase64(str, opts)
+	if err != nil {
+		L.Push(lua.LBool(false))
+		return 1
+	}
+	L.Push(lua.LBool(true))
+	L.Push(lua.LNumber(len(data)))
+	return 2
+}
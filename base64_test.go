@@ -0,0 +1,72 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsBase64(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		args     string
+		expected bool
+	}{
+		{`"aGVsbG8="`, true},
+		{`"aGVsbG8"`, true},
+		{`"aGVsbG8gd29ybGQ="`, true},
+		{`"aGVsbG8gd29ybGQ"`, true},
+		{`"aGVsbG8=="`, false},
+		{`"aGVsbG9="`, false},
+		{`"aGVsbG8=", {allow_padding = false}`, false},
+		{`"aGVsbG8", {allow_padding = false}`, true},
+		{`"aGVs\nbG8="`, false},
+		{`"a"`, false},
+		{`""`, false},
+		{`"hello world"`, false},
+		{`"-_-_"`, false},
+		{`"-_-_", {url_safe = true}`, true},
+		{`"+/+/", {url_safe = true}`, false},
+		{`"aGVsbG8gd29ybGQ=", {max_size = 11}`, true},
+		{`"aGVsbG8gd29ybGQ=", {max_size = 10}`, false},
+	}
+
+	for _, tt := range tests {
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.is_base64(` + tt.args + `)
+		`); err != nil {
+			t.Fatalf("is_base64(%s): %v", tt.args, err)
+		}
+		if got := L.Get(1) == lua.LTrue; got != tt.expected {
+			t.Errorf("is_base64(%s) = %v, want %v", tt.args, got, tt.expected)
+		}
+		L.SetTop(0)
+	}
+}
+
+func TestIsBase64Size(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	if err := L.DoString(`
+		local validation = require("validation")
+		return validation.is_base64("aGVsbG8=")
+	`); err != nil {
+		t.Fatalf("is_base64 failed: %v", err)
+	}
+	if L.Get(-1) != lua.LNumber(5) {
+		t.Errorf("expected decoded size 5, got %v", L.Get(-1))
+	}
+
+	if IsBase64(strings.Repeat("AAAA", defaultMaxBase64Size/3+1)) {
+		t.Error("expected input over the default size limit to be rejected")
+	}
+}
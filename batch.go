@@ -0,0 +1,58 @@
+package validation
+
+import (
+	"fmt"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// validateAll runs a list of rules against the module's own validators and
+// collects the messages of every rule that fails, in rule order. The module
+// table is bound as the first upvalue so rules resolve to the same functions
+// scripts see.
+// Usage: validation.validate_all({{fn="min_length", args={"hi", 3}, message="..."}, ...}) -> boolean, table
+func validateAll(L *lua.LState) int {
+	mod := L.CheckTable(lua.UpvalueIndex(1))
+	rules := L.CheckTable(1)
+
+	errors := L.NewTable()
+	for i := 1; i <= rules.Len(); i++ {
+		rule, ok := rules.RawGetInt(i).(*lua.LTable)
+		if !ok {
+			L.ArgError(1, fmt.Sprintf("rule %d must be a table", i))
+		}
+		name, ok := rule.RawGetString("fn").(lua.LString)
+		if !ok {
+			L.ArgError(1, fmt.Sprintf("rule %d: fn must be a string", i))
+		}
+		fn, ok := mod.RawGetString(string(name)).(*lua.LFunction)
+		if !ok || string(name) == "validate_all" {
+			L.ArgError(1, fmt.Sprintf("rule %d: unknown validator %q", i, string(name)))
+		}
+
+		var args []lua.LValue
+		if argTable, ok := rule.RawGetString("args").(*lua.LTable); ok {
+			for j := 1; j <= argTable.Len(); j++ {
+				args = append(args, argTable.RawGetInt(j))
+			}
+		}
+
+		if err := L.CallByParam(lua.P{Fn: fn, NRet: 1, Protect: true}, args...); err != nil {
+			L.RaiseError("rule %d (%s): %v", i, string(name), err)
+		}
+		passed := lua.LVAsBool(L.Get(-1))
+		L.Pop(1)
+
+		if !passed {
+			message, ok := rule.RawGetString("message").(lua.LString)
+			if !ok {
+				message = lua.LString(stateOf(L).message("validator_failed", map[string]string{"fn": string(name)}))
+			}
+			errors.Append(message)
+		}
+	}
+
+	L.Push(lua.LBool(errors.Len() == 0))
+	L.Push(errors)
+	return 2
+}
@@ -0,0 +1,96 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestValidateAll(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local ok, errors = validation.validate_all({
+			{fn="min_length", args={"hi", 3}, message="name too short"},
+			{fn="validate_email", args={"user@example.com"}, message="invalid email"},
+			{fn="in_range", args={150, 18, 120}, message="age out of range"},
+			{fn="is_string", args={42}},
+		})
+		return ok, table.concat(errors, "|")
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("ValidateAll test failed: %v", err)
+	}
+
+	ok := L.Get(-2).(lua.LBool)
+	errors := L.Get(-1).String()
+
+	if bool(ok) {
+		t.Error("Expected false when some rules fail")
+	}
+	expected := "name too short|age out of range|is_string failed"
+	if errors != expected {
+		t.Errorf("Expected errors %q, got %q", expected, errors)
+	}
+}
+
+func TestValidateAllPassing(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local ok, errors = validation.validate_all({
+			{fn="min_length", args={"hello", 3}, message="name too short"},
+			{fn="is_number", args={42}, message="not a number"},
+		})
+		return ok, #errors
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("ValidateAll passing test failed: %v", err)
+	}
+
+	ok := L.Get(-2).(lua.LBool)
+	count := L.Get(-1).(lua.LNumber)
+
+	if !bool(ok) {
+		t.Error("Expected true when all rules pass")
+	}
+	if count != 0 {
+		t.Errorf("Expected no errors, got %v", count)
+	}
+}
+
+func TestValidateAllUnknownValidator(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		return validation.validate_all({
+			{fn="is_string", args={"ok"}},
+			{fn="no_such_validator", args={"x"}},
+		})
+	`
+
+	err := L.DoString(script)
+	if err == nil {
+		t.Fatal("Expected error for unknown validator")
+	}
+	if !strings.Contains(err.Error(), `rule 2: unknown validator "no_such_validator"`) {
+		t.Errorf("Expected unknown validator error, got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package validation
+
+import (
+	"container/list"
+	"sync"
+)
+
+// compileCache keeps the results of recent compilations so sources used
+// repeatedly, such as patterns or scripts, are compiled only once. The
+// least recently used result is evicted when the cache is full. Failed
+// compilations are not cached.
+type compileCache[T any] struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+	build   func(source string) (T, error)
+}
+
+type cacheEntry[T any] struct {
+	source string
+	value  T
+}
+
+func newCompileCache[T any](size int, build func(source string) (T, error)) *compileCache[T] {
+	return &compileCache[T]{size: size, order: list.New(), entries: map[string]*list.Element{}, build: build}
+}
+
+// compile returns the compiled source, compiling and caching it on a miss.
+func (c *compileCache[T]) compile(source string) (T, error) {
+	c.mu.Lock()
+	if elem, ok := c.entries[source]; ok {
+		c.order.MoveToFront(elem)
+		c.mu.Unlock()
+		return elem.Value.(*cacheEntry[T]).value, nil
+	}
+	c.mu.Unlock()
+
+	value, err := c.build(source)
+	if err != nil {
+		return value, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[source]; ok {
+		c.order.MoveToFront(elem)
+		return elem.Value.(*cacheEntry[T]).value, nil
+	}
+	c.entries[source] = c.order.PushFront(&cacheEntry[T]{source: source, value: value})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry[T]).source)
+	}
+	return value, nil
+}
@@ -0,0 +1,219 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// Card numbers (PANs) in use are 12 to 19 digits long.
+const (
+	minCardDigits = 12
+	maxCardDigits = 19
+)
+
+// cardSeparators are the characters allowed between groups of card digits.
+var cardSeparators = strings.NewReplacer(" ", "", "-", "")
+
+// cardDigits removes spaces and hyphens from a card number and reports
+// whether the rest is a plausible number of digits.
+func cardDigits(s string) (string, bool) {
+	digits := cardSeparators.Replace(s)
+	return digits, isDigits(digits) && len(digits) >= minCardDigits && len(digits) <= maxCardDigits
+}
+
+// luhnValid reports whether a string of digits has a valid Luhn (mod 10)
+// check digit.
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// IsCreditCard reports whether s is a payment card number: 12 to 19 digits,
+// optionally grouped with spaces or hyphens, with a valid Luhn check digit.
+func IsCreditCard(s string) bool {
+	digits, ok := cardDigits(s)
+	return ok && luhnValid(digits)
+}
+
+// isCreditCard checks if a string is a payment card number with a valid checksum
+// Usage: validation.is_credit_card(str) -> boolean
+func isCreditCard(L *lua.LState) int {
+	L.Push(lua.LBool(IsCreditCard(L.CheckString(1))))
+	return 1
+}
+
+// cardBrand describes the numbers issued under a card brand.
+type cardBrand struct {
+	name      string
+	iins      []iinRange
+	lengths   []int
+	cvvLength int
+}
+
+// iinRange is an inclusive range of issuer identification number prefixes
+// of equal length, such as 2221-2720.
+type iinRange struct{ low, high string }
+
+// matches reports whether the number starts with a prefix in the range.
+func (r iinRange) matches(digits string) bool {
+	if len(digits) < len(r.low) {
+		return false
+	}
+	prefix := digits[:len(r.low)]
+	return prefix >= r.low && prefix <= r.high
+}
+
+func iin(prefix string) iinRange { return iinRange{prefix, prefix} }
+
+// cardBrands lists brands with their IIN ranges and card lengths. More
+// specific ranges come first: Discover co-brands part of the UnionPay range.
+var cardBrands = []cardBrand{
+	{"amex", []iinRange{iin("34"), iin("37")}, []int{15}, 4},
+	{"visa", []iinRange{iin("4")}, []int{13, 16, 19}, 3},
+	{"mir", []iinRange{{"2200", "2204"}}, []int{16, 17, 18, 19}, 3},
+	{"mastercard", []iinRange{{"51", "55"}, {"2221", "2720"}}, []int{16}, 3},
+	{"maestro", []iinRange{iin("5018"), iin("5020"), iin("5038"), iin("5893"), iin("6304"),
+		iin("6759"), {"6761", "6763"}}, []int{12, 13, 14, 15, 16, 17, 18, 19}, 3},
+	{"discover", []iinRange{iin("6011"), {"644", "649"}, iin("65"), {"622126", "622925"}},
+		[]int{16, 17, 18, 19}, 3},
+	{"unionpay", []iinRange{iin("62")}, []int{16, 17, 18, 19}, 3},
+	{"jcb", []iinRange{{"3528", "3589"}}, []int{16, 17, 18, 19}, 3},
+	{"diners", []iinRange{{"300", "305"}, iin("3095"), iin("36"), {"38", "39"}},
+		[]int{14, 15, 16, 17, 18, 19}, 3},
+}
+
+// brandOf returns the brand whose IIN ranges and lengths match a string of
+// card digits, or nil.
+func brandOf(digits string) *cardBrand {
+	for i := range cardBrands {
+		brand := &cardBrands[i]
+		for _, r := range brand.iins {
+			if r.matches(digits) && slices.Contains(brand.lengths, len(digits)) {
+				return brand
+			}
+		}
+	}
+	return nil
+}
+
+// CardBrand returns the brand of a card number, such as "visa",
+// "mastercard" or "amex", based on its IIN prefix and length, or "" if the
+// brand is unknown. Spaces and hyphens are ignored; the checksum is not
+// verified, use IsCreditCard for that.
+func CardBrand(s string) string {
+	digits, ok := cardDigits(s)
+	if !ok {
+		return ""
+	}
+	if brand := brandOf(digits); brand != nil {
+		return brand.name
+	}
+	return ""
+}
+
+// cardBrandOf returns the brand of a card number
+// Usage: validation.card_brand(str) -> string | nil
+func cardBrandOf(L *lua.LState) int {
+	if brand := CardBrand(L.CheckString(1)); brand != "" {
+		L.Push(lua.LString(brand))
+		return 1
+	}
+	L.Push(lua.LNil)
+	return 1
+}
+
+// parseCardExpiry parses an expiry date in MM/YY or MM/YYYY form, allowing
+// spaces around the slash, and returns the first instant after the card
+// expires: the start of the following month in loc.
+func parseCardExpiry(s string, loc *time.Location) (time.Time, bool) {
+	month, year, found := strings.Cut(s, "/")
+	month, year = strings.TrimSpace(month), strings.TrimSpace(year)
+	if !found || len(month) != 2 || !isDigits(month) || !isDigits(year) {
+		return time.Time{}, false
+	}
+
+	m, _ := strconv.Atoi(month)
+	y, _ := strconv.Atoi(year)
+	switch len(year) {
+	case 2:
+		y += 2000
+	case 4:
+	default:
+		return time.Time{}, false
+	}
+	if m < 1 || m > 12 {
+		return time.Time{}, false
+	}
+	return time.Date(y, time.Month(m)+1, 1, 0, 0, 0, 0, loc), true
+}
+
+// IsCardExpiry reports whether s is a card expiry date in MM/YY or MM/YYYY
+// form that has not passed at now. Cards are valid through the last day of
+// their expiry month.
+func IsCardExpiry(s string, now time.Time) bool {
+	expires, ok := parseCardExpiry(s, now.Location())
+	return ok && now.Before(expires)
+}
+
+// isCardExpiry checks if a card expiry date is well-formed and not in the past
+// Usage: validation.is_card_expiry(str) -> boolean
+func isCardExpiry(L *lua.LState) int {
+	str := L.CheckString(1)
+	L.Push(lua.LBool(IsCardExpiry(str, stateOf(L).options.now())))
+	return 1
+}
+
+// brandNamed returns the brand with the given name, or nil.
+func brandNamed(name string) *cardBrand {
+	for i := range cardBrands {
+		if cardBrands[i].name == name {
+			return &cardBrands[i]
+		}
+	}
+	return nil
+}
+
+// IsCVV reports whether s is a card security code (CVV/CVC): 4 digits for
+// American Express and 3 for other brands. brand is a name returned by
+// CardBrand; an empty or unknown brand accepts 3 or 4 digits.
+func IsCVV(s, brand string) bool {
+	if !isDigits(s) {
+		return false
+	}
+	if b := brandNamed(brand); b != nil {
+		return len(s) == b.cvvLength
+	}
+	return len(s) == 3 || len(s) == 4
+}
+
+// isCVV checks if a string is a card security code, optionally for a brand or card number
+// Usage: validation.is_cvv(str, brand_or_number?) -> boolean
+func isCVV(L *lua.LState) int {
+	str := L.CheckString(1)
+	brand := L.OptString(2, "")
+	if brand != "" && brandNamed(brand) == nil {
+		if _, ok := cardDigits(brand); !ok {
+			L.ArgError(2, fmt.Sprintf("unknown card brand %q", brand))
+		}
+		brand = CardBrand(brand)
+	}
+	L.Push(lua.LBool(IsCVV(str, brand)))
+	return 1
+}
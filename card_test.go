@@ -0,0 +1,181 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsCreditCard(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		number   string
+		expected bool
+	}{
+		{"4111111111111111", true},
+		{"4111 1111 1111 1111", true},
+		{"5555-5555-5555-4444", true},
+		{"378282246310005", true},
+		{"6011111111111117", true},
+		{"4111111111111112", false},
+		{"4111.1111.1111.1111", false},
+		{"41111111111", false},
+		{"41111111111111111111", false},
+		{"4111 1111 1111 111a", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			err := L.DoString(`return require("validation").is_credit_card("` + tt.number + `")`)
+			if err != nil {
+				t.Fatalf("is_credit_card failed: %v", err)
+			}
+			result := L.Get(-1).(lua.LBool)
+			L.Pop(1)
+			if bool(result) != tt.expected {
+				t.Errorf("Expected %v for %s, got %v", tt.expected, tt.number, result)
+			}
+		})
+	}
+}
+
+func TestCardBrand(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		number   string
+		expected string
+	}{
+		{"4111 1111 1111 1111", "visa"},
+		{"5555555555554444", "mastercard"},
+		{"2221000000000009", "mastercard"},
+		{"378282246310005", "amex"},
+		{"6011111111111117", "discover"},
+		{"6221260000000000", "discover"},
+		{"6200000000000005", "unionpay"},
+		{"3530111333300000", "jcb"},
+		{"30569309025904", "diners"},
+		{"6759649826438453", "maestro"},
+		{"2200123456789010", "mir"},
+		{"37828224631000", ""},
+		{"9999999999999999", ""},
+		{"not a card", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			err := L.DoString(`return require("validation").card_brand("` + tt.number + `")`)
+			if err != nil {
+				t.Fatalf("card_brand failed: %v", err)
+			}
+			result := L.Get(-1)
+			L.Pop(1)
+			if tt.expected == "" {
+				if result != lua.LNil {
+					t.Errorf("Expected nil for %s, got %v", tt.number, result)
+				}
+				return
+			}
+			if result.String() != tt.expected {
+				t.Errorf("Expected %s for %s, got %v", tt.expected, tt.number, result)
+			}
+		})
+	}
+}
+
+func TestIsCardExpiry(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	now := time.Date(2025, time.June, 30, 23, 59, 0, 0, time.UTC)
+	L.PreloadModule("validation", NewLoader(Options{Now: func() time.Time { return now }}))
+
+	tests := []struct {
+		expiry   string
+		expected bool
+	}{
+		{"06/25", true},
+		{"06/2025", true},
+		{"07 / 25", true},
+		{"12/30", true},
+		{"05/25", false},
+		{"12/2024", false},
+		{"13/25", false},
+		{"00/26", false},
+		{"6/26", false},
+		{"06/026", false},
+		{"0626", false},
+		{"ab/cd", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expiry, func(t *testing.T) {
+			err := L.DoString(`return require("validation").is_card_expiry("` + tt.expiry + `")`)
+			if err != nil {
+				t.Fatalf("is_card_expiry failed: %v", err)
+			}
+			result := L.Get(-1).(lua.LBool)
+			L.Pop(1)
+			if bool(result) != tt.expected {
+				t.Errorf("Expected %v for %s, got %v", tt.expected, tt.expiry, result)
+			}
+		})
+	}
+
+	if IsCardExpiry("06/25", now.Add(time.Minute)) {
+		t.Error("Expected 06/25 to expire at the start of July")
+	}
+}
+
+func TestIsCVV(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		args     string
+		expected bool
+	}{
+		{`"123"`, true},
+		{`"1234"`, true},
+		{`"12"`, false},
+		{`"12a"`, false},
+		{`"123", "visa"`, true},
+		{`"1234", "visa"`, false},
+		{`"1234", "amex"`, true},
+		{`"123", "amex"`, false},
+		{`"1234", "3782 822463 10005"`, true},
+		{`"123", "4111111111111111"`, true},
+		{`"1234", "9999999999999999"`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			err := L.DoString(`return require("validation").is_cvv(` + tt.args + `)`)
+			if err != nil {
+				t.Fatalf("is_cvv failed: %v", err)
+			}
+			result := L.Get(-1).(lua.LBool)
+			L.Pop(1)
+			if bool(result) != tt.expected {
+				t.Errorf("Expected %v for %s, got %v", tt.expected, tt.args, result)
+			}
+		})
+	}
+
+	err := L.DoString(`require("validation").is_cvv("123", "visacard")`)
+	if err == nil || !strings.Contains(err.Error(), `unknown card brand "visacard"`) {
+		t.Errorf("Expected unknown brand error, got %v", err)
+	}
+}
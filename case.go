@@ -0,0 +1,81 @@
+package validation
+
+import (
+	"regexp"
+	"unicode"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// caseStyles maps identifier naming styles to their patterns. Identifiers
+// are ASCII, start with a letter, and separate words with "_", "-" or an
+// upper-case letter. Digits may appear anywhere after the first letter.
+var caseStyles = map[string]*regexp.Regexp{
+	"snake_case":    regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`),
+	"constant_case": regexp.MustCompile(`^[A-Z][A-Z0-9]*(_[A-Z0-9]+)*$`),
+	"kebab_case":    regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`),
+	"camel_case":    regexp.MustCompile(`^[a-z][a-z0-9]*([A-Z][a-z0-9]*)*$`),
+	"pascal_case":   regexp.MustCompile(`^[A-Z][a-z0-9]*([A-Z][a-z0-9]*)*$`),
+}
+
+// IsLowercase reports whether s is non-empty valid UTF-8 without upper-case
+// or title-case letters. Digits, punctuation and letters without case are
+// allowed.
+func IsLowercase(s string) bool {
+	return s != "" && IsUTF8(s) && !containsRune(s, func(r rune) bool {
+		return unicode.IsUpper(r) || unicode.IsTitle(r)
+	})
+}
+
+// IsUppercase reports whether s is non-empty valid UTF-8 without lower-case
+// or title-case letters.
+func IsUppercase(s string) bool {
+	return s != "" && IsUTF8(s) && !containsRune(s, func(r rune) bool {
+		return unicode.IsLower(r) || unicode.IsTitle(r)
+	})
+}
+
+// containsRune reports whether any rune of s satisfies f.
+func containsRune(s string, f func(r rune) bool) bool {
+	for _, r := range s {
+		if f(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCaseStyle reports whether s is an identifier in the naming style, one of
+// "snake_case", "constant_case", "kebab_case", "camel_case" and
+// "pascal_case". Unknown styles never match.
+func IsCaseStyle(s, style string) bool {
+	re, ok := caseStyles[style]
+	return ok && re.MatchString(s)
+}
+
+// isLowercase checks if a string has no upper-case letters
+// Usage: validation.is_lowercase(str) -> boolean
+func isLowercase(L *lua.LState) int {
+	str := L.CheckString(1)
+	L.Push(lua.LBool(IsLowercase(str)))
+	return 1
+}
+
+// isUppercase checks if a string has no lower-case letters
+// Usage: validation.is_uppercase(str) -> boolean
+func isUppercase(L *lua.LState) int {
+	str := L.CheckString(1)
+	L.Push(lua.LBool(IsUppercase(str)))
+	return 1
+}
+
+// caseStyleFunc returns a Lua function checking an identifier's naming style
+// Usage: validation.is_snake_case(str) -> boolean
+func caseStyleFunc(style string) lua.LGFunction {
+	re := caseStyles[style]
+	return func(L *lua.LState) int {
+		str := L.CheckString(1)
+		L.Push(lua.LBool(re.MatchString(str)))
+		return 1
+	}
+}
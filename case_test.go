@@ -0,0 +1,72 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestCaseValidators(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		fn       string
+		input    string
+		expected bool
+	}{
+		{"is_lowercase", "hello world 42!", true},
+		{"is_lowercase", "straße", true},
+		{"is_lowercase", "123", true},
+		{"is_lowercase", "Hello", false},
+		{"is_lowercase", "\u01c5", false},
+		{"is_lowercase", "", false},
+		{"is_lowercase", "caf\xe9", false},
+		{"is_uppercase", "HELLO-42", true},
+		{"is_uppercase", "İSTANBUL", true},
+		{"is_uppercase", "HELLo", false},
+		{"is_uppercase", "", false},
+
+		{"is_snake_case", "user_id", true},
+		{"is_snake_case", "address_line2", true},
+		{"is_snake_case", "user", true},
+		{"is_snake_case", "User_id", false},
+		{"is_snake_case", "user__id", false},
+		{"is_snake_case", "_user", false},
+		{"is_snake_case", "user_", false},
+		{"is_snake_case", "2fa_code", false},
+		{"is_constant_case", "MAX_RETRIES", true},
+		{"is_constant_case", "HTTP2_PORT", true},
+		{"is_constant_case", "Max_Retries", false},
+		{"is_kebab_case", "my-component", true},
+		{"is_kebab_case", "my_component", false},
+		{"is_kebab_case", "my--component", false},
+		{"is_camel_case", "userId", true},
+		{"is_camel_case", "parseHTTPRequest", true},
+		{"is_camel_case", "user", true},
+		{"is_camel_case", "UserId", false},
+		{"is_camel_case", "user_id", false},
+		{"is_pascal_case", "UserProfile", true},
+		{"is_pascal_case", "User2", true},
+		{"is_pascal_case", "userProfile", false},
+		{"is_pascal_case", "User-Profile", false},
+	}
+
+	for _, tt := range tests {
+		L.SetGlobal("input", lua.LString(tt.input))
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.` + tt.fn + `(input)
+		`); err != nil {
+			t.Fatalf("%s(%q): %v", tt.fn, tt.input, err)
+		}
+
+		result := L.Get(-1)
+		L.Pop(1)
+		if result != lua.LBool(tt.expected) {
+			t.Errorf("%s(%q) = %v, want %v", tt.fn, tt.input, result, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package validation
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// IsASCII reports whether every byte of s is ASCII.
+func IsASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// IsPrintable reports whether s is valid UTF-8 made of graphic characters
+// and spaces only. Control characters such as tabs and newlines, invisible
+// formatting characters such as bidirectional overrides, private-use and
+// unassigned code points are rejected.
+func IsPrintable(s string) bool {
+	if !IsUTF8(s) {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsGraphic(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isPrintableASCII reports whether s consists of printable ASCII characters,
+// from space to tilde.
+func isPrintableASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < ' ' || s[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// isASCII checks if a string contains only ASCII characters
+// Usage: validation.is_ascii(str) -> boolean
+func isASCII(L *lua.LState) int {
+	str := L.CheckString(1)
+	L.Push(lua.LBool(IsASCII(str)))
+	return 1
+}
+
+// isPrintable checks if a string contains no control or invisible characters
+// Usage: validation.is_printable(str) -> boolean
+func isPrintable(L *lua.LState) int {
+	str := L.CheckString(1)
+	L.Push(lua.LBool(IsPrintable(str)))
+	return 1
+}
+
+// charClass describes the characters allowed by is_alpha, is_alphanumeric
+// and is_numeric_string in ASCII and Unicode mode.
+type charClass struct {
+	ascii   func(c byte) bool
+	unicode func(r rune) bool
+}
+
+var (
+	alphaClass = charClass{
+		ascii:   isASCIILetter,
+		unicode: unicode.IsLetter,
+	}
+	alphanumericClass = charClass{
+		ascii:   func(c byte) bool { return isASCIILetter(c) || isASCIIDigit(c) },
+		unicode: func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) },
+	}
+	numericClass = charClass{
+		ascii:   isASCIIDigit,
+		unicode: unicode.IsDigit,
+	}
+)
+
+// isASCIILetter reports whether c is an ASCII letter.
+func isASCIILetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// isASCIIDigit reports whether c is an ASCII digit.
+func isASCIIDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// matches reports whether s is a non-empty string of characters in the
+// class. In Unicode mode s must be valid UTF-8, and combining marks may
+// follow an allowed character, so decomposed accents such as "é" count.
+func (c charClass) matches(s string, unicodeMode bool) bool {
+	if s == "" {
+		return false
+	}
+	if !unicodeMode {
+		for i := 0; i < len(s); i++ {
+			if !c.ascii(s[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if !IsUTF8(s) {
+		return false
+	}
+	for i, r := range s {
+		if !c.unicode(r) && (i == 0 || !unicode.Is(unicode.M, r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsAlpha reports whether s is a non-empty string of letters, ASCII only
+// unless unicodeMode is set.
+func IsAlpha(s string, unicodeMode bool) bool {
+	return alphaClass.matches(s, unicodeMode)
+}
+
+// IsAlphanumeric reports whether s is a non-empty string of letters and
+// digits, ASCII only unless unicodeMode is set.
+func IsAlphanumeric(s string, unicodeMode bool) bool {
+	return alphanumericClass.matches(s, unicodeMode)
+}
+
+// IsNumericString reports whether s is a non-empty string of decimal digits,
+// ASCII only unless unicodeMode is set. Signs and decimal points are not
+// digits.
+func IsNumericString(s string, unicodeMode bool) bool {
+	return numericClass.matches(s, unicodeMode)
+}
+
+// charClassFunc returns a Lua function checking a string against a class
+// Usage: validation.is_alpha(str, {unicode=false}) -> boolean
+func charClassFunc(c charClass) lua.LGFunction {
+	return func(L *lua.LState) int {
+		str := L.CheckString(1)
+		opts := L.OptTable(2, L.NewTable())
+		L.Push(lua.LBool(c.matches(str, lua.LVAsBool(opts.RawGetString("unicode")))))
+		return 1
+	}
+}
@@ -0,0 +1,138 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsASCII(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"", true},
+		{"user_42", true},
+		{"tab\there", true},
+		{"\x00\x7f", true},
+		{"café", false},
+		{"\x80", false},
+	}
+
+	for _, tt := range tests {
+		L.SetGlobal("input", lua.LString(tt.input))
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.is_ascii(input)
+		`); err != nil {
+			t.Fatalf("is_ascii(%q): %v", tt.input, err)
+		}
+
+		result := L.Get(-1)
+		L.Pop(1)
+		if result != lua.LBool(tt.expected) {
+			t.Errorf("is_ascii(%q) = %v, want %v", tt.input, result, tt.expected)
+		}
+	}
+}
+
+func TestIsPrintable(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"", true},
+		{"Hello, World!", true},
+		{"İstanbul — 2024 ✓", true},
+		{"non\u00a0breaking", true},
+		{"tab\there", false},
+		{"line\nbreak", false},
+		{"nul\x00", false},
+		{"del\x7f", false},
+		{"c1\u0085", false},
+		{"bidi\u202eevil", false},
+		{"zero\u200bwidth", false},
+		{"private\ue000", false},
+		{"caf\xe9", false},
+	}
+
+	for _, tt := range tests {
+		L.SetGlobal("input", lua.LString(tt.input))
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.is_printable(input)
+		`); err != nil {
+			t.Fatalf("is_printable(%q): %v", tt.input, err)
+		}
+
+		result := L.Get(-1)
+		L.Pop(1)
+		if result != lua.LBool(tt.expected) {
+			t.Errorf("is_printable(%q) = %v, want %v", tt.input, result, tt.expected)
+		}
+	}
+}
+
+func TestCharClasses(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		fn       string
+		input    string
+		unicode  bool
+		expected bool
+	}{
+		{"is_alpha", "Hello", false, true},
+		{"is_alpha", "", false, false},
+		{"is_alpha", "Hello1", false, false},
+		{"is_alpha", "héllo", false, false},
+		{"is_alpha", "héllo", true, true},
+		{"is_alpha", "he\u0301llo", true, true},
+		{"is_alpha", "\u0301e", true, false},
+		{"is_alpha", "Привет", true, true},
+		{"is_alpha", "two words", true, false},
+		{"is_alpha", "caf\xe9", true, false},
+		{"is_alphanumeric", "user42", false, true},
+		{"is_alphanumeric", "user_42", false, false},
+		{"is_alphanumeric", "kullanıcı42", false, false},
+		{"is_alphanumeric", "kullanıcı42", true, true},
+		{"is_alphanumeric", "名前123", true, true},
+		{"is_numeric_string", "00123", false, true},
+		{"is_numeric_string", "-1", false, false},
+		{"is_numeric_string", "1.5", false, false},
+		{"is_numeric_string", "", false, false},
+		{"is_numeric_string", "\u0661\u0662\u0663", false, false},
+		{"is_numeric_string", "\u0661\u0662\u0663", true, true},
+		{"is_numeric_string", "½", true, false},
+	}
+
+	for _, tt := range tests {
+		L.SetGlobal("input", lua.LString(tt.input))
+		L.SetGlobal("unicode", lua.LBool(tt.unicode))
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.` + tt.fn + `(input, {unicode = unicode})
+		`); err != nil {
+			t.Fatalf("%s(%q): %v", tt.fn, tt.input, err)
+		}
+
+		result := L.Get(-1)
+		L.Pop(1)
+		if result != lua.LBool(tt.expected) {
+			t.Errorf("%s(%q, {unicode = %v}) = %v, want %v", tt.fn, tt.input, tt.unicode, result, tt.expected)
+		}
+	}
+}
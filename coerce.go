@@ -0,0 +1,74 @@
+package validation
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// coerceNumber converts a number or numeric string to a number
+// Usage: validation.coerce_number(value) -> number|nil
+func coerceNumber(L *lua.LState) int {
+	value := L.CheckAny(1)
+
+	if n, ok := toNumber(value); ok {
+		L.Push(n)
+		return 1
+	}
+
+	L.Push(lua.LNil)
+	return 1
+}
+
+// toNumber converts a number or finite numeric string to a number.
+func toNumber(value lua.LValue) (lua.LNumber, bool) {
+	switch v := value.(type) {
+	case lua.LNumber:
+		return v, true
+	case lua.LString:
+		n, err := strconv.ParseFloat(strings.TrimSpace(string(v)), 64)
+		if err == nil && !math.IsNaN(n) && !math.IsInf(n, 0) {
+			return lua.LNumber(n), true
+		}
+	}
+	return 0, false
+}
+
+// coerceBoolean converts true/false, "true"/"false", "1"/"0" and 1/0 to a boolean
+// Usage: validation.coerce_boolean(value) -> boolean|nil
+func coerceBoolean(L *lua.LState) int {
+	value := L.CheckAny(1)
+
+	if b, ok := toBoolean(value); ok {
+		L.Push(b)
+		return 1
+	}
+
+	L.Push(lua.LNil)
+	return 1
+}
+
+// toBoolean converts true/false, "true"/"false", "1"/"0" and 1/0 to a boolean.
+func toBoolean(value lua.LValue) (lua.LBool, bool) {
+	switch v := value.(type) {
+	case lua.LBool:
+		return v, true
+	case lua.LString:
+		switch string(v) {
+		case "true", "1":
+			return lua.LTrue, true
+		case "false", "0":
+			return lua.LFalse, true
+		}
+	case lua.LNumber:
+		switch v {
+		case 1:
+			return lua.LTrue, true
+		case 0:
+			return lua.LFalse, true
+		}
+	}
+	return false, false
+}
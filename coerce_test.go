@@ -0,0 +1,89 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestCoerceNumber(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected lua.LValue
+	}{
+		{"numeric string", `"42"`, lua.LNumber(42)},
+		{"float string with spaces", `" 3.5 "`, lua.LNumber(3.5)},
+		{"number", "7", lua.LNumber(7)},
+		{"non-numeric string", `"abc"`, lua.LNil},
+		{"infinity string", `"inf"`, lua.LNil},
+		{"table", "{}", lua.LNil},
+		{"nil", "nil", lua.LNil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script := `
+				local validation = require("validation")
+				return validation.coerce_number(` + tt.value + `)
+			`
+
+			err := L.DoString(script)
+			if err != nil {
+				t.Fatalf("CoerceNumber test failed: %v", err)
+			}
+
+			result := L.Get(-1)
+			if result != tt.expected {
+				t.Errorf("Expected %v for %s, got %v", tt.expected, tt.value, result)
+			}
+		})
+	}
+}
+
+func TestCoerceBoolean(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected lua.LValue
+	}{
+		{"true string", `"true"`, lua.LTrue},
+		{"false string", `"false"`, lua.LFalse},
+		{"one string", `"1"`, lua.LTrue},
+		{"zero string", `"0"`, lua.LFalse},
+		{"one number", "1", lua.LTrue},
+		{"zero number", "0", lua.LFalse},
+		{"boolean", "false", lua.LFalse},
+		{"yes string", `"yes"`, lua.LNil},
+		{"other number", "2", lua.LNil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script := `
+				local validation = require("validation")
+				return validation.coerce_boolean(` + tt.value + `)
+			`
+
+			err := L.DoString(script)
+			if err != nil {
+				t.Fatalf("CoerceBoolean test failed: %v", err)
+			}
+
+			result := L.Get(-1)
+			if result != tt.expected {
+				t.Errorf("Expected %v for %s, got %v", tt.expected, tt.value, result)
+			}
+		})
+	}
+}
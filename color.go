@@ -0,0 +1,158 @@
+package validation
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// hexColor matches #rgb, #rgba, #rrggbb and #rrggbbaa.
+var hexColor = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+
+// cssNumber matches a non-negative CSS number without an exponent.
+var cssNumber = regexp.MustCompile(`^(?:\d+(?:\.\d+)?|\.\d+)$`)
+
+// hueUnits are the angle units accepted for the hue of hsl().
+var hueUnits = []string{"deg", "grad", "rad", "turn"}
+
+// IsHexColor reports whether s is a CSS hex color such as "#fff", "#ffff",
+// "#ff8800" or "#ff880080".
+func IsHexColor(s string) bool {
+	return hexColor.MatchString(s)
+}
+
+// IsRGB reports whether s is a CSS rgb() or rgba() color, in the legacy
+// comma-separated form "rgb(255, 136, 0)" or the space-separated form
+// "rgb(255 136 0 / 50%)", with an optional alpha.
+func IsRGB(s string) bool {
+	args, alpha, legacy, ok := colorFunction(s, "rgb")
+	if !ok || !isAlpha(alpha) {
+		return false
+	}
+	percent := strings.HasSuffix(args[0], "%")
+	for _, arg := range args {
+		// The legacy syntax does not allow numbers and percentages to mix.
+		if legacy && strings.HasSuffix(arg, "%") != percent {
+			return false
+		}
+		if !isPercentage(arg) && !isNumberWithin(arg, 255) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsHSL reports whether s is a CSS hsl() or hsla() color such as
+// "hsl(30, 100%, 50%)" or "hsl(30deg 100% 50% / 0.5)".
+func IsHSL(s string) bool {
+	args, alpha, legacy, ok := colorFunction(s, "hsl")
+	if !ok || !isAlpha(alpha) || !isHue(args[0]) {
+		return false
+	}
+	for _, arg := range args[1:] {
+		// The space-separated syntax also allows bare numbers from 0 to 100.
+		if !isPercentage(arg) && (legacy || !isNumberWithin(arg, 100)) {
+			return false
+		}
+	}
+	return true
+}
+
+// colorFunction splits a CSS color function call such as "rgb(1, 2, 3)" or
+// "rgba(1 2 3 / 50%)" into its three arguments and optional alpha. legacy
+// reports whether the arguments were separated by commas.
+func colorFunction(s, name string) (args []string, alpha string, legacy, ok bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	inner, found := strings.CutPrefix(s, name+"a(")
+	if !found {
+		if inner, found = strings.CutPrefix(s, name+"("); !found {
+			return nil, "", false, false
+		}
+	}
+	inner, found = strings.CutSuffix(inner, ")")
+	if !found {
+		return nil, "", false, false
+	}
+
+	if strings.Contains(inner, ",") {
+		args = strings.Split(inner, ",")
+		for i := range args {
+			args[i] = strings.TrimSpace(args[i])
+		}
+		if len(args) == 4 {
+			args, alpha = args[:3], args[3]
+			if alpha == "" {
+				return nil, "", false, false
+			}
+		}
+		return args, alpha, true, len(args) == 3
+	}
+
+	if before, after, found := strings.Cut(inner, "/"); found {
+		inner, alpha = before, strings.TrimSpace(after)
+		if alpha == "" {
+			return nil, "", false, false
+		}
+	}
+	args = strings.Fields(inner)
+	return args, alpha, false, len(args) == 3
+}
+
+// isNumberWithin reports whether s is a number from 0 to max.
+func isNumberWithin(s string, max float64) bool {
+	if !cssNumber.MatchString(s) {
+		return false
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	return err == nil && n <= max
+}
+
+// isPercentage reports whether s is a percentage from 0% to 100%.
+func isPercentage(s string) bool {
+	n, ok := strings.CutSuffix(s, "%")
+	return ok && isNumberWithin(n, 100)
+}
+
+// isAlpha reports whether s is empty or an alpha value from 0 to 1 or 0%
+// to 100%.
+func isAlpha(s string) bool {
+	return s == "" || isNumberWithin(s, 1) || isPercentage(s)
+}
+
+// isHue reports whether s is an angle, with or without a unit. Hues wrap
+// around, so any value is allowed.
+func isHue(s string) bool {
+	for _, unit := range hueUnits {
+		if n, ok := strings.CutSuffix(s, unit); ok {
+			s = n
+			break
+		}
+	}
+	return cssNumber.MatchString(strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+"))
+}
+
+// isHexColor checks if a string is a CSS hex color
+// Usage: validation.is_hex_color(str) -> boolean
+func isHexColor(L *lua.LState) int {
+	str := L.CheckString(1)
+	L.Push(lua.LBool(IsHexColor(str)))
+	return 1
+}
+
+// isRGB checks if a string is a CSS rgb() or rgba() color
+// Usage: validation.is_rgb(str) -> boolean
+func isRGB(L *lua.LState) int {
+	str := L.CheckString(1)
+	L.Push(lua.LBool(IsRGB(str)))
+	return 1
+}
+
+// isHSL checks if a string is a CSS hsl() or hsla() color
+// Usage: validation.is_hsl(str) -> boolean
+func isHSL(L *lua.LState) int {
+	str := L.CheckString(1)
+	L.Push(lua.LBool(IsHSL(str)))
+	return 1
+}
@@ -0,0 +1,65 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestColors(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		call     string
+		expected bool
+	}{
+		{`is_hex_color("#fff")`, true},
+		{`is_hex_color("#FFFA")`, true},
+		{`is_hex_color("#ff8800")`, true},
+		{`is_hex_color("#ff880080")`, true},
+		{`is_hex_color("ff8800")`, false},
+		{`is_hex_color("#ff88")`, true},
+		{`is_hex_color("#ff880")`, false},
+		{`is_hex_color("#ggg")`, false},
+		{`is_rgb("rgb(255, 136, 0)")`, true},
+		{`is_rgb("RGB(255,136,0)")`, true},
+		{`is_rgb("rgba(255, 136, 0, 0.5)")`, true},
+		{`is_rgb("rgb(100%, 50%, 0%)")`, true},
+		{`is_rgb("rgb(255 136 0)")`, true},
+		{`is_rgb("rgb(255 136 0 / 50%)")`, true},
+		{`is_rgb("rgb(100% 136 0)")`, true},
+		{`is_rgb("rgb(100%, 136, 0)")`, false},
+		{`is_rgb("rgb(256, 0, 0)")`, false},
+		{`is_rgb("rgb(-1, 0, 0)")`, false},
+		{`is_rgb("rgb(255, 0)")`, false},
+		{`is_rgb("rgb(255, 0, 0,)")`, false},
+		{`is_rgb("rgba(255, 0, 0, 1.5)")`, false},
+		{`is_rgb("rgb(255 0 0 /)")`, false},
+		{`is_rgb("rgb(255, 0 0)")`, false},
+		{`is_rgb("rgb(255 0 0")`, false},
+		{`is_hsl("hsl(30, 100%, 50%)")`, true},
+		{`is_hsl("hsla(30, 100%, 50%, 0.3)")`, true},
+		{`is_hsl("hsl(-120deg 100% 50%)")`, true},
+		{`is_hsl("hsl(0.5turn 60 40 / 25%)")`, true},
+		{`is_hsl("hsl(30, 100, 50)")`, false},
+		{`is_hsl("hsl(30, 101%, 50%)")`, false},
+		{`is_hsl("hsl(30px, 100%, 50%)")`, false},
+		{`is_hsl("rgb(30, 100%, 50%)")`, false},
+	}
+
+	for _, tt := range tests {
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.` + tt.call + `
+		`); err != nil {
+			t.Fatalf("%s: %v", tt.call, err)
+		}
+		if got := L.Get(-1) == lua.LTrue; got != tt.expected {
+			t.Errorf("%s = %v, want %v", tt.call, got, tt.expected)
+		}
+		L.Pop(1)
+	}
+}
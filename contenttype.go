@@ -0,0 +1,136 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net/http"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// sniffLen is the number of leading bytes examined by DetectContentType.
+const sniffLen = 512
+
+// magicSignature identifies a content type by bytes at a fixed offset.
+type magicSignature struct {
+	offset      int
+	magic       string
+	contentType string
+}
+
+// magicSignatures are checked before the WHATWG algorithm of
+// http.DetectContentType, which does not know these formats.
+var magicSignatures = []magicSignature{
+	{0, "II*\x00", "image/tiff"},
+	{0, "MM\x00*", "image/tiff"},
+	{0, "8BPS", "image/vnd.adobe.photoshop"},
+	{0, "7z\xbc\xaf\x27\x1c", "application/x-7z-compressed"},
+	{0, "\xfd7zXZ\x00", "application/x-xz"},
+	{0, "\x28\xb5\x2f\xfd", "application/zstd"},
+	{257, "ustar", "application/x-tar"},
+	{0, "SQLite format 3\x00", "application/vnd.sqlite3"},
+	{0, "\xd0\xcf\x11\xe0\xa1\xb1\x1a\xe1", "application/x-ole-storage"},
+	{0, "{\\rtf", "application/rtf"},
+	{0, "\x7fELF", "application/x-executable"},
+	{0, "fLaC", "audio/flac"},
+}
+
+// isoBrands maps the major brand of an ISO base media file, found after
+// "ftyp" at offset 4, to content types http.DetectContentType misses.
+var isoBrands = map[string]string{
+	"avif": "image/avif",
+	"avis": "image/avif",
+	"heic": "image/heic",
+	"heix": "image/heic",
+	"mif1": "image/heif",
+	"msf1": "image/heif",
+	"qt  ": "video/quicktime",
+}
+
+// contentSniffers recognize formats whose signatures are too short to be
+// matched alone.
+var contentSniffers = []struct {
+	contentType string
+	match       func(data []byte) bool
+}{
+	{"application/x-bzip2", sniffBzip2},
+	{"application/vnd.microsoft.portable-executable", sniffPortableExecutable},
+	{"image/svg+xml", sniffSVG},
+}
+
+// sniffBzip2 reports whether data starts with a bzip2 header: "BZh" and the
+// block size digit.
+func sniffBzip2(data []byte) bool {
+	return len(data) >= 4 && string(data[:3]) == "BZh" && data[3] >= '1' && data[3] <= '9'
+}
+
+// sniffPortableExecutable reports whether data starts with an MS-DOS header
+// pointing to a "PE" signature within the examined bytes.
+func sniffPortableExecutable(data []byte) bool {
+	if len(data) < 0x40 || string(data[:2]) != "MZ" {
+		return false
+	}
+	offset := int(binary.LittleEndian.Uint32(data[0x3c:]))
+	return offset >= 0x40 && offset <= len(data)-4 && string(data[offset:offset+4]) == "PE\x00\x00"
+}
+
+// sniffSVG reports whether data starts like an SVG document: an <svg> root
+// element, optionally after an XML declaration, comments or a DOCTYPE.
+func sniffSVG(data []byte) bool {
+	data = bytes.TrimLeft(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")), " \t\r\n")
+	for bytes.HasPrefix(data, []byte("<?")) || bytes.HasPrefix(data, []byte("<!")) {
+		end := bytes.IndexByte(data, '>')
+		if end < 0 {
+			return false
+		}
+		data = bytes.TrimLeft(data[end+1:], " \t\r\n")
+	}
+	return bytes.HasPrefix(data, []byte("<svg")) && len(data) > 4 && bytes.IndexByte([]byte(" \t\r\n>/"), data[4]) >= 0
+}
+
+// DetectContentType determines the content type of data from its leading
+// bytes, like http.DetectContentType, and also recognizes TIFF, HEIF, AVIF,
+// SVG, common archives and executables. It returns
+// "application/octet-stream" if no more specific type applies.
+func DetectContentType(data []byte) string {
+	if len(data) > sniffLen {
+		data = data[:sniffLen]
+	}
+	for _, sig := range magicSignatures {
+		if len(data) >= sig.offset+len(sig.magic) && string(data[sig.offset:sig.offset+len(sig.magic)]) == sig.magic {
+			return sig.contentType
+		}
+	}
+	if len(data) >= 12 && string(data[4:8]) == "ftyp" {
+		if contentType, ok := isoBrands[string(data[8:12])]; ok {
+			return contentType
+		}
+	}
+	for _, sniffer := range contentSniffers {
+		if sniffer.match(data) {
+			return sniffer.contentType
+		}
+	}
+	return http.DetectContentType(data)
+}
+
+// detectContentType determines the content type of raw or base64-encoded
+// bytes from their magic numbers
+// Usage: validation.detect_content_type(data, {base64=false, url_safe=false}) -> content_type_or_nil, error?
+func detectContentType(L *lua.LState) int {
+	data := L.CheckString(1)
+	opts := L.OptTable(2, L.NewTable())
+
+	raw := []byte(data)
+	if lua.LVAsBool(opts.RawGetString("base64")) {
+		decoded, err := decodeBase64(data, base64OptionsFrom(opts))
+		if err != nil {
+			L.Push(lua.LNil)
+			L.Push(lua.LString("invalid base64: " + err.Error()))
+			return 2
+		}
+		raw = decoded
+	}
+	L.Push(lua.LString(DetectContentType(raw)))
+	return 1
+}
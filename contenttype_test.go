@@ -0,0 +1,72 @@
+package validation
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestDetectContentType(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	png := "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+	pe := "MZ" + strings.Repeat("\x00", 0x3a) + "\x40\x00\x00\x00" + "PE\x00\x00"
+
+	tests := []struct {
+		data     string
+		expected string
+	}{
+		{png, "image/png"},
+		{"%PDF-1.7\n", "application/pdf"},
+		{"\x1f\x8b\x08\x00", "application/x-gzip"},
+		{"II*\x00\x08\x00\x00\x00", "image/tiff"},
+		{"\x00\x00\x00\x18ftypheic\x00\x00\x00\x00", "image/heic"},
+		{"\x00\x00\x00\x1cftypavif\x00\x00\x00\x00", "image/avif"},
+		{"7z\xbc\xaf\x27\x1c\x00\x04", "application/x-7z-compressed"},
+		{"BZh91AY&SY", "application/x-bzip2"},
+		{"BZh is not bzip2", "text/plain; charset=utf-8"},
+		{strings.Repeat("\x00", 257) + "ustar\x0000", "application/x-tar"},
+		{"\x7fELF\x02\x01\x01", "application/x-executable"},
+		{pe, "application/vnd.microsoft.portable-executable"},
+		{"MZ is a two-letter code", "text/plain; charset=utf-8"},
+		{`<svg xmlns="http://www.w3.org/2000/svg"></svg>`, "image/svg+xml"},
+		{"\ufeff<?xml version=\"1.0\"?>\n<!-- logo -->\n<svg>", "image/svg+xml"},
+		{"<svgfoo/>", "text/plain; charset=utf-8"},
+		{"<!DOCTYPE html><html>", "text/html; charset=utf-8"},
+		{"hello", "text/plain; charset=utf-8"},
+	}
+
+	for _, tt := range tests {
+		L.SetGlobal("data", lua.LString(tt.data))
+		L.SetGlobal("encoded", lua.LString(base64.StdEncoding.EncodeToString([]byte(tt.data))))
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.detect_content_type(data), validation.detect_content_type(encoded, {base64 = true})
+		`); err != nil {
+			t.Fatalf("detect_content_type(%q): %v", tt.data, err)
+		}
+
+		raw, decoded := L.Get(-2), L.Get(-1)
+		L.Pop(2)
+		if raw.String() != tt.expected {
+			t.Errorf("detect_content_type(%q) = %q, want %q", tt.data, raw, tt.expected)
+		}
+		if decoded.String() != tt.expected {
+			t.Errorf("detect_content_type(base64 %q) = %q, want %q", tt.data, decoded, tt.expected)
+		}
+	}
+
+	if err := L.DoString(`
+		local validation = require("validation")
+		local content_type, err = validation.detect_content_type("not base64!", {base64 = true})
+		assert(content_type == nil and err:find("invalid base64"), err)
+		assert(validation.detect_content_type("iVBORw0KGgo", {base64 = true}) == "image/png")
+	`); err != nil {
+		t.Fatalf("detect_content_type: %v", err)
+	}
+}
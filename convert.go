@@ -0,0 +1,76 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// toLua converts a Go value, such as the result of decoding JSON, into a Lua
+// value. Maps become tables keyed by their string keys and slices become
+// arrays, in which nil elements keep their positions. Values of other types
+// are converted to strings.
+func toLua(L *lua.LState, value any) lua.LValue {
+	switch v := value.(type) {
+	case nil:
+		return lua.LNil
+	case lua.LValue:
+		return v
+	case bool:
+		return lua.LBool(v)
+	case string:
+		return lua.LString(v)
+	case json.Number:
+		if n, err := v.Float64(); err == nil {
+			return lua.LNumber(n)
+		}
+		return lua.LString(v)
+	case []any:
+		tbl := L.CreateTable(len(v), 0)
+		for i, item := range v {
+			tbl.RawSetInt(i+1, toLua(L, item))
+		}
+		return tbl
+	case map[string]any:
+		tbl := L.CreateTable(0, len(v))
+		for key, item := range v {
+			tbl.RawSetString(key, toLua(L, item))
+		}
+		return tbl
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return lua.LNumber(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return lua.LNumber(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return lua.LNumber(rv.Float())
+	case reflect.Slice, reflect.Array:
+		tbl := L.CreateTable(rv.Len(), 0)
+		for i := 0; i < rv.Len(); i++ {
+			tbl.RawSetInt(i+1, toLua(L, rv.Index(i).Interface()))
+		}
+		return tbl
+	case reflect.Map:
+		tbl := L.CreateTable(0, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			tbl.RawSetString(fmt.Sprint(iter.Key().Interface()), toLua(L, iter.Value().Interface()))
+		}
+		return tbl
+	}
+	return lua.LString(fmt.Sprint(value))
+}
+
+// errorsMap converts a table of field paths to messages into a Go map.
+func errorsMap(tbl *lua.LTable) map[string]string {
+	errs := map[string]string{}
+	tbl.ForEach(func(key, value lua.LValue) {
+		errs[key.String()] = value.String()
+	})
+	return errs
+}
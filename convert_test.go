@@ -0,0 +1,20 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestToLuaArrayNil(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	tbl, ok := toLua(L, []any{nil, "x"}).(*lua.LTable)
+	if !ok {
+		t.Fatal("Expected a table")
+	}
+	if tbl.Len() != 2 || tbl.RawGetInt(1) != lua.LNil || tbl.RawGetInt(2) != lua.LString("x") {
+		t.Errorf("Expected {nil, \"x\"}, got length %d", tbl.Len())
+	}
+}
@@ -0,0 +1,58 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsCountryCode(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		args     string
+		expected bool
+	}{
+		{`"DE"`, true},
+		{`"us"`, true},
+		{`"AX"`, true},
+		{`"XK"`, false},
+		{`"ZZ"`, false},
+		{`"UK"`, false},
+		{`"DEU"`, false},
+		{`""`, false},
+		{`"DEU", {alpha3 = true}`, true},
+		{`"gbr", {alpha3 = true}`, true},
+		{`"GB", {alpha3 = true}`, false},
+		{`"UKR", {alpha3 = true}`, true},
+		{`"XXX", {alpha3 = true}`, false},
+	}
+
+	for _, tt := range tests {
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.is_country_code(` + tt.args + `)
+		`); err != nil {
+			t.Fatalf("is_country_code(%s): %v", tt.args, err)
+		}
+		if got := L.Get(-1) == lua.LTrue; got != tt.expected {
+			t.Errorf("is_country_code(%s) = %v, want %v", tt.args, got, tt.expected)
+		}
+		L.Pop(1)
+	}
+}
+
+func TestCountryCodeTables(t *testing.T) {
+	if len(countryCodes2) != 249 || len(countryCodes3) != 249 {
+		t.Fatalf("got %d alpha-2 and %d alpha-3 codes, want 249 each", len(countryCodes2), len(countryCodes3))
+	}
+	for alpha2, alpha3 := range countryAlpha3 {
+		if len(alpha2) != 2 || len(alpha3) != 3 || strings.ToUpper(alpha3) != alpha3 {
+			t.Errorf("malformed pair %s:%s", alpha2, alpha3)
+		}
+	}
+}
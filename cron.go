@@ -0,0 +1,333 @@
+package validation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// cronSchedule is a parsed cron expression. Each field is a bitset of the
+// values it allows; expressions without a seconds field fire at second 0.
+type cronSchedule struct {
+	second, minute, hour, dom, month, dow uint64
+	// domAny and dowAny record whether the day fields were left unrestricted,
+	// which decides whether days match on either field or on both.
+	domAny, dowAny bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronSecond = cronField{name: "second", min: 0, max: 59}
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Day of week accepts 7 as an alias for Sunday.
+	cronDow = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// parseCron parses a 5-field (minute precision) or 6-field (leading seconds)
+// cron expression. Ranges, steps, lists, month and weekday names and the
+// common @-macros are supported.
+func parseCron(expr string) (*cronSchedule, error) {
+	spec := strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) == 5 {
+		fields = append([]string{"0"}, fields...)
+	} else if len(fields) != 6 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 or 6 fields, got %d", expr, len(fields))
+	}
+
+	s := &cronSchedule{}
+	var err error
+	if s.second, err = cronSecond.parse(fields[0]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %v", expr, err)
+	}
+	if s.minute, err = cronMinute.parse(fields[1]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %v", expr, err)
+	}
+	if s.hour, err = cronHour.parse(fields[2]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %v", expr, err)
+	}
+	if s.dom, err = cronDom.parse(fields[3]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %v", expr, err)
+	}
+	if s.month, err = cronMonth.parse(fields[4]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %v", expr, err)
+	}
+	if s.dow, err = cronDow.parse(fields[5]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %v", expr, err)
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	s.domAny = fields[3] == "*" || fields[3] == "?"
+	s.dowAny = fields[5] == "*" || fields[5] == "?"
+	return s, nil
+}
+
+// parse converts a comma-separated list of cron terms into a bitset.
+func (f cronField) parse(field string) (uint64, error) {
+	var bits uint64
+	for _, term := range strings.Split(field, ",") {
+		b, err := f.parseTerm(term)
+		if err != nil {
+			return 0, err
+		}
+		bits |= b
+	}
+	return bits, nil
+}
+
+func (f cronField) parseTerm(term string) (uint64, error) {
+	rangePart, step := term, 1
+	if i := strings.IndexByte(term, '/'); i >= 0 {
+		n, err := strconv.Atoi(term[i+1:])
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid step %q in %s field", term[i+1:], f.name)
+		}
+		rangePart, step = term[:i], n
+	}
+
+	var lo, hi int
+	switch {
+	case rangePart == "*" || (rangePart == "?" && (f.name == cronDom.name || f.name == cronDow.name)):
+		lo, hi = f.min, f.max
+		if f.name == cronDow.name {
+			hi = 6
+		}
+	case strings.Contains(rangePart, "-"):
+		bounds := strings.SplitN(rangePart, "-", 2)
+		var err error
+		if lo, err = f.value(bounds[0]); err != nil {
+			return 0, err
+		}
+		if hi, err = f.value(bounds[1]); err != nil {
+			return 0, err
+		}
+		if lo > hi {
+			return 0, fmt.Errorf("invalid range %q in %s field", rangePart, f.name)
+		}
+	default:
+		var err error
+		if lo, err = f.value(rangePart); err != nil {
+			return 0, err
+		}
+		hi = lo
+		if step > 1 {
+			hi = f.max
+		}
+	}
+
+	var bits uint64
+	for v := lo; v <= hi; v += step {
+		bits |= 1 << uint(v)
+	}
+	return bits, nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid value %q in %s field", s, f.name)
+	}
+	return v, nil
+}
+
+// matchesDay reports whether the schedule fires on the given date.
+func (s *cronSchedule) matchesDay(day int, weekday time.Weekday) bool {
+	domMatch := s.dom&(1<<uint(day)) != 0
+	dowMatch := s.dow&(1<<uint(weekday)) != 0
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// cronCycleStart and cronCycleEnd bound a 28-year span in which every date,
+// including February 29, falls on every day of the week. Schedules repeat
+// over it, so scanning it is enough to find every way a schedule can fire.
+var (
+	cronCycleStart = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	cronCycleEnd   = cronCycleStart.AddDate(28, 0, 0)
+)
+
+// minInterval returns the shortest gap between two consecutive firings.
+// Times of day are the same on every firing day, so the gap is the smaller of
+// the closest pair within a day and the closest pair of firing days, scanned
+// over a 28-year weekday cycle. ok is false if the schedule can never fire
+// twice.
+func (s *cronSchedule) minInterval() (time.Duration, bool) {
+	var times []int
+	for h := 0; h < 24; h++ {
+		if s.hour&(1<<uint(h)) == 0 {
+			continue
+		}
+		for m := 0; m < 60; m++ {
+			if s.minute&(1<<uint(m)) == 0 {
+				continue
+			}
+			for sec := 0; sec < 60; sec++ {
+				if s.second&(1<<uint(sec)) != 0 {
+					times = append(times, h*3600+m*60+sec)
+				}
+			}
+		}
+	}
+	if len(times) == 0 {
+		return 0, false
+	}
+
+	best := -1
+	for i := 1; i < len(times); i++ {
+		if gap := times[i] - times[i-1]; best < 0 || gap < best {
+			best = gap
+		}
+	}
+
+	dayGap, last, index := -1, -1, 0
+	weekday := cronCycleStart.Weekday()
+	for t := cronCycleStart; t.Before(cronCycleEnd) && dayGap != 1; t = t.AddDate(0, 0, 1) {
+		if s.month&(1<<uint(t.Month())) != 0 && s.matchesDay(t.Day(), weekday) {
+			if last >= 0 && (dayGap < 0 || index-last < dayGap) {
+				dayGap = index - last
+			}
+			last = index
+		}
+		index++
+		weekday = (weekday + 1) % 7
+	}
+	if dayGap > 0 {
+		gap := dayGap*86400 - (times[len(times)-1] - times[0])
+		if best < 0 || gap < best {
+			best = gap
+		}
+	}
+
+	if best < 0 {
+		return 0, false
+	}
+	return time.Duration(best) * time.Second, true
+}
+
+// fires reports whether the schedule fires at all. A day of month that does
+// not occur in the chosen months, as in "0 0 30 2 *", never does. Without a
+// day of week it is enough to compare the days with each month's length;
+// otherwise one weekday cycle is scanned.
+func (s *cronSchedule) fires() bool {
+	if s.dowAny {
+		for m := time.January; m <= time.December; m++ {
+			if s.month&(1<<uint(m)) == 0 {
+				continue
+			}
+			for day := 1; day <= cronMonthDays[m-1]; day++ {
+				if s.dom&(1<<uint(day)) != 0 {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	weekday := cronCycleStart.Weekday()
+	for t := cronCycleStart; t.Before(cronCycleEnd); t = t.AddDate(0, 0, 1) {
+		if s.month&(1<<uint(t.Month())) != 0 && s.matchesDay(t.Day(), weekday) {
+			return true
+		}
+		weekday = (weekday + 1) % 7
+	}
+	return false
+}
+
+// cronMonthDays is the longest each month can be, counting February 29.
+var cronMonthDays = [12]int{31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+
+// validateCron parses a cron expression and checks that it can fire.
+func validateCron(expr string) error {
+	schedule, err := parseCron(expr)
+	if err != nil {
+		return err
+	}
+	if !schedule.fires() {
+		return fmt.Errorf("invalid cron expression %q: schedule never fires", expr)
+	}
+	return nil
+}
+
+// IsCron reports whether s is a 5-field or 6-field cron expression, or an
+// @-macro, that fires on at least one date.
+func IsCron(s string) bool {
+	return validateCron(s) == nil
+}
+
+// isCron checks if a string is a valid cron expression
+// Usage: validation.is_cron(str) -> boolean, error?
+func isCron(L *lua.LState) int {
+	if err := validateCron(L.CheckString(1)); err != nil {
+		L.Push(lua.LBool(false))
+		L.Push(lua.LString(err.Error()))
+		return 2
+	}
+	L.Push(lua.LBool(true))
+	return 1
+}
+
+// cronNotMoreFrequentThan checks that a cron expression never fires more often
+// than a minimum interval given as a Go duration string.
+// Usage: validation.cron_not_more_frequent_than(str, min_interval) -> boolean, reason?
+func cronNotMoreFrequentThan(L *lua.LState) int {
+	expr := L.CheckString(1)
+	floor, err := time.ParseDuration(L.CheckString(2))
+	if err != nil {
+		L.ArgError(2, err.Error())
+	}
+
+	schedule, err := parseCron(expr)
+	if err != nil {
+		L.Push(lua.LBool(false))
+		L.Push(lua.LString(err.Error()))
+		return 2
+	}
+
+	interval, ok := schedule.minInterval()
+	if ok && interval < floor {
+		L.Push(lua.LBool(false))
+		L.Push(lua.LString(stateOf(L).message("cron_too_frequent", map[string]string{
+			"interval": interval.String(),
+			"min":      floor.String(),
+		})))
+		return 2
+	}
+
+	L.Push(lua.LBool(true))
+	return 1
+}
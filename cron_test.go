@@ -0,0 +1,156 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestCronNotMoreFrequentThan(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local hourly = validation.cron_not_more_frequent_than("0 * * * *", "30m")
+		local everyMinute, reason = validation.cron_not_more_frequent_than("* * * * *", "5m")
+		return hourly, everyMinute, reason
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("CronNotMoreFrequentThan test failed: %v", err)
+	}
+
+	hourly := L.Get(-3).(lua.LBool)
+	everyMinute := L.Get(-2).(lua.LBool)
+	reason := L.Get(-1)
+
+	if !bool(hourly) {
+		t.Error("Expected true for hourly cron with 30m floor")
+	}
+	if bool(everyMinute) {
+		t.Error("Expected false for every-minute cron with 5m floor")
+	}
+	if reason == lua.LNil {
+		t.Error("Expected a reason for every-minute cron")
+	}
+}
+
+func TestCronNotMoreFrequentThanInvalid(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		return validation.cron_not_more_frequent_than("61 * * * *", "1m")
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("CronNotMoreFrequentThan invalid test failed: %v", err)
+	}
+
+	result := L.Get(-2).(lua.LBool)
+	reason := L.Get(-1).String()
+
+	if bool(result) {
+		t.Error("Expected false for invalid cron expression")
+	}
+	if !strings.Contains(reason, "invalid value") {
+		t.Errorf("Expected parse error, got %q", reason)
+	}
+}
+
+func TestCronMinInterval(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected time.Duration
+	}{
+		{"*/15 * * * *", 15 * time.Minute},
+		{"0 9-17 * * MON-FRI", time.Hour},
+		{"30 23 * * *", 24 * time.Hour},
+		{"0 0 * * SAT,SUN", 24 * time.Hour},
+		{"@weekly", 7 * 24 * time.Hour},
+		{"*/10 * * * * *", 10 * time.Second},
+		{"0 12 1 */3 *", 90 * 24 * time.Hour},
+		{"0 0 1 * *", 28 * 24 * time.Hour},
+		{"@yearly", 365 * 24 * time.Hour},
+		{"0 0 29 2 *", 1461 * 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			schedule, err := parseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("parseCron(%q) failed: %v", tt.expr, err)
+			}
+			interval, ok := schedule.minInterval()
+			if !ok || interval != tt.expected {
+				t.Errorf("Expected %v, got %v (ok=%v)", tt.expected, interval, ok)
+			}
+		})
+	}
+}
+
+func TestIsCron(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"* * * * *", true},
+		{"*/15 9-17 * * mon-fri", true},
+		{"0 0 1,15 * *", true},
+		{"30 4 * JAN-MAR,DEC SUN", true},
+		{"0 0 29 2 *", true},
+		{"0 0 30 2 mon", true},
+		{"0 0 29 2 ?", true},
+		{"0 */5 * * * *", true},
+		{"0 0 ? * 7", true},
+		{"@daily", true},
+		{"@Weekly", true},
+		{"* * * *", false},
+		{"* * * * * * *", false},
+		{"60 * * * *", false},
+		{"* 24 * * *", false},
+		{"* * 0 * *", false},
+		{"* * * 13 *", false},
+		{"* * * * 8", false},
+		{"5-1 * * * *", false},
+		{"*/0 * * * *", false},
+		{"1,,2 * * * *", false},
+		{"* * * foo *", false},
+		{"0 0 30 2 *", false},
+		{"0 0 31 4,6,9,11 *", false},
+		{"@every 5m", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if err := L.DoString(`
+			local validation = require("validation")
+			local ok, err = validation.is_cron("` + tt.input + `")
+			return ok, err
+		`); err != nil {
+			t.Fatalf("is_cron(%q): %v", tt.input, err)
+		}
+		if got := L.Get(-2) == lua.LTrue; got != tt.expected {
+			t.Errorf("is_cron(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+		if !tt.expected && L.Get(-1).Type() != lua.LTString {
+			t.Errorf("is_cron(%q) returned no error message", tt.input)
+		}
+		L.Pop(2)
+	}
+}
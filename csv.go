@@ -0,0 +1,185 @@
+package validation
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// csvOptions configures validate_csv.
+type csvOptions struct {
+	delimiter rune
+	header    bool
+	columns   int
+	names     []string
+	rules     *schema
+}
+
+// csvOptionsFrom reads the options of validate_csv from the table at
+// argument 2, raising an argument error for invalid ones.
+func csvOptionsFrom(L *lua.LState, opts *lua.LTable) csvOptions {
+	o := csvOptions{delimiter: ',', header: true}
+
+	if v, ok := opts.RawGetString("delimiter").(lua.LString); ok {
+		r, size := utf8.DecodeRuneInString(string(v))
+		if size != len(v) || r == utf8.RuneError || r == '"' || r == '\r' || r == '\n' {
+			L.ArgError(2, fmt.Sprintf("invalid delimiter %q", string(v)))
+		}
+		o.delimiter = r
+	}
+	if v, ok := opts.RawGetString("header").(lua.LBool); ok {
+		o.header = bool(v)
+	}
+
+	switch v := opts.RawGetString("expected_columns").(type) {
+	case lua.LNumber:
+		o.columns = int(v)
+	case *lua.LTable:
+		if !o.header {
+			L.ArgError(2, "expected_columns names require a header")
+		}
+		for i := 1; i <= v.Len(); i++ {
+			o.names = append(o.names, lua.LVAsString(v.RawGetInt(i)))
+		}
+		o.columns = len(o.names)
+	}
+
+	if rules, ok := opts.RawGetString("column_rules").(*lua.LTable); ok {
+		// Without a header, columns are named by their position.
+		named := L.NewTable()
+		rules.ForEach(func(key, value lua.LValue) {
+			named.RawSetString(key.String(), value)
+		})
+		s, err := compileSchema(L, named)
+		if err != nil {
+			L.ArgError(2, err.Error())
+		}
+		o.rules = s
+	}
+	return o
+}
+
+// checkCSVHeader compares a header with the expected column names and
+// describes any problems.
+func checkCSVHeader(header, expected []string) string {
+	var problems []string
+	seen := map[string]bool{}
+	for _, name := range header {
+		if seen[name] {
+			problems = append(problems, fmt.Sprintf("duplicate column %q", name))
+		}
+		seen[name] = true
+	}
+	if expected == nil {
+		return strings.Join(problems, "; ")
+	}
+
+	var missing, unexpected []string
+	wanted := map[string]bool{}
+	for _, name := range expected {
+		wanted[name] = true
+		if !seen[name] {
+			missing = append(missing, name)
+		}
+	}
+	for _, name := range header {
+		if !wanted[name] {
+			unexpected = append(unexpected, name)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(unexpected)
+	if len(missing) > 0 {
+		problems = append(problems, "missing columns: "+strings.Join(missing, ", "))
+	}
+	if len(unexpected) > 0 {
+		problems = append(problems, "unexpected columns: "+strings.Join(unexpected, ", "))
+	}
+	return strings.Join(problems, "; ")
+}
+
+// validateCSV validates CSV text: its header, the number of columns of every
+// record and the values of each column
+// Usage: validation.validate_csv(str, {delimiter=",", header=true, expected_columns=n_or_names, column_rules={...}}) -> boolean, table
+func validateCSV(L *lua.LState) int {
+	str := L.CheckString(1)
+	o := csvOptionsFrom(L, L.OptTable(2, L.NewTable()))
+
+	reader := csv.NewReader(strings.NewReader(strings.TrimPrefix(str, "\ufeff")))
+	reader.Comma = o.delimiter
+	reader.FieldsPerRecord = o.columns
+
+	var errs []fieldError
+	problems := map[string]string{}
+	var header []string
+	row := 0
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		row++
+		key := strconv.Itoa(row)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) && errors.Is(parseErr.Err, csv.ErrFieldCount) {
+				msg := fmt.Sprintf("expected %d columns, got %d", reader.FieldsPerRecord, len(record))
+				if o.header && header == nil {
+					// Records cannot be matched to a malformed header.
+					problems["header"] = msg
+					break
+				}
+				problems[key] = msg
+				continue
+			}
+			if errors.As(err, &parseErr) {
+				err = fmt.Errorf("line %d, column %d: %v", parseErr.Line, parseErr.Column, parseErr.Err)
+			}
+			problems[key] = err.Error()
+			break
+		}
+
+		if o.header && header == nil {
+			header = record
+			if msg := checkCSVHeader(header, o.names); msg != "" {
+				problems["header"] = msg
+			}
+			continue
+		}
+		if o.rules == nil {
+			continue
+		}
+
+		// Empty cells are missing values, so only required columns reject them.
+		values := L.NewTable()
+		for i, value := range record {
+			name := strconv.Itoa(i + 1)
+			if o.header {
+				name = header[i]
+			}
+			if value != "" {
+				values.RawSetString(name, lua.LString(value))
+			}
+		}
+		o.rules.coerceStrings(L, values)
+		errs = o.rules.validateFields(L, key+".", values, errs)
+	}
+	if o.header && header == nil && len(problems) == 0 {
+		problems["header"] = "missing header"
+	}
+
+	tbl := fieldErrorsTable(L, errs)
+	for key, msg := range problems {
+		tbl.RawSetString(key, lua.LString(msg))
+	}
+	L.Push(lua.LBool(len(errs) == 0 && len(problems) == 0))
+	L.Push(tbl)
+	return 2
+}
@@ -0,0 +1,117 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestValidateCSV(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     string
+		expected bool
+		errors   map[string]string
+	}{
+		{
+			name:     "valid with rules",
+			input:    "email,age,active\nana@example.com,34,true\nbo@example.com,,false\n",
+			opts:     `{column_rules = {email = "required|email", age = "integer|min:18", active = "boolean"}}`,
+			expected: true,
+		},
+		{
+			name:     "rule failures",
+			input:    "email,age\nana@example.com,16\n,20\n",
+			opts:     `{column_rules = {email = "required|email", age = "integer|min:18"}}`,
+			expected: false,
+			errors:   map[string]string{"2.age": "2.age must be at least 18", "3.email": "3.email is required"},
+		},
+		{
+			name:     "semicolon delimiter with byte order mark",
+			input:    "\ufeffname;price\nPen;1.5\n",
+			opts:     `{delimiter = ";", expected_columns = {"price", "name"}, column_rules = {price = "number|min:0"}}`,
+			expected: true,
+		},
+		{
+			name:     "header mismatch",
+			input:    "name,name,extra\nPen,Pen,x\n",
+			opts:     `{expected_columns = {"name", "price", "sku"}}`,
+			expected: false,
+			errors:   map[string]string{"header": `duplicate column "name"; missing columns: price, sku; unexpected columns: extra`},
+		},
+		{
+			name:     "column count",
+			input:    "a,b\n1,2\n1\n1,2,3\n",
+			opts:     `{}`,
+			expected: false,
+			errors:   map[string]string{"3": "expected 2 columns, got 1", "4": "expected 2 columns, got 3"},
+		},
+		{
+			name:     "expected column count without header",
+			input:    "1,2,3\n4,5\n",
+			opts:     `{header = false, expected_columns = 3, column_rules = {[2] = "integer|max:3"}}`,
+			expected: false,
+			errors:   map[string]string{"2": "expected 3 columns, got 2"},
+		},
+		{
+			name:     "header with wrong column count",
+			input:    "a,b\n1,2,3\n",
+			opts:     `{expected_columns = 3}`,
+			expected: false,
+			errors:   map[string]string{"header": "expected 3 columns, got 2"},
+		},
+		{
+			name:     "syntax error",
+			input:    "a,b\n1,\"2\n",
+			opts:     `{}`,
+			expected: false,
+			errors:   map[string]string{"2": "line 2, column 6: extraneous or missing \" in quoted-field"},
+		},
+		{
+			name:     "missing header",
+			input:    "",
+			opts:     `{}`,
+			expected: false,
+			errors:   map[string]string{"header": "missing header"},
+		},
+	}
+
+	for _, tt := range tests {
+		L.SetGlobal("input", lua.LString(tt.input))
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.validate_csv(input, ` + tt.opts + `)
+		`); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+
+		ok := L.Get(-2)
+		errs := errorsMap(L.Get(-1).(*lua.LTable))
+		L.Pop(2)
+		if ok != lua.LBool(tt.expected) {
+			t.Errorf("%s: validate_csv = %v, want %v (errors %v)", tt.name, ok, tt.expected, errs)
+		}
+		if len(errs) != len(tt.errors) {
+			t.Errorf("%s: errors = %v, want %v", tt.name, errs, tt.errors)
+			continue
+		}
+		for key, msg := range tt.errors {
+			if errs[key] != msg {
+				t.Errorf("%s: errors[%q] = %q, want %q", tt.name, key, errs[key], msg)
+			}
+		}
+	}
+
+	if err := L.DoString(`
+		local validation = require("validation")
+		validation.validate_csv("a", {delimiter = ",,"})
+	`); err == nil {
+		t.Error("validate_csv with a two-character delimiter should raise an error")
+	}
+}
@@ -0,0 +1,48 @@
+package validation
+
+import (
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// currencyCodes holds the active ISO 4217 alphabetic codes for national and
+// regional currencies.
+var currencyCodes = buildCodeSet(`
+	AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BRL
+	BSD BTN BWP BYN BZD CAD CDF CHF CLP CNY COP CRC CUC CUP CVE CZK DJF DKK DOP DZD
+	EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD GNF GTQ GYD HKD HNL HTG HUF IDR ILS
+	INR IQD IRR ISK JMD JOD JPY KES KGS KHR KMF KPW KRW KWD KYD KZT LAK LBP LKR LRD
+	LSL LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MYR MZN NAD NGN NIO NOK
+	NPR NZD OMR PAB PEN PGK PHP PKR PLN PYG QAR RON RSD RUB RWF SAR SBD SCR SDG SEK
+	SGD SHP SLE SLL SOS SRD SSP STN SVC SYP SZL THB TJS TMT TND TOP TRY TTD TWD TZS
+	UAH UGX USD UYU UZS VED VES VND VUV WST XAF XCD XCG XOF XPF YER ZAR ZMW ZWG ZWL
+`)
+
+// specialCurrencyCodes holds the ISO 4217 codes that do not name a currency
+// in circulation: fund codes, precious metals, bond market units, XDR, XSU,
+// XUA, the testing code XTS and XXX for transactions without a currency.
+var specialCurrencyCodes = buildCodeSet(`
+	BOV CHE CHW CLF COU MXV USN UYI UYW
+	XAG XAU XPD XPT XBA XBB XBC XBD XDR XSU XUA XTS XXX
+`)
+
+// IsCurrencyCode reports whether s is an active ISO 4217 alphabetic code such
+// as "EUR". Fund, metal, test and other special codes are accepted unless
+// excludeFunds is set. Letters are matched case-insensitively.
+func IsCurrencyCode(s string, excludeFunds bool) bool {
+	s = strings.ToUpper(s)
+	if currencyCodes[s] {
+		return true
+	}
+	return !excludeFunds && specialCurrencyCodes[s]
+}
+
+// isCurrencyCode checks if a string is an ISO 4217 currency code
+// Usage: validation.is_currency_code(str, {exclude_funds=false}) -> boolean
+func isCurrencyCode(L *lua.LState) int {
+	str := L.CheckString(1)
+	opts := L.OptTable(2, L.NewTable())
+	L.Push(lua.LBool(IsCurrencyCode(str, lua.LVAsBool(opts.RawGetString("exclude_funds")))))
+	return 1
+}
@@ -0,0 +1,47 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsCurrencyCode(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		args     string
+		expected bool
+	}{
+		{`"EUR"`, true},
+		{`"usd"`, true},
+		{`"JPY"`, true},
+		{`"XTS"`, true},
+		{`"XAU"`, true},
+		{`"CHE"`, true},
+		{`"DEM"`, false},
+		{`"ABC"`, false},
+		{`"EU"`, false},
+		{`""`, false},
+		{`"EUR", {exclude_funds = true}`, true},
+		{`"XTS", {exclude_funds = true}`, false},
+		{`"XXX", {exclude_funds = true}`, false},
+		{`"USN", {exclude_funds = true}`, false},
+	}
+
+	for _, tt := range tests {
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.is_currency_code(` + tt.args + `)
+		`); err != nil {
+			t.Fatalf("is_currency_code(%s): %v", tt.args, err)
+		}
+		if got := L.Get(-1) == lua.LTrue; got != tt.expected {
+			t.Errorf("is_currency_code(%s) = %v, want %v", tt.args, got, tt.expected)
+		}
+		L.Pop(1)
+	}
+}
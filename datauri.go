@@ -0,0 +1,99 @@
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// defaultDataURIMediaType is the media type of a data URI that omits one.
+const defaultDataURIMediaType = "text/plain"
+
+// dataURIOptions restricts the data URIs accepted by parseDataURI.
+type dataURIOptions struct {
+	mimeTypes []string // allowed media types; "image/*" matches a whole type
+	maxBytes  int
+}
+
+// parseDataURI validates an RFC 2397 data URI, data:[<media type>][;base64],<data>,
+// and returns its media type. Base64 content is decoded and percent-encoded
+// content unescaped so that the size limit applies to the actual bytes.
+func parseDataURI(s string, opts dataURIOptions) (string, error) {
+	if len(s) < 5 || !strings.EqualFold(s[:5], "data:") {
+		return "", fmt.Errorf("missing data: scheme")
+	}
+	meta, data, ok := strings.Cut(s[5:], ",")
+	if !ok {
+		return "", fmt.Errorf("missing comma before data")
+	}
+
+	meta, isBase64 := strings.CutSuffix(meta, ";base64")
+	mediaType := defaultDataURIMediaType
+	if meta != "" {
+		if strings.HasPrefix(meta, ";") {
+			meta = defaultDataURIMediaType + meta
+		}
+		var err error
+		if mediaType, _, err = parseMediaType(meta); err != nil {
+			return "", err
+		}
+	}
+	if len(opts.mimeTypes) > 0 && !mimeTypeAllowed(mediaType, opts.mimeTypes) {
+		return "", fmt.Errorf("media type %q is not allowed", mediaType)
+	}
+
+	size := 0
+	if isBase64 {
+		decoded, err := decodeBase64(data, base64Options{allowPadding: true, maxSize: opts.maxBytes})
+		if err != nil {
+			return "", fmt.Errorf("invalid base64 data: %v", err)
+		}
+		size = len(decoded)
+	} else {
+		decoded, err := url.PathUnescape(data)
+		if err != nil {
+			return "", fmt.Errorf("invalid percent-encoding in data")
+		}
+		size = len(decoded)
+	}
+	if opts.maxBytes > 0 && size > opts.maxBytes {
+		return "", fmt.Errorf("data exceeds %d bytes", opts.maxBytes)
+	}
+	return mediaType, nil
+}
+
+// IsDataURI reports whether s is a well-formed RFC 2397 data URI whose
+// content decodes to at most 10 MiB.
+func IsDataURI(s string) bool {
+	_, err := parseDataURI(s, dataURIOptions{maxBytes: defaultMaxBase64Size})
+	return err == nil
+}
+
+// isDataURI checks if a string is a data URI and returns its media type
+// Usage: validation.is_data_uri(str, {allowed_mime_types={...}, max_bytes=10485760}) -> boolean, media_type_or_error
+func isDataURI(L *lua.LState) int {
+	str := L.CheckString(1)
+	opts := L.OptTable(2, L.NewTable())
+
+	o := dataURIOptions{maxBytes: defaultMaxBase64Size}
+	if n, ok := opts.RawGetString("max_bytes").(lua.LNumber); ok {
+		o.maxBytes = int(n)
+	}
+	if tbl, ok := opts.RawGetString("allowed_mime_types").(*lua.LTable); ok {
+		for i := 1; i <= tbl.Len(); i++ {
+			o.mimeTypes = append(o.mimeTypes, lua.LVAsString(tbl.RawGetInt(i)))
+		}
+	}
+
+	mediaType, err := parseDataURI(str, o)
+	if err != nil {
+		L.Push(lua.LBool(false))
+		L.Push(lua.LString(err.Error()))
+		return 2
+	}
+	L.Push(lua.LBool(true))
+	L.Push(lua.LString(mediaType))
+	return 2
+}
@@ -0,0 +1,56 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsDataURI(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		args      string
+		expected  bool
+		mediaType string
+	}{
+		{`"data:,Hello%2C%20World%21"`, true, "text/plain"},
+		{`"data:text/plain;base64,SGVsbG8sIFdvcmxkIQ=="`, true, "text/plain"},
+		{`"DATA:Image/PNG;base64,iVBORw0KGgo="`, true, "image/png"},
+		{`"data:;charset=utf-8,caf%C3%A9"`, true, "text/plain"},
+		{`"data:text/html;charset=utf-8,%3Ch1%3EHi%3C%2Fh1%3E"`, true, "text/html"},
+		{`"data:image/svg+xml;base64,PHN2Zy8+"`, true, "image/svg+xml"},
+		{`"data:text/plain;base64,SGVsbG8"`, true, "text/plain"},
+		{`"data:text/plain;base64,SGVsbG8==="`, false, ""},
+		{`"data:text/plain,100%"`, false, ""},
+		{`"data:text,hello"`, false, ""},
+		{`"data:text/plain"`, false, ""},
+		{`"text/plain,hello"`, false, ""},
+		{`"http://example.com/a.png"`, false, ""},
+		{`"data:image/png;base64,iVBORw0KGgo=", {allowed_mime_types = {"image/png", "image/jpeg"}}`, true, "image/png"},
+		{`"data:image/gif;base64,R0lGODlh", {allowed_mime_types = {"image/*"}}`, true, "image/gif"},
+		{`"data:text/html,hi", {allowed_mime_types = {"image/*"}}`, false, ""},
+		{`"data:text/plain;base64,SGVsbG8sIFdvcmxkIQ==", {max_bytes = 13}`, true, "text/plain"},
+		{`"data:text/plain;base64,SGVsbG8sIFdvcmxkIQ==", {max_bytes = 12}`, false, ""},
+		{`"data:,Hello%2C%20World%21", {max_bytes = 12}`, false, ""},
+	}
+
+	for _, tt := range tests {
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.is_data_uri(` + tt.args + `)
+		`); err != nil {
+			t.Fatalf("is_data_uri(%s): %v", tt.args, err)
+		}
+		if got := L.Get(1) == lua.LTrue; got != tt.expected {
+			t.Errorf("is_data_uri(%s) = %v (%v), want %v", tt.args, got, L.Get(2), tt.expected)
+		}
+		if tt.expected && L.Get(2) != lua.LString(tt.mediaType) {
+			t.Errorf("is_data_uri(%s) media type = %v, want %q", tt.args, L.Get(2), tt.mediaType)
+		}
+		L.SetTop(0)
+	}
+}
@@ -0,0 +1,314 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// defaultDateLayout is the layout used when none is given: an ISO 8601
+// calendar date.
+const defaultDateLayout = time.DateOnly
+
+// dateLayouts maps friendly layout names to Go reference layouts. Any other
+// layout is passed to time.Parse as is.
+var dateLayouts = map[string]string{
+	"YYYY-MM-DD":          "2006-01-02",
+	"YYYY/MM/DD":          "2006/01/02",
+	"YYYYMMDD":            "20060102",
+	"DD/MM/YYYY":          "02/01/2006",
+	"DD.MM.YYYY":          "02.01.2006",
+	"DD-MM-YYYY":          "02-01-2006",
+	"MM/DD/YYYY":          "01/02/2006",
+	"YYYY-MM-DD HH:mm":    "2006-01-02 15:04",
+	"YYYY-MM-DD HH:mm:ss": "2006-01-02 15:04:05",
+	"HH:mm":               "15:04",
+	"HH:mm:ss":            "15:04:05",
+}
+
+// dateLayout resolves a friendly layout name, or returns layout unchanged.
+func dateLayout(layout string) string {
+	if layout == "" {
+		return defaultDateLayout
+	}
+	if l, ok := dateLayouts[layout]; ok {
+		return l
+	}
+	return layout
+}
+
+// ParseDate parses s with a Go reference layout or one of the friendly
+// names such as "YYYY-MM-DD"; an empty layout means "2006-01-02". Dates
+// that do not exist, such as February 30, are rejected.
+func ParseDate(s, layout string) (time.Time, error) {
+	return time.Parse(dateLayout(layout), s)
+}
+
+// IsDate reports whether s is a real calendar date in the given layout.
+func IsDate(s, layout string) bool {
+	_, err := ParseDate(s, layout)
+	return err == nil
+}
+
+// rfc3339 matches the shape of an RFC 3339 date-time. Zone offsets are
+// captured so that their ranges can be checked; time.Parse accepts +24:00.
+var rfc3339 = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}[Tt]\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:[Zz]|[+-](\d{2}):(\d{2}))?$`)
+
+// IsDatetime reports whether s is an RFC 3339 timestamp such as
+// "2024-02-29T13:45:00Z" naming a real date and time. Without
+// requireTimezone the offset may be left out, as in "2024-02-29T13:45:00".
+func IsDatetime(s string, requireTimezone bool) bool {
+	m := rfc3339.FindStringSubmatch(s)
+	if m == nil {
+		return false
+	}
+	hasZone := strings.ContainsAny(s[19:], "Zz+-")
+	if !hasZone && requireTimezone {
+		return false
+	}
+	if m[1] != "" {
+		hours, _ := strconv.Atoi(m[1])
+		minutes, _ := strconv.Atoi(m[2])
+		if hours > 23 || minutes > 59 {
+			return false
+		}
+	}
+
+	layout := "2006-01-02T15:04:05.999999999"
+	if hasZone {
+		layout += "Z07:00"
+	}
+	_, err := time.Parse(layout, strings.ToUpper(s))
+	return err == nil
+}
+
+// dateTable returns the components of t with the keys used by os.date("*t"),
+// so the table can be passed to os.time.
+func dateTable(L *lua.LState, t time.Time) *lua.LTable {
+	tbl := L.CreateTable(0, 8)
+	tbl.RawSetString("year", lua.LNumber(t.Year()))
+	tbl.RawSetString("month", lua.LNumber(t.Month()))
+	tbl.RawSetString("day", lua.LNumber(t.Day()))
+	tbl.RawSetString("hour", lua.LNumber(t.Hour()))
+	tbl.RawSetString("min", lua.LNumber(t.Minute()))
+	tbl.RawSetString("sec", lua.LNumber(t.Second()))
+	tbl.RawSetString("wday", lua.LNumber(t.Weekday()+1))
+	tbl.RawSetString("yday", lua.LNumber(t.YearDay()))
+	return tbl
+}
+
+// isDate checks if a string is a valid date in a layout and returns its components
+// Usage: validation.is_date(str, layout?) -> boolean, components?
+func isDate(L *lua.LState) int {
+	str := L.CheckString(1)
+	layout := L.OptString(2, "")
+
+	t, err := ParseDate(str, layout)
+	if err != nil {
+		L.Push(lua.LBool(false))
+		return 1
+	}
+	L.Push(lua.LBool(true))
+	L.Push(dateTable(L, t))
+	return 2
+}
+
+// isDatetime checks if a string is a strict RFC 3339 timestamp
+// Usage: validation.is_datetime(str, {require_timezone=true}) -> boolean
+func isDatetime(L *lua.LState) int {
+	str := L.CheckString(1)
+	opts := L.OptTable(2, L.NewTable())
+
+	requireTimezone := true
+	if v, ok := opts.RawGetString("require_timezone").(lua.LBool); ok {
+		requireTimezone = bool(v)
+	}
+
+	L.Push(lua.LBool(IsDatetime(str, requireTimezone)))
+	return 1
+}
+
+// dateLayoutOption reads the layout of the date comparison functions from
+// argument n, given as a layout string or a table with a layout field.
+func dateLayoutOption(L *lua.LState, n int) string {
+	switch opts := L.Get(n).(type) {
+	case lua.LString:
+		return string(opts)
+	case *lua.LTable:
+		if v, ok := opts.RawGetString("layout").(lua.LString); ok {
+			return string(v)
+		}
+	case *lua.LNilType:
+	default:
+		L.ArgError(n, "layout or options table expected")
+	}
+	return ""
+}
+
+// parseDateOperand parses an operand of the date comparison functions.
+// "now" is the current time of the module's clock. Without a layout, an
+// RFC 3339 timestamp or a YYYY-MM-DD date is accepted.
+func parseDateOperand(L *lua.LState, s, layout string) (time.Time, error) {
+	if s == "now" {
+		return stateOf(L).options.now(), nil
+	}
+	if layout == "" && IsDatetime(s, true) {
+		return time.Parse(time.RFC3339Nano, strings.ToUpper(s))
+	}
+	t, err := ParseDate(s, layout)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+	return t, nil
+}
+
+// compareDates parses the first count arguments as dates, reading options
+// from the argument after them, and pushes the result of check. An operand
+// that cannot be parsed makes it push false and an error message.
+func compareDates(L *lua.LState, count int, check func(dates []time.Time) bool) int {
+	layout := dateLayoutOption(L, count+1)
+	dates := make([]time.Time, count)
+	for i := range dates {
+		t, err := parseDateOperand(L, L.CheckString(i+1), layout)
+		if err != nil {
+			L.Push(lua.LBool(false))
+			L.Push(lua.LString(err.Error()))
+			return 2
+		}
+		dates[i] = t
+	}
+	L.Push(lua.LBool(check(dates)))
+	return 1
+}
+
+// dateBefore checks if a date is strictly before another
+// Usage: validation.date_before(a, b, {layout=...}) -> boolean, error?
+func dateBefore(L *lua.LState) int {
+	return compareDates(L, 2, func(d []time.Time) bool { return d[0].Before(d[1]) })
+}
+
+// dateAfter checks if a date is strictly after another
+// Usage: validation.date_after(a, b, {layout=...}) -> boolean, error?
+func dateAfter(L *lua.LState) int {
+	return compareDates(L, 2, func(d []time.Time) bool { return d[0].After(d[1]) })
+}
+
+// dateBetween checks if a date lies within an inclusive range
+// Usage: validation.date_between(d, min, max, {layout=...}) -> boolean, error?
+func dateBetween(L *lua.LState) int {
+	return compareDates(L, 3, func(d []time.Time) bool { return !d[0].Before(d[1]) && !d[0].After(d[2]) })
+}
+
+// Age returns the age in whole years on the calendar date of now of someone
+// born on dob. A February 29 birthday is reached on March 1 in common years.
+func Age(dob, now time.Time) int {
+	y, m, d := now.Date()
+	by, bm, bd := dob.Date()
+	age := y - by
+	if m < bm || m == bm && d < bd {
+		age--
+	}
+	return age
+}
+
+// checkAge parses the date of birth and required years and pushes whether
+// the age satisfies check. A date of birth in the future never does.
+func checkAge(L *lua.LState, check func(age, years int) bool) int {
+	str := L.CheckString(1)
+	years := L.CheckInt(2)
+	layout := dateLayoutOption(L, 3)
+
+	dob, err := parseDateOperand(L, str, layout)
+	if err != nil {
+		L.Push(lua.LBool(false))
+		L.Push(lua.LString(err.Error()))
+		return 2
+	}
+	now := stateOf(L).options.now()
+	L.Push(lua.LBool(!dob.After(now) && check(Age(dob, now), years)))
+	return 1
+}
+
+// minAge checks if someone born on a date is at least a number of years old
+// Usage: validation.min_age(dob, years, {layout=...}) -> boolean, error?
+func minAge(L *lua.LState) int {
+	return checkAge(L, func(age, years int) bool { return age >= years })
+}
+
+// maxAge checks if someone born on a date is at most a number of years old
+// Usage: validation.max_age(dob, years, {layout=...}) -> boolean, error?
+func maxAge(L *lua.LState) int {
+	return checkAge(L, func(age, years int) bool { return age <= years })
+}
+
+// IsWeekend reports whether t falls on a Saturday or Sunday.
+func IsWeekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
+
+// IsBusinessDay reports whether t is a weekday whose calendar date is not
+// one of holidays.
+func IsBusinessDay(t time.Time, holidays []time.Time) bool {
+	if IsWeekend(t) {
+		return false
+	}
+	y, m, d := t.Date()
+	for _, h := range holidays {
+		if hy, hm, hd := h.Date(); hy == y && hm == m && hd == d {
+			return false
+		}
+	}
+	return true
+}
+
+// checkDay parses the date in argument 1 with the layout option in argument
+// n and pushes the result of check. An invalid date makes it push false and
+// an error message.
+func checkDay(L *lua.LState, n int, check func(t time.Time) bool) int {
+	layout := dateLayoutOption(L, n)
+	t, err := parseDateOperand(L, L.CheckString(1), layout)
+	if err != nil {
+		L.Push(lua.LBool(false))
+		L.Push(lua.LString(err.Error()))
+		return 2
+	}
+	L.Push(lua.LBool(check(t)))
+	return 1
+}
+
+// isWeekday checks if a date falls on Monday to Friday
+// Usage: validation.is_weekday(date, {layout=...}) -> boolean, error?
+func isWeekday(L *lua.LState) int {
+	return checkDay(L, 2, func(t time.Time) bool { return !IsWeekend(t) })
+}
+
+// isWeekend checks if a date falls on Saturday or Sunday
+// Usage: validation.is_weekend(date, {layout=...}) -> boolean, error?
+func isWeekend(L *lua.LState) int {
+	return checkDay(L, 2, IsWeekend)
+}
+
+// isBusinessDay checks if a date is a weekday and not one of a list of holidays
+// Usage: validation.is_business_day(date, holidays?, {layout=...}) -> boolean, error?
+func isBusinessDay(L *lua.LState) int {
+	layout := dateLayoutOption(L, 3)
+	var holidays []time.Time
+	if tbl := L.OptTable(2, nil); tbl != nil {
+		for i := 1; i <= tbl.Len(); i++ {
+			s, ok := tbl.RawGetInt(i).(lua.LString)
+			if !ok {
+				L.ArgError(2, fmt.Sprintf("holiday %d is not a string", i))
+			}
+			h, err := parseDateOperand(L, string(s), layout)
+			if err != nil {
+				L.ArgError(2, err.Error())
+			}
+			holidays = append(holidays, h)
+		}
+	}
+	return checkDay(L, 3, func(t time.Time) bool { return IsBusinessDay(t, holidays) })
+}
@@ -0,0 +1,270 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsDate(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		args     string
+		expected bool
+	}{
+		{`"2024-02-29"`, true},
+		{`"2023-02-29"`, false},
+		{`"2024-02-30"`, false},
+		{`"2024-13-01"`, false},
+		{`"2024-2-3"`, false},
+		{`"2024-02-03 "`, false},
+		{`""`, false},
+		{`"2024-02-29", "YYYY-MM-DD"`, true},
+		{`"29/02/2024", "DD/MM/YYYY"`, true},
+		{`"02/29/2024", "DD/MM/YYYY"`, false},
+		{`"02/29/2024", "MM/DD/YYYY"`, true},
+		{`"29.02.2024", "DD.MM.YYYY"`, true},
+		{`"20240229", "YYYYMMDD"`, true},
+		{`"2024-02-29 23:59:59", "YYYY-MM-DD HH:mm:ss"`, true},
+		{`"2024-02-29 24:00:00", "YYYY-MM-DD HH:mm:ss"`, false},
+		{`"Feb 29, 2024", "Jan 2, 2006"`, true},
+		{`"Feb 30, 2024", "Jan 2, 2006"`, false},
+	}
+
+	for _, tt := range tests {
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.is_date(` + tt.args + `)
+		`); err != nil {
+			t.Fatalf("is_date(%s): %v", tt.args, err)
+		}
+		if got := L.Get(1) == lua.LTrue; got != tt.expected {
+			t.Errorf("is_date(%s) = %v, want %v", tt.args, got, tt.expected)
+		}
+		L.SetTop(0)
+	}
+}
+
+func TestIsDateComponents(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	if err := L.DoString(`
+		local validation = require("validation")
+		local ok, d = validation.is_date("29/02/2024 13:45", "02/01/2006 15:04")
+		return ok, d.year, d.month, d.day, d.hour, d.min, d.sec, d.wday, d.yday
+	`); err != nil {
+		t.Fatalf("is_date components failed: %v", err)
+	}
+
+	want := []lua.LValue{lua.LTrue, lua.LNumber(2024), lua.LNumber(2), lua.LNumber(29), lua.LNumber(13), lua.LNumber(45), lua.LNumber(0), lua.LNumber(5), lua.LNumber(60)}
+	for i, w := range want {
+		if got := L.Get(i + 1); got != w {
+			t.Errorf("value %d = %v, want %v", i+1, got, w)
+		}
+	}
+}
+
+func TestIsDatetime(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		args     string
+		expected bool
+	}{
+		{`"2024-02-29T13:45:00Z"`, true},
+		{`"2024-02-29t13:45:00z"`, true},
+		{`"2024-02-29T13:45:00.123456789Z"`, true},
+		{`"2024-02-29T13:45:00+03:00"`, true},
+		{`"2024-02-29T13:45:00-23:59"`, true},
+		{`"2024-02-30T13:45:00Z"`, false},
+		{`"2023-02-29T13:45:00Z"`, false},
+		{`"2024-02-29T24:00:00Z"`, false},
+		{`"2024-02-29T13:60:00Z"`, false},
+		{`"2024-02-29T13:45:00+24:00"`, false},
+		{`"2024-02-29T13:45:00+03:60"`, false},
+		{`"2024-02-29T13:45:00+0300"`, false},
+		{`"2024-02-29T13:45:00,5Z"`, false},
+		{`"2024-02-29T13:45:00.Z"`, false},
+		{`"2024-02-29T13:45Z"`, false},
+		{`"2024-02-29 13:45:00Z"`, false},
+		{`"2024-02-29"`, false},
+		{`"2024-02-29T13:45:00"`, false},
+		{`"2024-02-29T13:45:00", {require_timezone = false}`, true},
+		{`"2024-02-29T13:45:00.5", {require_timezone = false}`, true},
+		{`"2024-02-29T13:45:00Z", {require_timezone = false}`, true},
+		{`"2024-02-30T13:45:00", {require_timezone = false}`, false},
+	}
+
+	for _, tt := range tests {
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.is_datetime(` + tt.args + `)
+		`); err != nil {
+			t.Fatalf("is_datetime(%s): %v", tt.args, err)
+		}
+		if got := L.Get(-1) == lua.LTrue; got != tt.expected {
+			t.Errorf("is_datetime(%s) = %v, want %v", tt.args, got, tt.expected)
+		}
+		L.Pop(1)
+	}
+}
+
+func TestDateComparisons(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	L.PreloadModule("validation", NewLoader(Options{Now: func() time.Time { return now }}))
+
+	tests := []struct {
+		call     string
+		expected bool
+	}{
+		{`date_before("2024-01-01", "2024-01-02")`, true},
+		{`date_before("2024-01-02", "2024-01-02")`, false},
+		{`date_before("2024-06-15", "now")`, true},
+		{`date_before("2024-06-15T12:00:01Z", "now")`, false},
+		{`date_before("2024-06-15T14:59:00+03:00", "now")`, true},
+		{`date_after("now", "2024-06-14")`, true},
+		{`date_after("2024-01-02", "2024-01-01")`, true},
+		{`date_after("01/02/2024", "31/01/2024", "DD/MM/YYYY")`, true},
+		{`date_after("01/02/2024", "31/01/2024", {layout = "DD/MM/YYYY"})`, true},
+		{`date_between("2024-03-01", "2024-01-01", "2024-12-31")`, true},
+		{`date_between("2024-01-01", "2024-01-01", "2024-12-31")`, true},
+		{`date_between("2024-12-31", "2024-01-01", "2024-12-31")`, true},
+		{`date_between("2025-01-01", "2024-01-01", "2024-12-31")`, false},
+		{`date_between("now", "2024-01-01", "2024-12-31")`, true},
+	}
+
+	for _, tt := range tests {
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.` + tt.call + `
+		`); err != nil {
+			t.Fatalf("%s: %v", tt.call, err)
+		}
+		if got := L.Get(1) == lua.LTrue; got != tt.expected {
+			t.Errorf("%s = %v, want %v", tt.call, got, tt.expected)
+		}
+		L.SetTop(0)
+	}
+}
+
+func TestDateComparisonInvalidDate(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	if err := L.DoString(`
+		local validation = require("validation")
+		local ok, err = validation.date_before("2024-02-30", "now")
+		return ok, err
+	`); err != nil {
+		t.Fatalf("date_before with invalid date failed: %v", err)
+	}
+	if L.Get(-2) != lua.LFalse {
+		t.Errorf("expected false for an invalid date, got %v", L.Get(-2))
+	}
+	if msg, ok := L.Get(-1).(lua.LString); !ok || msg != `invalid date "2024-02-30"` {
+		t.Errorf("unexpected error message: %v", L.Get(-1))
+	}
+}
+
+func TestAge(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	now := time.Date(2025, 2, 28, 9, 0, 0, 0, time.UTC)
+	L.PreloadModule("validation", NewLoader(Options{Now: func() time.Time { return now }}))
+
+	tests := []struct {
+		call     string
+		expected bool
+	}{
+		{`min_age("2007-02-28", 18)`, true},
+		{`min_age("2007-03-01", 18)`, false},
+		{`min_age("2007-02-29", 18)`, false},
+		{`min_age("2006-02-29", 18)`, false},
+		{`min_age("2004-02-29", 21)`, false},
+		{`min_age("2004-02-28", 21)`, true},
+		{`min_age("28/02/2007", 18, "DD/MM/YYYY")`, true},
+		{`min_age("2030-01-01", 0)`, false},
+		{`max_age("1925-03-01", 99)`, true},
+		{`max_age("1925-02-28", 99)`, false},
+		{`max_age("2025-02-28", 0)`, true},
+		{`max_age("2030-01-01", 120)`, false},
+	}
+
+	for _, tt := range tests {
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.` + tt.call + `
+		`); err != nil {
+			t.Fatalf("%s: %v", tt.call, err)
+		}
+		if got := L.Get(1) == lua.LTrue; got != tt.expected {
+			t.Errorf("%s = %v, want %v", tt.call, got, tt.expected)
+		}
+		L.SetTop(0)
+	}
+}
+
+func TestBusinessDays(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	now := time.Date(2024, 12, 25, 9, 0, 0, 0, time.UTC)
+	L.PreloadModule("validation", NewLoader(Options{Now: func() time.Time { return now }}))
+
+	tests := []struct {
+		call     string
+		expected bool
+	}{
+		{`is_weekday("2024-12-20")`, true},
+		{`is_weekday("2024-12-21")`, false},
+		{`is_weekday("22/12/2024", "DD/MM/YYYY")`, false},
+		{`is_weekend("2024-12-21")`, true},
+		{`is_weekend("2024-12-22")`, true},
+		{`is_weekend("2024-12-23")`, false},
+		{`is_weekend("2024-12-21T23:30:00-05:00")`, true},
+		{`is_business_day("2024-12-24")`, true},
+		{`is_business_day("2024-12-25", {"2024-12-25", "2024-12-26"})`, false},
+		{`is_business_day("2024-12-27", {"2024-12-25", "2024-12-26"})`, true},
+		{`is_business_day("2024-12-28", {})`, false},
+		{`is_business_day("25.12.2024", {"25.12.2024"}, {layout = "DD.MM.YYYY"})`, false},
+		{`is_business_day("now", {"2024-12-25"})`, false},
+		{`is_weekday("2024-02-30")`, false},
+	}
+
+	for _, tt := range tests {
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.` + tt.call + `
+		`); err != nil {
+			t.Fatalf("%s: %v", tt.call, err)
+		}
+		if got := L.Get(1) == lua.LTrue; got != tt.expected {
+			t.Errorf("%s = %v, want %v", tt.call, got, tt.expected)
+		}
+		L.SetTop(0)
+	}
+
+	if err := L.DoString(`
+		local validation = require("validation")
+		return validation.is_business_day("2024-12-24", {"Christmas"})
+	`); err == nil {
+		t.Error("expected an error for an invalid holiday")
+	}
+}
@@ -0,0 +1,102 @@
+package validation
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"net/mail"
+	"strings"
+	"sync"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+//go:embed data/disposable_domains.txt
+var embeddedDisposableDomains string
+
+var (
+	disposableDomainsMu sync.RWMutex
+	disposableDomains   = mustParseDomainList(embeddedDisposableDomains)
+)
+
+// parseDomainList reads one domain per line, skipping blank lines and lines
+// starting with #. Domains are lowercased and a trailing dot is removed.
+func parseDomainList(r io.Reader) (map[string]bool, error) {
+	domains := map[string]bool{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+		domain := strings.ToLower(strings.TrimSuffix(entry, "."))
+		if !IsDomain(domain) {
+			return nil, fmt.Errorf("line %d: invalid domain %q", line, entry)
+		}
+		domains[domain] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return domains, nil
+}
+
+func mustParseDomainList(list string) map[string]bool {
+	domains, err := parseDomainList(strings.NewReader(list))
+	if err != nil {
+		panic("validation: embedded domain list: " + err.Error())
+	}
+	return domains
+}
+
+// LoadDisposableDomains replaces the list of disposable email domains used by
+// is_disposable_email in every Lua state, e.g. to refresh it from an
+// up-to-date source at startup. The format is one domain per line; blank
+// lines and lines starting with # are ignored. On error the current list is
+// kept.
+func LoadDisposableDomains(r io.Reader) error {
+	domains, err := parseDomainList(r)
+	if err != nil {
+		return err
+	}
+
+	disposableDomainsMu.Lock()
+	defer disposableDomainsMu.Unlock()
+	disposableDomains = domains
+	return nil
+}
+
+// IsDisposableEmail reports whether the domain of email, or one of its
+// parent domains, is a known disposable email provider.
+func IsDisposableEmail(email string) bool {
+	if addr, err := mail.ParseAddress(email); err == nil {
+		email = addr.Address
+	}
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return false
+	}
+	domain := strings.TrimSuffix(strings.ToLower(email[at+1:]), ".")
+
+	disposableDomainsMu.RLock()
+	defer disposableDomainsMu.RUnlock()
+	for domain != "" {
+		if disposableDomains[domain] {
+			return true
+		}
+		dot := strings.IndexByte(domain, '.')
+		if dot < 0 {
+			break
+		}
+		domain = domain[dot+1:]
+	}
+	return false
+}
+
+// isDisposableEmail checks if an email address uses a disposable email provider
+// Usage: validation.is_disposable_email(email) -> boolean
+func isDisposableEmail(L *lua.LState) int {
+	L.Push(lua.LBool(IsDisposableEmail(L.CheckString(1))))
+	return 1
+}
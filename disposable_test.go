@@ -0,0 +1,64 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsDisposableEmail(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		email    string
+		expected bool
+	}{
+		{"someone@mailinator.com", true},
+		{"someone@MAILINATOR.COM", true},
+		{"someone@eu.mailinator.com", true},
+		{"Someone <someone@yopmail.fr>", true},
+		{"someone@example.com", false},
+		{"someone@notmailinator.com", false},
+		{"not an email", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			err := L.DoString(`return require("validation").is_disposable_email("` + tt.email + `")`)
+			if err != nil {
+				t.Fatalf("is_disposable_email failed: %v", err)
+			}
+			result := L.Get(-1).(lua.LBool)
+			L.Pop(1)
+			if bool(result) != tt.expected {
+				t.Errorf("Expected %v for %s, got %v", tt.expected, tt.email, result)
+			}
+		})
+	}
+}
+
+func TestLoadDisposableDomains(t *testing.T) {
+	defer LoadDisposableDomains(strings.NewReader(embeddedDisposableDomains))
+
+	err := LoadDisposableDomains(strings.NewReader("# refreshed list\n\nthrowaway.test\nBurner.Example.\n"))
+	if err != nil {
+		t.Fatalf("LoadDisposableDomains failed: %v", err)
+	}
+	if !IsDisposableEmail("a@throwaway.test") || !IsDisposableEmail("a@burner.example") {
+		t.Error("Expected loaded domains to be disposable")
+	}
+	if IsDisposableEmail("a@mailinator.com") {
+		t.Error("Expected the list to be replaced")
+	}
+
+	if err := LoadDisposableDomains(strings.NewReader("ok.test\nnot a domain\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected an error for line 2, got %v", err)
+	}
+	if !IsDisposableEmail("a@throwaway.test") {
+		t.Error("Expected a failed load to keep the current list")
+	}
+}
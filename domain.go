@@ -0,0 +1,235 @@
+package validation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+	"golang.org/x/net/idna"
+)
+
+// maxLabelLength is the longest DNS label allowed by RFC 1035.
+const maxLabelLength = 63
+
+// checkDNSLabel validates a single DNS label: 1-63 ASCII letters, digits or
+// hyphens, not starting or ending with a hyphen.
+func checkDNSLabel(label string) error {
+	return checkLabel(label, false)
+}
+
+// checkLabel validates a DNS label, optionally also accepting underscores as
+// used by service records such as _dmarc or _sip._tcp.
+func checkLabel(label string, allowUnderscore bool) error {
+	if label == "" {
+		return fmt.Errorf("label must not be empty")
+	}
+	if len(label) > maxLabelLength {
+		return fmt.Errorf("label must be at most %d characters", maxLabelLength)
+	}
+	for _, r := range label {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' && allowUnderscore) {
+			return fmt.Errorf("invalid character %q", r)
+		}
+	}
+	if label[0] == '-' || label[len(label)-1] == '-' {
+		return fmt.Errorf("label must not start or end with a hyphen")
+	}
+	return nil
+}
+
+// maxHostnameLength is the longest hostname allowed in its textual form.
+const maxHostnameLength = 253
+
+// hostnameRules describes how strictly a hostname or domain is validated.
+type hostnameRules struct {
+	minLabels        int
+	allowTrailingDot bool
+	allowUnderscore  bool
+	allowIDN         bool
+	// alphaTLD rejects an all-numeric last label, so that dotted-decimal
+	// strings such as 192.168.0.1 are not taken for hostnames (RFC 1123
+	// section 2.1).
+	alphaTLD bool
+}
+
+// domainRules returns the rules of is_domain, which IsDomain, the "domain"
+// schema format and the fqdn tag share: two or more labels and a top-level
+// label that is not all-numeric.
+func domainRules() hostnameRules {
+	return hostnameRules{minLabels: 2, alphaTLD: true}
+}
+
+// rfc1123HostnameRules returns the rules of is_hostname, which IsHostname,
+// the "hostname" schema format and the hostname_rfc1123 tag share.
+func rfc1123HostnameRules() hostnameRules {
+	return hostnameRules{minLabels: 1, alphaTLD: true}
+}
+
+// withOptions applies the shared hostname options from an options table.
+func (r hostnameRules) withOptions(opts *lua.LTable) hostnameRules {
+	r.allowTrailingDot = lua.LVAsBool(opts.RawGetString("allow_trailing_dot"))
+	r.allowUnderscore = lua.LVAsBool(opts.RawGetString("allow_underscore"))
+	r.allowIDN = lua.LVAsBool(opts.RawGetString("allow_idn"))
+	return r
+}
+
+// check validates a dot-separated hostname label by label.
+func (r hostnameRules) check(host string) error {
+	if r.allowTrailingDot {
+		host = strings.TrimSuffix(host, ".")
+	}
+	if host == "" {
+		return fmt.Errorf("hostname must not be empty")
+	}
+	if r.allowIDN {
+		ascii, err := toASCIIHost(host)
+		if err != nil {
+			return err
+		}
+		host = ascii
+	}
+	if len(host) > maxHostnameLength {
+		return fmt.Errorf("hostname must be at most %d characters", maxHostnameLength)
+	}
+
+	labels := strings.Split(host, ".")
+	if len(labels) < r.minLabels {
+		return fmt.Errorf("hostname must have at least %d labels", r.minLabels)
+	}
+	for _, label := range labels {
+		if err := checkLabel(label, r.allowUnderscore); err != nil {
+			return err
+		}
+	}
+	if r.alphaTLD && isDigits(labels[len(labels)-1]) {
+		return fmt.Errorf("top-level label must not be all-numeric")
+	}
+	return nil
+}
+
+// toASCIIHost converts an internationalized hostname to its ASCII-compatible
+// encoding. Labels already in punycode must decode to a valid Unicode label
+// that encodes back to the same form.
+func toASCIIHost(host string) (string, error) {
+	if !IsASCII(host) {
+		ascii, err := idna.Lookup.ToASCII(host)
+		if err != nil {
+			return "", err
+		}
+		host = ascii
+	}
+	for _, label := range strings.Split(host, ".") {
+		if len(label) < 4 || !strings.EqualFold(label[:4], "xn--") {
+			continue
+		}
+		unicode, err := idna.Lookup.ToUnicode(label)
+		if err != nil {
+			return "", err
+		}
+		if ascii, err := idna.Lookup.ToASCII(unicode); err != nil || ascii != strings.ToLower(label) {
+			return "", fmt.Errorf("invalid punycode label %q", label)
+		}
+	}
+	return host, nil
+}
+
+// domainToASCII converts an internationalized domain name to punycode
+// Usage: validation.domain_to_ascii(str) -> string | nil, error
+func domainToASCII(L *lua.LState) int {
+	ascii, err := toASCIIHost(L.CheckString(1))
+	if err != nil {
+		L.Push(lua.LNil)
+		L.Push(lua.LString(err.Error()))
+		return 2
+	}
+	L.Push(lua.LString(ascii))
+	return 1
+}
+
+// domainToUnicode converts a punycode domain name to its Unicode form
+// Usage: validation.domain_to_unicode(str) -> string | nil, error
+func domainToUnicode(L *lua.LState) int {
+	ascii, err := toASCIIHost(L.CheckString(1))
+	if err == nil {
+		var unicode string
+		if unicode, err = idna.Lookup.ToUnicode(ascii); err == nil {
+			L.Push(lua.LString(unicode))
+			return 1
+		}
+	}
+	L.Push(lua.LNil)
+	L.Push(lua.LString(err.Error()))
+	return 2
+}
+
+// validateDomain validates a domain name with at least two labels
+// Usage: validation.validate_domain(str, {allow_trailing_dot=false, allow_idn=false}) -> boolean
+func validateDomain(L *lua.LState) int {
+	str := L.CheckString(1)
+	opts := L.OptTable(2, L.NewTable())
+	L.Push(lua.LBool(hostnameRules{minLabels: 2}.withOptions(opts).check(str) == nil))
+	return 1
+}
+
+// validateHostname validates a hostname, allowing a single label such as localhost
+// Usage: validation.validate_hostname(str, {allow_trailing_dot=false, allow_idn=false}) -> boolean
+func validateHostname(L *lua.LState) int {
+	str := L.CheckString(1)
+	opts := L.OptTable(2, L.NewTable())
+	L.Push(lua.LBool(hostnameRules{minLabels: 1}.withOptions(opts).check(str) == nil))
+	return 1
+}
+
+// isHostname validates an RFC 1123 hostname
+// Usage: validation.is_hostname(str, {allow_trailing_dot=false, allow_underscore=false, allow_idn=false}) -> boolean
+func isHostname(L *lua.LState) int {
+	str := L.CheckString(1)
+	rules := rfc1123HostnameRules().withOptions(L.OptTable(2, L.NewTable()))
+	L.Push(lua.LBool(rules.check(str) == nil))
+	return 1
+}
+
+// isAvailableSubdomain checks if a string is a valid, non-reserved subdomain label
+// Usage: validation.is_available_subdomain(str, {reserved={...}, min=1, max=63}) -> boolean, reason?
+func isAvailableSubdomain(L *lua.LState) int {
+	str := L.CheckString(1)
+	opts := L.OptTable(2, L.NewTable())
+
+	min := 1
+	if n, ok := opts.RawGetString("min").(lua.LNumber); ok {
+		min = int(n)
+	}
+	max := maxLabelLength
+	if n, ok := opts.RawGetString("max").(lua.LNumber); ok {
+		max = int(n)
+	}
+
+	reason, params := "", map[string]string{"value": str}
+	switch {
+	case len(str) < min:
+		reason, params["min"] = "subdomain_too_short", strconv.Itoa(min)
+	case len(str) > max:
+		reason, params["max"] = "subdomain_too_long", strconv.Itoa(max)
+	case checkDNSLabel(str) != nil:
+		reason = "subdomain_invalid"
+	}
+
+	if reason == "" {
+		if reserved, ok := opts.RawGetString("reserved").(*lua.LTable); ok {
+			reserved.ForEach(func(_, value lua.LValue) {
+				if name, ok := value.(lua.LString); ok && strings.EqualFold(str, string(name)) {
+					reason = "subdomain_reserved"
+				}
+			})
+		}
+	}
+
+	if reason != "" {
+		L.Push(lua.LBool(false))
+		L.Push(lua.LString(stateOf(L).message(reason, params)))
+		return 2
+	}
+	L.Push(lua.LBool(true))
+	return 1
+}
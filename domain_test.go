@@ -0,0 +1,279 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsAvailableSubdomain(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		name      string
+		subdomain string
+		expected  bool
+	}{
+		{"valid subdomain", "acme-corp", true},
+		{"reserved name", "api", false},
+		{"reserved name different case", "WWW", false},
+		{"too short", "ab", false},
+		{"invalid character", "acme_corp", false},
+		{"leading hyphen", "-acme", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script := `
+				local validation = require("validation")
+				local ok, reason = validation.is_available_subdomain("` + tt.subdomain + `", {reserved={"www","api","admin"}, min=3, max=63})
+				if not ok and reason == nil then
+					error("Expected a reason for rejected subdomain")
+				end
+				return ok
+			`
+
+			err := L.DoString(script)
+			if err != nil {
+				t.Fatalf("IsAvailableSubdomain test failed: %v", err)
+			}
+
+			result := L.Get(-1).(lua.LBool)
+			if bool(result) != tt.expected {
+				t.Errorf("Expected %v for %s, got %v", tt.expected, tt.subdomain, result)
+			}
+		})
+	}
+}
+
+func TestValidateDomain(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		name     string
+		domain   string
+		expected bool
+	}{
+		{"valid domain", "example.com", true},
+		{"valid subdomain", "api.my-host.example.com", true},
+		{"single label", "localhost", false},
+		{"leading hyphen", "-bad.com", false},
+		{"trailing hyphen", "bad-.com", false},
+		{"over-long label", strings.Repeat("a", 64) + ".com", false},
+		{"empty label", "example..com", false},
+		{"trailing dot", "example.com.", false},
+		{"underscore", "my_host.com", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script := `
+				local validation = require("validation")
+				return validation.validate_domain("` + tt.domain + `")
+			`
+
+			err := L.DoString(script)
+			if err != nil {
+				t.Fatalf("ValidateDomain test failed: %v", err)
+			}
+
+			result := L.Get(-1).(lua.LBool)
+			if bool(result) != tt.expected {
+				t.Errorf("Expected %v for %s, got %v", tt.expected, tt.domain, result)
+			}
+		})
+	}
+}
+
+func TestValidateHostname(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		return validation.validate_hostname("localhost"),
+			validation.validate_hostname("my-host"),
+			validation.validate_hostname("-bad"),
+			validation.validate_hostname("example.com."),
+			validation.validate_hostname("example.com.", {allow_trailing_dot=true})
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("ValidateHostname test failed: %v", err)
+	}
+
+	localhost := L.Get(-5).(lua.LBool)
+	myHost := L.Get(-4).(lua.LBool)
+	bad := L.Get(-3).(lua.LBool)
+	fqdn := L.Get(-2).(lua.LBool)
+	fqdnAllowed := L.Get(-1).(lua.LBool)
+
+	if !bool(localhost) {
+		t.Error("Expected true for localhost")
+	}
+	if !bool(myHost) {
+		t.Error("Expected true for my-host")
+	}
+	if bool(bad) {
+		t.Error("Expected false for -bad")
+	}
+	if bool(fqdn) {
+		t.Error("Expected false for trailing dot without allow_trailing_dot")
+	}
+	if !bool(fqdnAllowed) {
+		t.Error("Expected true for trailing dot with allow_trailing_dot")
+	}
+}
+
+func TestIsHostname(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		name     string
+		call     string
+		expected bool
+	}{
+		{"single label", `"localhost"`, true},
+		{"leading digit", `"3com.example"`, true},
+		{"dotted decimal", `"192.168.0.1"`, false},
+		{"numeric top-level label", `"host.123"`, false},
+		{"over-long label", `"` + strings.Repeat("a", 64) + `.com"`, false},
+		{"over-long name", `"` + strings.Repeat("a.", 127) + `com"`, false},
+		{"empty label", `"a..b"`, false},
+		{"trailing dot", `"example.com."`, false},
+		{"trailing dot allowed", `"example.com.", {allow_trailing_dot=true}`, true},
+		{"underscore", `"_dmarc.example.com"`, false},
+		{"underscore allowed", `"_sip._tcp.example.com", {allow_underscore=true}`, true},
+		{"space", `"my host"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := L.DoString(`return require("validation").is_hostname(` + tt.call + `)`)
+			if err != nil {
+				t.Fatalf("is_hostname failed: %v", err)
+			}
+			result := L.Get(-1).(lua.LBool)
+			L.Pop(1)
+			if bool(result) != tt.expected {
+				t.Errorf("Expected %v for %s, got %v", tt.expected, tt.call, result)
+			}
+		})
+	}
+}
+
+func TestIsDomain(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		name     string
+		call     string
+		expected bool
+	}{
+		{"valid domain", `"example.com"`, true},
+		{"single label", `"localhost"`, false},
+		{"numeric top-level label", `"example.123"`, false},
+		{"unknown tld without check", `"example.lol123"`, true},
+		{"unknown tld", `"example.lol123", {check_tld=true}`, false},
+		{"known tld", `"example.com", {check_tld=true}`, true},
+		{"known tld upper case", `"EXAMPLE.ORG", {check_tld=true}`, true},
+		{"country code tld", `"example.co.uk", {check_tld=true}`, true},
+		{"punycode tld", `"example.xn--p1ai", {check_tld=true}`, true},
+		{"reserved tld", `"printer.local", {check_tld=true}`, false},
+		{"trailing dot", `"example.com.", {check_tld=true, allow_trailing_dot=true}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := L.DoString(`return require("validation").is_domain(` + tt.call + `)`)
+			if err != nil {
+				t.Fatalf("is_domain failed: %v", err)
+			}
+			result := L.Get(-1).(lua.LBool)
+			L.Pop(1)
+			if bool(result) != tt.expected {
+				t.Errorf("Expected %v for %s, got %v", tt.expected, tt.call, result)
+			}
+		})
+	}
+}
+
+func TestDomainIDN(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		name     string
+		call     string
+		expected bool
+	}{
+		{"unicode domain", `validate_domain("münchen.de", {allow_idn=true})`, true},
+		{"unicode domain without allow_idn", `validate_domain("münchen.de")`, false},
+		{"cyrillic domain", `is_domain("пример.рф", {allow_idn=true, check_tld=true})`, true},
+		{"punycode domain", `validate_domain("xn--mnchen-3ya.de", {allow_idn=true})`, true},
+		{"malformed punycode", `validate_domain("xn--abc-.de", {allow_idn=true})`, false},
+		{"disallowed rune", `validate_hostname("ex ample", {allow_idn=true})`, false},
+		{"unicode hostname", `is_hostname("bücher", {allow_idn=true})`, true},
+		{"over-long after encoding", `validate_domain("` + strings.Repeat("ü", 60) + `.de", {allow_idn=true})`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := L.DoString(`return require("validation").` + tt.call)
+			if err != nil {
+				t.Fatalf("%s failed: %v", tt.call, err)
+			}
+			result := L.Get(-1).(lua.LBool)
+			L.Pop(1)
+			if bool(result) != tt.expected {
+				t.Errorf("Expected %v for %s, got %v", tt.expected, tt.call, result)
+			}
+		})
+	}
+}
+
+func TestDomainConversion(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	err := L.DoString(`
+		local validation = require("validation")
+		local bad, err = validation.domain_to_unicode("xn--abc-.de")
+		return validation.domain_to_ascii("Bücher.example"),
+			validation.domain_to_unicode("xn--mnchen-3ya.de"),
+			bad == nil and err ~= nil
+	`)
+	if err != nil {
+		t.Fatalf("domain conversion failed: %v", err)
+	}
+
+	if ascii := L.Get(-3).String(); ascii != "xn--bcher-kva.example" {
+		t.Errorf("Expected xn--bcher-kva.example, got %s", ascii)
+	}
+	if unicode := L.Get(-2).String(); unicode != "münchen.de" {
+		t.Errorf("Expected münchen.de, got %s", unicode)
+	}
+	if !bool(L.Get(-1).(lua.LBool)) {
+		t.Error("Expected an error for malformed punycode")
+	}
+}
@@ -0,0 +1,117 @@
+package validation
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// isoDurationUnit is an ISO 8601 duration designator; time designators are
+// prefixed with T to tell minutes from months.
+type isoDurationUnit struct {
+	designator string
+	seconds    float64
+}
+
+// isoDurationUnits lists the designators in the order they must appear.
+// Years and months have no fixed length; nominal 365-day years and 30-day
+// months are used.
+var isoDurationUnits = []isoDurationUnit{
+	{"Y", 365 * 86400}, {"M", 30 * 86400}, {"W", 7 * 86400}, {"D", 86400},
+	{"TH", 3600}, {"TM", 60}, {"TS", 1},
+}
+
+// ParseISODuration parses an ISO 8601 duration such as "PT1H30M", "P3D" or
+// "P2W" into seconds. Only the last component may have a fraction, and
+// weeks cannot be combined with other components.
+func ParseISODuration(s string) (float64, bool) {
+	rest, ok := strings.CutPrefix(s, "P")
+	if !ok || rest == "" {
+		return 0, false
+	}
+
+	var seconds float64
+	next, parts, fraction, inTime := 0, 0, false, false
+	for rest != "" {
+		if rest[0] == 'T' {
+			if inTime {
+				return 0, false
+			}
+			inTime, rest = true, rest[1:]
+			if rest == "" {
+				return 0, false
+			}
+			continue
+		}
+		i := strings.IndexFunc(rest, func(r rune) bool { return r >= 'A' && r <= 'Z' })
+		if i <= 0 || fraction {
+			return 0, false
+		}
+		number, unit := rest[:i], rest[i:i+1]
+		rest = rest[i+1:]
+		if inTime {
+			unit = "T" + unit
+		}
+		at := next
+		for at < len(isoDurationUnits) && isoDurationUnits[at].designator != unit {
+			at++
+		}
+		if at == len(isoDurationUnits) {
+			return 0, false
+		}
+		next = at + 1
+
+		fraction = strings.ContainsAny(number, ".,")
+		digits := strings.Replace(number, ",", ".", 1)
+		if !isDigits(strings.Replace(digits, ".", "", 1)) || digits[0] == '.' || digits[len(digits)-1] == '.' {
+			return 0, false
+		}
+		n, err := strconv.ParseFloat(digits, 64)
+		if err != nil {
+			return 0, false
+		}
+		seconds += n * isoDurationUnits[at].seconds
+		parts++
+	}
+	if parts > 1 && strings.Contains(s, "W") {
+		return 0, false
+	}
+	return seconds, true
+}
+
+// parseDurationSeconds parses a Go duration such as "1h30m" and, with
+// iso8601, an ISO 8601 duration, returning its length in seconds.
+func parseDurationSeconds(s string, iso8601 bool) (float64, bool) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return d.Seconds(), true
+	}
+	if iso8601 {
+		return ParseISODuration(s)
+	}
+	return 0, false
+}
+
+// IsDuration reports whether s is a Go duration such as "1h30m" or "250ms",
+// or with iso8601 also an ISO 8601 duration such as "PT1H30M".
+func IsDuration(s string, iso8601 bool) bool {
+	_, ok := parseDurationSeconds(s, iso8601)
+	return ok
+}
+
+// isDuration checks if a string is a duration and returns its length in seconds
+// Usage: validation.is_duration(str, {iso8601=false}) -> boolean, seconds?
+func isDuration(L *lua.LState) int {
+	str := L.CheckString(1)
+	opts := L.OptTable(2, L.NewTable())
+
+	seconds, ok := parseDurationSeconds(str, lua.LVAsBool(opts.RawGetString("iso8601")))
+	if !ok {
+		L.Push(lua.LBool(false))
+		return 1
+	}
+	L.Push(lua.LBool(true))
+	L.Push(lua.LNumber(seconds))
+	return 2
+}
@@ -0,0 +1,69 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsDuration(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		args    string
+		valid   bool
+		seconds float64
+	}{
+		{`"1h30m"`, true, 5400},
+		{`"250ms"`, true, 0.25},
+		{`"-5s"`, true, -5},
+		{`"0"`, true, 0},
+		{`"1.5h"`, true, 5400},
+		{`"90"`, false, 0},
+		{`"1d"`, false, 0},
+		{`""`, false, 0},
+		{`"PT1H30M"`, false, 0},
+		{`"PT1H30M", {iso8601 = true}`, true, 5400},
+		{`"1h30m", {iso8601 = true}`, true, 5400},
+		{`"P3D", {iso8601 = true}`, true, 259200},
+		{`"P2W", {iso8601 = true}`, true, 1209600},
+		{`"P1DT12H", {iso8601 = true}`, true, 129600},
+		{`"PT0.5S", {iso8601 = true}`, true, 0.5},
+		{`"PT1,5M", {iso8601 = true}`, true, 90},
+		{`"P1Y2M", {iso8601 = true}`, true, 36720000},
+		{`"P", {iso8601 = true}`, false, 0},
+		{`"PT", {iso8601 = true}`, false, 0},
+		{`"P1DT", {iso8601 = true}`, false, 0},
+		{`"P1H", {iso8601 = true}`, false, 0},
+		{`"PT1D", {iso8601 = true}`, false, 0},
+		{`"PT30M1H", {iso8601 = true}`, false, 0},
+		{`"PT1.5H30M", {iso8601 = true}`, false, 0},
+		{`"P1W2D", {iso8601 = true}`, false, 0},
+		{`"P1D2M", {iso8601 = true}`, false, 0},
+		{`"P1Y1Y", {iso8601 = true}`, false, 0},
+		{`"PT.5S", {iso8601 = true}`, false, 0},
+		{`"-PT1H", {iso8601 = true}`, false, 0},
+		{`"P1X", {iso8601 = true}`, false, 0},
+	}
+
+	for _, tt := range tests {
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.is_duration(` + tt.args + `)
+		`); err != nil {
+			t.Fatalf("is_duration(%s): %v", tt.args, err)
+		}
+		if got := L.Get(1) == lua.LTrue; got != tt.valid {
+			t.Errorf("is_duration(%s) = %v, want %v", tt.args, got, tt.valid)
+		}
+		if tt.valid {
+			if got, _ := L.Get(2).(lua.LNumber); float64(got) != tt.seconds {
+				t.Errorf("is_duration(%s) seconds = %v, want %v", tt.args, L.Get(2), tt.seconds)
+			}
+		}
+		L.SetTop(0)
+	}
+}
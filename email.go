@@ -0,0 +1,133 @@
+package validation
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// emailModes are the address grammars accepted by validate_email. Each check
+// takes the address and whether internationalized domains are allowed.
+var emailModes = map[string]func(string, bool) bool{
+	"rfc5322": checkEmail,
+	"html5":   checkHTML5Email,
+	"simple":  checkSimpleEmail,
+}
+
+// validateEmail validates an email address
+// Usage: validation.validate_email(email, mode | {mode="rfc5322", allow_idn=false}) -> boolean
+func validateEmail(L *lua.LState) int {
+	email := L.CheckString(1)
+	check, allowIDN := emailOptions(L, 2)
+	L.Push(lua.LBool(check(email, allowIDN)))
+	return 1
+}
+
+// emailOptions reads the mode string or options table at argument n and
+// returns the check of the selected mode and whether IDNs are allowed.
+func emailOptions(L *lua.LState, n int) (func(string, bool) bool, bool) {
+	mode, allowIDN := "rfc5322", false
+	switch opts := L.Get(n).(type) {
+	case lua.LString:
+		mode = string(opts)
+	case *lua.LTable:
+		if v, ok := opts.RawGetString("mode").(lua.LString); ok {
+			mode = string(v)
+		}
+		allowIDN = lua.LVAsBool(opts.RawGetString("allow_idn"))
+	case *lua.LNilType:
+	default:
+		L.ArgError(n, "mode or options table expected")
+	}
+
+	check, ok := emailModes[mode]
+	if !ok {
+		L.ArgError(n, fmt.Sprintf("unknown email mode %q", mode))
+	}
+	return check, allowIDN
+}
+
+// checkEmail parses an address with net/mail and requires an ASCII domain.
+// With allowIDN, an internationalized domain is converted to punycode and
+// only the domain is checked again. The local part stays as first parsed,
+// since net/mail returns quoted local parts unquoted.
+func checkEmail(email string, allowIDN bool) bool {
+	addr, err := mail.ParseAddress(email)
+	if err != nil {
+		return false
+	}
+
+	domain := addr.Address[strings.LastIndexByte(addr.Address, '@')+1:]
+	if !allowIDN {
+		return IsASCII(domain)
+	}
+
+	ascii, err := toASCIIHost(domain)
+	if err != nil {
+		return false
+	}
+	_, err = mail.ParseAddress("local@" + ascii)
+	return err == nil
+}
+
+// html5Email is the valid e-mail address grammar of the HTML living standard,
+// used by browsers for <input type="email">.
+var html5Email = regexp.MustCompile("^[a-zA-Z0-9.!#$%&'*+/=?^_`{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$")
+
+// checkHTML5Email validates an address as browsers do. Single-label domains
+// such as a@b are accepted, display names and quoted local parts are not.
+func checkHTML5Email(email string, allowIDN bool) bool {
+	local, domain, ok := splitEmail(email, allowIDN)
+	return ok && html5Email.MatchString(local+"@"+domain)
+}
+
+// Limits from RFC 5321 section 4.5.3.1.
+const (
+	maxEmailLocalLength = 64
+	maxEmailLength      = 254
+)
+
+// checkSimpleEmail validates a plain local@domain address: a dot-atom local
+// part of at most 64 characters and a domain of at least two labels whose
+// top-level label is not numeric. Display names, quoted local parts, comments
+// and address literals are rejected.
+func checkSimpleEmail(email string, allowIDN bool) bool {
+	local, domain, ok := splitEmail(email, allowIDN)
+	if !ok || len(local) > maxEmailLocalLength || len(local)+1+len(domain) > maxEmailLength {
+		return false
+	}
+	for _, atom := range strings.Split(local, ".") {
+		if atom == "" || strings.IndexFunc(atom, func(r rune) bool { return !isAtext(r) }) >= 0 {
+			return false
+		}
+	}
+	return domainRules().check(domain) == nil
+}
+
+// splitEmail splits an address at its last @, converting an internationalized
+// domain to punycode when allowIDN is set.
+func splitEmail(email string, allowIDN bool) (local, domain string, ok bool) {
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return "", "", false
+	}
+	local, domain = email[:at], email[at+1:]
+	if allowIDN {
+		ascii, err := toASCIIHost(domain)
+		if err != nil {
+			return "", "", false
+		}
+		domain = ascii
+	}
+	return local, domain, local != "" && domain != ""
+}
+
+// isAtext reports whether r may appear in an unquoted local part (RFC 5322
+// atext).
+func isAtext(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+		strings.ContainsRune("!#$%&'*+-/=?^_`{|}~", r)
+}
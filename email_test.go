@@ -0,0 +1,196 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestValidateEmail(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		name     string
+		email    string
+		expected bool
+	}{
+		{"valid email", "user@example.com", true},
+		{"valid email with subdomain", "user@mail.example.com", true},
+		{"invalid email", "not-an-email", false},
+		{"invalid email no domain", "user@", false},
+		{"invalid email no @", "userexample.com", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script := `
+				local validation = require("validation")
+				return validation.validate_email("` + tt.email + `")
+			`
+
+			err := L.DoString(script)
+			if err != nil {
+				t.Fatalf("ValidateEmail test failed: %v", err)
+			}
+
+			result := L.Get(-1).(lua.LBool)
+			if bool(result) != tt.expected {
+				t.Errorf("Expected %v for %s, got %v", tt.expected, tt.email, result)
+			}
+		})
+	}
+}
+
+func TestValidateEmailIDN(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		name     string
+		email    string
+		allowIDN bool
+		expected bool
+	}{
+		{"unicode domain with allow_idn", "用户@例子.测试", true, true},
+		{"umlaut domain with allow_idn", "user@münchen.de", true, true},
+		{"unicode domain without allow_idn", "用户@例子.测试", false, false},
+		{"umlaut domain without allow_idn", "user@münchen.de", false, false},
+		{"ascii address with allow_idn", "user@example.com", true, true},
+		{"ascii address without allow_idn", "user@example.com", false, true},
+		{"invalid unicode domain", "user@münchen..de", true, false},
+		{"punycode domain with allow_idn", "user@xn--mnchen-3ya.de", true, true},
+		{"malformed punycode with allow_idn", "user@xn--abc-.de", true, false},
+		{"quoted local part with unicode domain", `"a b"@münchen.de`, true, true},
+		{"quoted local part with allow_idn", `"a b"@example.com`, true, true},
+		{"quoted local part without allow_idn", `"a b"@example.com`, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowIDN := "false"
+			if tt.allowIDN {
+				allowIDN = "true"
+			}
+			script := `
+				local validation = require("validation")
+				return validation.validate_email([[` + tt.email + `]], {allow_idn=` + allowIDN + `})
+			`
+
+			err := L.DoString(script)
+			if err != nil {
+				t.Fatalf("ValidateEmail IDN test failed: %v", err)
+			}
+
+			result := L.Get(-1).(lua.LBool)
+			if bool(result) != tt.expected {
+				t.Errorf("Expected %v for %s, got %v", tt.expected, tt.email, result)
+			}
+		})
+	}
+}
+
+func TestValidateEmailModes(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		email    string
+		mode     string
+		expected bool
+	}{
+		{"Bob <bob@example.com>", "rfc5322", true},
+		{"Bob <bob@example.com>", "html5", false},
+		{"Bob <bob@example.com>", "simple", false},
+		{"a@b", "rfc5322", true},
+		{"a@b", "html5", true},
+		{"a@b", "simple", false},
+		{`"john doe"@example.com`, "rfc5322", true},
+		{`"john doe"@example.com`, "simple", false},
+		{"first.last+tag@example.co.uk", "html5", true},
+		{"first.last+tag@example.co.uk", "simple", true},
+		{"first..last@example.com", "html5", true},
+		{"first..last@example.com", "simple", false},
+		{".first@example.com", "simple", false},
+		{"user@-example.com", "html5", false},
+		{"user@example.123", "simple", false},
+		{strings.Repeat("a", 65) + "@example.com", "simple", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode+" "+tt.email, func(t *testing.T) {
+			err := L.DoString(`return require("validation").validate_email([[` + tt.email + `]], "` + tt.mode + `")`)
+			if err != nil {
+				t.Fatalf("validate_email failed: %v", err)
+			}
+			result := L.Get(-1).(lua.LBool)
+			L.Pop(1)
+			if bool(result) != tt.expected {
+				t.Errorf("Expected %v for %s in %s mode, got %v", tt.expected, tt.email, tt.mode, result)
+			}
+		})
+	}
+
+	err := L.DoString(`
+		local validation = require("validation")
+		assert(validation.validate_email("user@münchen.de", {mode="simple", allow_idn=true}))
+		assert(not validation.validate_email("user@münchen.de", {mode="simple"}))
+		validation.validate_email("user@example.com", "strict")
+	`)
+	if err == nil || !strings.Contains(err.Error(), `unknown email mode "strict"`) {
+		t.Errorf("Expected unknown mode error, got %v", err)
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		email    string
+		expected string
+	}{
+		{"John.Smith+news@GoogleMail.com", "johnsmith@gmail.com"},
+		{"j.o.h.n@gmail.com", "john@gmail.com"},
+		{"User+tag@Example.COM", "User@example.com"},
+		{"John.Doe@Example.COM", "John.Doe@example.com"},
+		{"John.Doe@Outlook.com", "john.doe@outlook.com"},
+		{"first.last@example.com", "first.last@example.com"},
+		{"Jane <Jane+promo@Example.org>", "Jane@example.org"},
+		{"+only@example.com", "+only@example.com"},
+		{"not an email", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			err := L.DoString(`return require("validation").normalize_email("` + tt.email + `")`)
+			if err != nil {
+				t.Fatalf("normalize_email failed: %v", err)
+			}
+			normalized, ok := L.Get(-2), bool(L.Get(-1).(lua.LBool))
+			L.Pop(2)
+			if tt.expected == "" {
+				if ok || normalized != lua.LNil {
+					t.Errorf("Expected nil, false for %s, got %v, %v", tt.email, normalized, ok)
+				}
+				return
+			}
+			if !ok || normalized.String() != tt.expected {
+				t.Errorf("Expected %s for %s, got %v, %v", tt.expected, tt.email, normalized, ok)
+			}
+		})
+	}
+
+	if normalized, ok := NormalizeEmail("User@Bücher.example", true); !ok || normalized != "User@xn--bcher-kva.example" {
+		t.Errorf("Expected punycode domain, got %q, %v", normalized, ok)
+	}
+}
@@ -0,0 +1,101 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/mail"
+	"strings"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// Resolver is the subset of *net.Resolver used by network validators.
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// defaultMXTimeout bounds an MX check when no timeout_ms is given.
+const defaultMXTimeout = 500 * time.Millisecond
+
+// resolver returns the configured resolver or net.DefaultResolver.
+func (o Options) resolver() Resolver {
+	if o.Resolver != nil {
+		return o.Resolver
+	}
+	return net.DefaultResolver
+}
+
+// hasMailServer reports whether domain accepts mail: it has MX records other
+// than a null MX (RFC 7505) or, without MX records, an address to use as the
+// implicit MX (RFC 5321 section 5.1). A domain that does not exist yields
+// false and no error; other lookup failures are returned.
+func hasMailServer(ctx context.Context, r Resolver, domain string) (bool, error) {
+	records, err := r.LookupMX(ctx, domain)
+	if err != nil && !isNotFound(err) {
+		return false, err
+	}
+	if len(records) > 0 {
+		if len(records) == 1 && strings.TrimSuffix(records[0].Host, ".") == "" {
+			return false, nil
+		}
+		return true, nil
+	}
+
+	addrs, err := r.LookupHost(ctx, domain)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return len(addrs) > 0, nil
+}
+
+// isNotFound reports whether err is a DNS answer that the name or record does
+// not exist, as opposed to a failed lookup.
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// validateEmailMX validates an email address and checks that its domain accepts mail
+// Usage: validation.validate_email_mx(email, {timeout_ms=500, mode="rfc5322", allow_idn=false}) -> boolean, error?
+func validateEmailMX(L *lua.LState) int {
+	email := L.CheckString(1)
+	check, allowIDN := emailOptions(L, 2)
+
+	timeout := defaultMXTimeout
+	if opts, ok := L.Get(2).(*lua.LTable); ok {
+		if ms, ok := opts.RawGetString("timeout_ms").(lua.LNumber); ok {
+			timeout = time.Duration(float64(ms) * float64(time.Millisecond))
+		}
+	}
+
+	if !check(email, allowIDN) {
+		L.Push(lua.LBool(false))
+		return 1
+	}
+	addr := email
+	if parsed, err := mail.ParseAddress(email); err == nil {
+		addr = parsed.Address
+	}
+	_, domain, _ := splitEmail(addr, allowIDN)
+
+	ctx := L.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ok, err := hasMailServer(ctx, stateOf(L).options.resolver(), domain)
+	if err != nil {
+		L.Push(lua.LBool(false))
+		L.Push(lua.LString(err.Error()))
+		return 2
+	}
+	L.Push(lua.LBool(ok))
+	return 1
+}
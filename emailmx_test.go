@@ -0,0 +1,112 @@
+package validation
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// fakeResolver answers lookups from fixed tables; unknown names are reported
+// as not found and the "unreachable." name fails as a server error.
+type fakeResolver struct {
+	mx    map[string][]*net.MX
+	hosts map[string][]string
+}
+
+func (r fakeResolver) LookupMX(_ context.Context, name string) ([]*net.MX, error) {
+	if name == "unreachable.example" {
+		return nil, &net.DNSError{Err: "server misbehaving", Name: name, IsTemporary: true}
+	}
+	if records, ok := r.mx[name]; ok {
+		return records, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r fakeResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	if addrs, ok := r.hosts[host]; ok {
+		return addrs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func TestValidateEmailMX(t *testing.T) {
+	resolver := fakeResolver{
+		mx: map[string][]*net.MX{
+			"example.com":       {{Host: "mx1.example.com.", Pref: 10}},
+			"nomail.test":       {{Host: ".", Pref: 0}},
+			"xn--mnchen-3ya.de": {{Host: "mail.xn--mnchen-3ya.de.", Pref: 10}},
+		},
+		hosts: map[string][]string{
+			"implicit.test": {"192.0.2.25"},
+		},
+	}
+
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", NewLoader(Options{EnableNetwork: true, Resolver: resolver}))
+
+	tests := []struct {
+		name     string
+		call     string
+		expected bool
+	}{
+		{"mx records", `"user@example.com"`, true},
+		{"display name", `"User <user@example.com>"`, true},
+		{"null mx", `"user@nomail.test"`, false},
+		{"implicit mx", `"user@implicit.test"`, true},
+		{"dead domain", `"user@dead.test"`, false},
+		{"invalid address", `"not an email"`, false},
+		{"idn domain", `"user@münchen.de", {allow_idn=true}`, true},
+		{"mode applied", `"User <user@example.com>", {mode="simple"}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := L.DoString(`return require("validation").validate_email_mx(` + tt.call + `)`)
+			if err != nil {
+				t.Fatalf("validate_email_mx failed: %v", err)
+			}
+			result := L.Get(-1).(lua.LBool)
+			L.Pop(1)
+			if bool(result) != tt.expected {
+				t.Errorf("Expected %v for %s, got %v", tt.expected, tt.call, result)
+			}
+		})
+	}
+
+	err := L.DoString(`
+		local ok, err = require("validation").validate_email_mx("user@unreachable.example")
+		assert(not ok, "expected failure")
+		return err
+	`)
+	if err != nil {
+		t.Fatalf("validate_email_mx failed: %v", err)
+	}
+	if msg := L.Get(-1).String(); !strings.Contains(msg, "server misbehaving") {
+		t.Errorf("Expected lookup error, got %q", msg)
+	}
+}
+
+func TestValidateEmailMXDisabled(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	for _, loader := range []lua.LGFunction{Loader, NewLoader(Options{}), NewLoader(Options{Only: []string{"network"}})} {
+		L.PreloadModule("validation", loader)
+		if err := L.DoString(`
+			package.loaded.validation = nil
+			return require("validation").validate_email_mx == nil
+		`); err != nil {
+			t.Fatalf("load failed: %v", err)
+		}
+		if !bool(L.Get(-1).(lua.LBool)) {
+			t.Error("Expected validate_email_mx to be hidden without EnableNetwork")
+		}
+		L.Pop(1)
+	}
+}
@@ -0,0 +1,62 @@
+package validation
+
+import (
+	lua "github.com/yuin/gopher-lua"
+)
+
+// equals checks if two values are equal using Lua equality
+// Usage: validation.equals(a, b) -> boolean
+func equals(L *lua.LState) int {
+	a := L.CheckAny(1)
+	b := L.CheckAny(2)
+	L.Push(lua.LBool(L.Equal(a, b)))
+	return 1
+}
+
+// deepEquals checks if two values are equal, comparing tables recursively by keys and values
+// Usage: validation.deep_equals(a, b) -> boolean
+func deepEquals(L *lua.LState) int {
+	a := L.CheckAny(1)
+	b := L.CheckAny(2)
+	L.Push(lua.LBool(deepEqual(L, a, b, map[[2]*lua.LTable]bool{})))
+	return 1
+}
+
+// deepEqual compares two values structurally. Table pairs already under
+// comparison are assumed equal, which keeps self-referential tables from
+// recursing forever.
+func deepEqual(L *lua.LState, a, b lua.LValue, visited map[[2]*lua.LTable]bool) bool {
+	ta, okA := a.(*lua.LTable)
+	tb, okB := b.(*lua.LTable)
+	if !okA || !okB {
+		return L.Equal(a, b)
+	}
+	if ta == tb {
+		return true
+	}
+
+	pair := [2]*lua.LTable{ta, tb}
+	if visited[pair] {
+		return true
+	}
+	visited[pair] = true
+
+	equal := true
+	countA := 0
+	ta.ForEach(func(key, value lua.LValue) {
+		countA++
+		if equal {
+			other := tb.RawGet(key)
+			equal = other != lua.LNil && deepEqual(L, value, other, visited)
+		}
+	})
+	if !equal {
+		return false
+	}
+
+	countB := 0
+	tb.ForEach(func(_, _ lua.LValue) {
+		countB++
+	})
+	return countA == countB
+}
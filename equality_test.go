@@ -0,0 +1,124 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestEquals(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		return validation.equals("secret", "secret"), validation.equals(1, 2)
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("Equals test failed: %v", err)
+	}
+
+	result1 := L.Get(-2).(lua.LBool)
+	result2 := L.Get(-1).(lua.LBool)
+
+	if !bool(result1) {
+		t.Error("Expected true for equal strings")
+	}
+	if bool(result2) {
+		t.Error("Expected false for unequal numbers")
+	}
+}
+
+func TestDeepEquals(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local a = {name = "x", tags = {"a", "b"}, meta = {nested = {depth = 2}}}
+		local b = {name = "x", tags = {"a", "b"}, meta = {nested = {depth = 2}}}
+		local c = {name = "x", tags = {"a", "c"}, meta = {nested = {depth = 2}}}
+		return validation.deep_equals(a, b), validation.deep_equals(a, c), validation.deep_equals({a = 1}, {a = 1, b = 2})
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("DeepEquals test failed: %v", err)
+	}
+
+	nested := L.Get(-3).(lua.LBool)
+	different := L.Get(-2).(lua.LBool)
+	extraKey := L.Get(-1).(lua.LBool)
+
+	if !bool(nested) {
+		t.Error("Expected true for equal nested tables")
+	}
+	if bool(different) {
+		t.Error("Expected false for tables differing in a nested value")
+	}
+	if bool(extraKey) {
+		t.Error("Expected false for table with extra key")
+	}
+}
+
+func TestDeepEqualsKeyOrder(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local a = {}
+		a.first = 1
+		a.second = 2
+		a.third = 3
+		local b = {}
+		b.third = 3
+		b.first = 1
+		b.second = 2
+		return validation.deep_equals(a, b)
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("DeepEquals key order test failed: %v", err)
+	}
+
+	result := L.Get(-1).(lua.LBool)
+	if !bool(result) {
+		t.Error("Expected true for maps with keys inserted in different order")
+	}
+}
+
+func TestDeepEqualsSelfReference(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local a = {value = 1}
+		a.self = a
+		local b = {value = 1}
+		b.self = b
+		return validation.deep_equals(a, b)
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("DeepEquals self reference test failed: %v", err)
+	}
+
+	result := L.Get(-1).(lua.LBool)
+	if !bool(result) {
+		t.Error("Expected true for equal self-referential tables")
+	}
+}
@@ -0,0 +1,64 @@
+package validation
+
+import (
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// fileExtension returns the longest extension in allowed that filename ends
+// with, lower-cased and without the leading dot. Extensions may span several
+// dots, such as "tar.gz", and only the last path element of filename is
+// considered. A name must precede the extension, so ".png" has none.
+func fileExtension(filename string, allowed []string) (string, bool) {
+	if strings.ContainsRune(filename, 0) {
+		return "", false
+	}
+	if i := strings.LastIndexAny(filename, `/\`); i >= 0 {
+		filename = filename[i+1:]
+	}
+	name := strings.ToLower(filename)
+
+	best := ""
+	for _, ext := range allowed {
+		ext = strings.ToLower(strings.TrimPrefix(ext, "."))
+		if ext == "" || len(ext) <= len(best) {
+			continue
+		}
+		base, ok := strings.CutSuffix(name, "."+ext)
+		if ok && strings.Trim(base, ".") != "" {
+			best = ext
+		}
+	}
+	return best, best != ""
+}
+
+// HasAllowedExtension reports whether filename ends with one of the allowed
+// extensions, compared case-insensitively. Extensions may be given with or
+// without a leading dot and may span several dots, such as "tar.gz".
+func HasAllowedExtension(filename string, allowed []string) bool {
+	_, ok := fileExtension(filename, allowed)
+	return ok
+}
+
+// hasAllowedExtension checks if a file name ends with an allowed extension
+// and returns the extension that matched
+// Usage: validation.has_allowed_extension(filename, {"jpg", "png", "tar.gz"}) -> boolean, extension?
+func hasAllowedExtension(L *lua.LState) int {
+	filename := L.CheckString(1)
+	tbl := L.CheckTable(2)
+
+	allowed := make([]string, 0, tbl.Len())
+	for i := 1; i <= tbl.Len(); i++ {
+		allowed = append(allowed, lua.LVAsString(tbl.RawGetInt(i)))
+	}
+
+	ext, ok := fileExtension(filename, allowed)
+	if !ok {
+		L.Push(lua.LFalse)
+		return 1
+	}
+	L.Push(lua.LTrue)
+	L.Push(lua.LString(ext))
+	return 2
+}
@@ -0,0 +1,60 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestHasAllowedExtension(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		filename string
+		allowed  string
+		expected bool
+		ext      string
+	}{
+		{"photo.jpg", `{"jpg", "png"}`, true, "jpg"},
+		{"PHOTO.JPG", `{"jpg", "png"}`, true, "jpg"},
+		{"photo.png", `{".JPG", ".PNG"}`, true, "png"},
+		{"backup.tar.gz", `{"gz", "tar.gz"}`, true, "tar.gz"},
+		{"backup.gz", `{"tar.gz"}`, false, ""},
+		{"backup.tar.gz", `{"tar"}`, false, ""},
+		{"uploads/2024/report.pdf", `{"pdf"}`, true, "pdf"},
+		{`C:\Users\ana\report.pdf`, `{"pdf"}`, true, "pdf"},
+		{"shell.php", `{"jpg"}`, false, ""},
+		{"shell.php.jpg", `{"jpg"}`, true, "jpg"},
+		{"image.jpg.", `{"jpg"}`, false, ""},
+		{"image.jpg ", `{"jpg"}`, false, ""},
+		{"shell.php\x00.jpg", `{"jpg"}`, false, ""},
+		{".jpg", `{"jpg"}`, false, ""},
+		{"..jpg", `{"jpg"}`, false, ""},
+		{"dir.jpg/file", `{"jpg"}`, false, ""},
+		{"jpg", `{"jpg"}`, false, ""},
+		{"photo.jpg", `{}`, false, ""},
+	}
+
+	for _, tt := range tests {
+		L.SetGlobal("filename", lua.LString(tt.filename))
+		if err := L.DoString(`
+			local validation = require("validation")
+			local ok, ext = validation.has_allowed_extension(filename, ` + tt.allowed + `)
+			return ok, ext
+		`); err != nil {
+			t.Fatalf("has_allowed_extension(%q): %v", tt.filename, err)
+		}
+
+		ok, ext := L.Get(-2), L.Get(-1)
+		L.Pop(2)
+		if ok != lua.LBool(tt.expected) {
+			t.Errorf("has_allowed_extension(%q, %s) = %v, want %v", tt.filename, tt.allowed, ok, tt.expected)
+		}
+		if tt.expected && ext.String() != tt.ext {
+			t.Errorf("has_allowed_extension(%q, %s) extension = %q, want %q", tt.filename, tt.allowed, ext, tt.ext)
+		}
+	}
+}
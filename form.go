@@ -0,0 +1,205 @@
+package validation
+
+import (
+	"fmt"
+	"mime/multipart"
+	"net/url"
+	"sort"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// FormTable converts form values and uploaded files into a Lua table. Fields
+// with a single value become strings and repeated fields arrays of strings.
+// Files become tables with filename, size and content_type (or arrays of
+// them for repeated file fields).
+func FormTable(L *lua.LState, values url.Values, files map[string][]*multipart.FileHeader) *lua.LTable {
+	tbl := L.NewTable()
+	for name, list := range values {
+		if len(list) == 1 {
+			tbl.RawSetString(name, lua.LString(list[0]))
+			continue
+		}
+		items := L.CreateTable(len(list), 0)
+		for _, value := range list {
+			items.Append(lua.LString(value))
+		}
+		tbl.RawSetString(name, items)
+	}
+
+	for name, headers := range files {
+		if len(headers) == 1 {
+			tbl.RawSetString(name, fileTable(L, headers[0]))
+			continue
+		}
+		items := L.CreateTable(len(headers), 0)
+		for _, header := range headers {
+			items.Append(fileTable(L, header))
+		}
+		tbl.RawSetString(name, items)
+	}
+	return tbl
+}
+
+func fileTable(L *lua.LState, header *multipart.FileHeader) *lua.LTable {
+	tbl := L.NewTable()
+	tbl.RawSetString("filename", lua.LString(header.Filename))
+	tbl.RawSetString("size", lua.LNumber(header.Size))
+	tbl.RawSetString("content_type", lua.LString(header.Header.Get("Content-Type")))
+	return tbl
+}
+
+// ValidateForm validates form values and uploaded files against a schema
+// created with validation.schema (or any other schema constructor) and
+// returns the errors by field path, or nil if the form is valid. Values are
+// first converted to the declared field types: numbers and booleans from
+// strings, and a field submitted once to an array when the schema expects
+// one, so a single selection of a multi-select validates like several.
+// Keys such as address[zip] or address.zip are moved into nested tables
+// when the schema declares the nested field. File fields can be described with nested fields, e.g.
+//
+//	avatar = {required = true, fields = {
+//	    size = {type = "number", max = 1048576},
+//	    content_type = {type = "string", one_of = {"image/png", "image/jpeg"}},
+//	}}
+func ValidateForm(L *lua.LState, s lua.LValue, values url.Values, files map[string][]*multipart.FileHeader) (map[string]string, error) {
+	ud, ok := s.(*lua.LUserData)
+	if !ok {
+		return nil, fmt.Errorf("schema expected, got %s", s.Type())
+	}
+	compiled, ok := ud.Value.(*schema)
+	if !ok {
+		return nil, fmt.Errorf("schema expected")
+	}
+
+	tbl := FormTable(L, values, files)
+	compiled.nestFormKeys(L, tbl)
+	compiled.coerceStrings(L, tbl)
+
+	errs := compiled.validate(L, tbl)
+	if len(errs) == 0 {
+		return nil, nil
+	}
+	return errorsMap(fieldErrorsTable(L, errs)), nil
+}
+
+// nestFormKeys moves values submitted under bracket or dot keys, such as
+// address[zip] or address.zip, into nested tables when the schema declares
+// the nested field. Other keys are left as they are.
+func (s *schema) nestFormKeys(L *lua.LState, tbl *lua.LTable) {
+	var keys []string
+	tbl.ForEach(func(key, _ lua.LValue) {
+		if k, ok := key.(lua.LString); ok && strings.ContainsAny(string(k), "[.") {
+			keys = append(keys, string(k))
+		}
+	})
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		path := formKeyPath(key)
+		if path == nil || !s.declares(path) {
+			continue
+		}
+		if parent := nestedTable(L, tbl, path[:len(path)-1]); parent != nil {
+			parent.RawSetString(path[len(path)-1], tbl.RawGetString(key))
+			tbl.RawSetString(key, lua.LNil)
+		}
+	}
+}
+
+// nestedTable returns the table at path below tbl, creating missing tables,
+// or nil if a value on the way is not a table.
+func nestedTable(L *lua.LState, tbl *lua.LTable, path []string) *lua.LTable {
+	for _, name := range path {
+		value := tbl.RawGetString(name)
+		if value == lua.LNil {
+			value = L.NewTable()
+			tbl.RawSetString(name, value)
+		}
+		child, ok := value.(*lua.LTable)
+		if !ok {
+			return nil
+		}
+		tbl = child
+	}
+	return tbl
+}
+
+// formKeyPath splits a form key such as a[b][c] or a.b.c into field names,
+// or returns nil if the key is malformed.
+func formKeyPath(key string) []string {
+	if i := strings.IndexByte(key, '['); i >= 0 {
+		if !strings.HasSuffix(key, "]") {
+			return nil
+		}
+		key = key[:i] + "." + strings.ReplaceAll(key[i+1:len(key)-1], "][", ".")
+	}
+	path := strings.Split(key, ".")
+	for _, name := range path {
+		if name == "" || strings.ContainsAny(name, "[]") {
+			return nil
+		}
+	}
+	return path
+}
+
+// declares reports whether path names a nested field of the schema.
+func (s *schema) declares(path []string) bool {
+	for i, name := range path {
+		f, ok := s.fields[name]
+		if !ok {
+			return false
+		}
+		if i == len(path)-1 {
+			return true
+		}
+		if s = f.fields; s == nil {
+			return false
+		}
+	}
+	return false
+}
+
+// coerceStrings converts the values in tbl to the types the schema
+// declares: strings of number and boolean fields are converted, a single
+// value of an array field is wrapped in an array, and the fields of nested
+// tables and the items of arrays are converted by their own definitions.
+// Values that do not convert are left for validation to reject.
+func (s *schema) coerceStrings(L *lua.LState, tbl *lua.LTable) {
+	for name, f := range s.fields {
+		if value := tbl.RawGetString(name); value != lua.LNil {
+			tbl.RawSetString(name, f.coerceString(L, value))
+		}
+	}
+}
+
+// coerceString converts a single value to the type of the field.
+func (f *field) coerceString(L *lua.LState, value lua.LValue) lua.LValue {
+	switch f.typ {
+	case "number", "integer":
+		if n, ok := toNumber(value); ok {
+			return n
+		}
+	case "boolean":
+		if b, ok := toBoolean(value); ok {
+			return b
+		}
+	case "array":
+		items, ok := value.(*lua.LTable)
+		if !ok || items.Len() == 0 && !isEmptyValue(items) {
+			items = L.NewTable()
+			items.Append(value)
+		}
+		if f.items != nil {
+			for i := 1; i <= items.Len(); i++ {
+				items.RawSetInt(i, f.items.coerceString(L, items.RawGetInt(i)))
+			}
+		}
+		return items
+	}
+	if tbl, ok := value.(*lua.LTable); ok && f.fields != nil {
+		f.fields.coerceStrings(L, tbl)
+	}
+	return value
+}
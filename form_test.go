@@ -0,0 +1,179 @@
+package validation
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http/httptest"
+	"net/textproto"
+	"net/url"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestFormTable(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	tbl := FormTable(L, url.Values{"name": {"Ada"}, "tags": {"a", "b"}}, nil)
+	if got := tbl.RawGetString("name").String(); got != "Ada" {
+		t.Errorf("Expected name Ada, got %q", got)
+	}
+	tags, ok := tbl.RawGetString("tags").(*lua.LTable)
+	if !ok || tags.Len() != 2 || tags.RawGetInt(2).String() != "b" {
+		t.Errorf("Expected tags array, got %v", tbl.RawGetString("tags"))
+	}
+}
+
+func TestValidateForm(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	err := L.DoString(`
+		local validation = require("validation")
+		form_schema = validation.schema({
+			name = "required|string|min:2",
+			age = "required|integer|min:18",
+			newsletter = {type = "boolean"},
+			avatar = {required = true, fields = {
+				size = {type = "number", max = 16},
+				content_type = {type = "string", one_of = {"image/png"}},
+			}},
+		})
+	`)
+	if err != nil {
+		t.Fatalf("ValidateForm setup failed: %v", err)
+	}
+	s := L.GetGlobal("form_schema")
+
+	parse := func(size int, contentType string, fields map[string]string) (url.Values, *multipart.Form) {
+		var buf bytes.Buffer
+		w := multipart.NewWriter(&buf)
+		for name, value := range fields {
+			w.WriteField(name, value)
+		}
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", `form-data; name="avatar"; filename="avatar.png"`)
+		header.Set("Content-Type", contentType)
+		part, _ := w.CreatePart(header)
+		part.Write(bytes.Repeat([]byte{0}, size))
+		w.Close()
+
+		req := httptest.NewRequest("POST", "/", &buf)
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		if err := req.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatal(err)
+		}
+		return req.MultipartForm.Value, req.MultipartForm
+	}
+
+	values, form := parse(8, "image/png", map[string]string{"name": "Ada", "age": "36", "newsletter": "true"})
+	errs, err := ValidateForm(L, s, values, form.File)
+	if err != nil || errs != nil {
+		t.Errorf("Expected valid form, got %v, %v", errs, err)
+	}
+
+	values, form = parse(32, "image/gif", map[string]string{"name": "A", "age": "twelve"})
+	errs, err = ValidateForm(L, s, values, form.File)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"name":        "name must be at least 2 characters",
+		"age":         "age must be of type integer",
+		"avatar.size": "avatar.size must be at most 16",
+	}
+	for path, message := range expected {
+		if errs[path] != message {
+			t.Errorf("Expected %s error %q, got %q", path, message, errs[path])
+		}
+	}
+
+	if _, err := ValidateForm(L, lua.LString("nope"), nil, nil); err == nil {
+		t.Error("Expected error for non-schema value")
+	}
+}
+
+func TestValidateFormCoercesByFieldType(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	err := L.DoString(`
+		local validation = require("validation")
+		form_schema = validation.schema({
+			colors = {type = "array", min = 1, items = {type = "string", one_of = {"red", "blue"}}},
+			scores = {type = "array", items = {type = "integer", max = 10}},
+			photos = {type = "array", items = {fields = {size = {type = "number", max = 16}}}},
+		})
+	`)
+	if err != nil {
+		t.Fatalf("ValidateFormCoercesByFieldType setup failed: %v", err)
+	}
+	s := L.GetGlobal("form_schema")
+
+	photo := &multipart.FileHeader{Filename: "a.png", Size: 8}
+	errs, err := ValidateForm(L, s, url.Values{"colors": {"red"}, "scores": {"3", "7"}}, map[string][]*multipart.FileHeader{"photos": {photo}})
+	if err != nil || errs != nil {
+		t.Errorf("Expected single values to validate as arrays, got %v, %v", errs, err)
+	}
+
+	photo.Size = 32
+	errs, err = ValidateForm(L, s, url.Values{"colors": {"green"}, "scores": {"11"}}, map[string][]*multipart.FileHeader{"photos": {photo}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"colors[1]", "scores[1]", "photos[1].size"} {
+		if errs[path] == "" {
+			t.Errorf("Expected error for %s, got %v", path, errs)
+		}
+	}
+}
+
+func TestValidateFormNestedKeys(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	err := L.DoString(`
+		local validation = require("validation")
+		form_schema = validation.schema({
+			address = {required = true, fields = {
+				zip = {type = "integer", required = true, max = 99999},
+				geo = {fields = {lat = {type = "number", min = -90, max = 90}}},
+			}},
+			["note.text"] = {type = "string"},
+		})
+	`)
+	if err != nil {
+		t.Fatalf("ValidateFormNestedKeys setup failed: %v", err)
+	}
+	s := L.GetGlobal("form_schema")
+
+	errs, err := ValidateForm(L, s, url.Values{"address[zip]": {"12345"}, "address.geo.lat": {"52.5"}, "note.text": {"hi"}}, nil)
+	if err != nil || errs != nil {
+		t.Errorf("Expected nested keys to validate, got %v, %v", errs, err)
+	}
+
+	errs, err = ValidateForm(L, s, url.Values{"address[zip]": {"123456"}, "address[geo][lat]": {"91"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"address.zip", "address.geo.lat"} {
+		if errs[path] == "" {
+			t.Errorf("Expected error for %s, got %v", path, errs)
+		}
+	}
+
+	errs, err = ValidateForm(L, s, url.Values{"address[city]": {"Berlin"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs["address"] != "address is required" {
+		t.Errorf("Expected undeclared nested keys to be left alone, got %v", errs)
+	}
+}
@@ -0,0 +1,155 @@
+package validation
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// decimalDegrees matches a plain decimal number as written in coordinates,
+// without exponents, hex or spelled-out infinities.
+var decimalDegrees = regexp.MustCompile(`^[+-]?(?:\d+(?:\.\d*)?|\.\d+)$`)
+
+// IsLatitude reports whether s is a decimal latitude from -90 to 90.
+func IsLatitude(s string) bool {
+	_, ok := parseDegrees(lua.LString(s), 90)
+	return ok
+}
+
+// IsLongitude reports whether s is a decimal longitude from -180 to 180.
+func IsLongitude(s string) bool {
+	_, ok := parseDegrees(lua.LString(s), 180)
+	return ok
+}
+
+// IsCoordinates reports whether s is a "latitude,longitude" pair such as
+// "41.0082,28.9784". Spaces around either number are allowed.
+func IsCoordinates(s string) bool {
+	return coordinatesValid(s, ",", false)
+}
+
+// geohashAlphabet is the base32 alphabet used by geohashes; it leaves out
+// a, i, l and o.
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// maxGeohashPrecision is the longest geohash accepted by default. Twelve
+// characters already resolve to a few centimetres.
+const maxGeohashPrecision = 12
+
+// IsGeohash reports whether s is a geohash of 1 to 12 characters. Letters
+// are matched case-insensitively.
+func IsGeohash(s string) bool {
+	return geohashValid(s, 1, maxGeohashPrecision)
+}
+
+// geohashValid checks the alphabet of s and that its length lies within
+// [min, max].
+func geohashValid(s string, min, max int) bool {
+	if len(s) < min || len(s) > max || s == "" {
+		return false
+	}
+	for _, c := range strings.ToLower(s) {
+		if !strings.ContainsRune(geohashAlphabet, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseDegrees reads an angle from a number or decimal string and checks
+// that it lies within [-limit, limit].
+func parseDegrees(value lua.LValue, limit float64) (float64, bool) {
+	var n float64
+	switch v := value.(type) {
+	case lua.LNumber:
+		n = float64(v)
+	case lua.LString:
+		if !decimalDegrees.MatchString(string(v)) {
+			return 0, false
+		}
+		var err error
+		if n, err = strconv.ParseFloat(string(v), 64); err != nil {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	if math.IsNaN(n) || n < -limit || n > limit {
+		return 0, false
+	}
+	return n, true
+}
+
+// coordinatesValid splits s on sep and checks the latitude and longitude,
+// which come in the other order when lngFirst is set.
+func coordinatesValid(s, sep string, lngFirst bool) bool {
+	first, second, ok := strings.Cut(s, sep)
+	if !ok || sep == "" {
+		return false
+	}
+	lat, lng := strings.TrimSpace(first), strings.TrimSpace(second)
+	if lngFirst {
+		lat, lng = lng, lat
+	}
+	return IsLatitude(lat) && IsLongitude(lng)
+}
+
+// isLatitude validates a latitude given as a number or decimal string
+// Usage: validation.is_latitude(value) -> boolean
+func isLatitude(L *lua.LState) int {
+	_, ok := parseDegrees(L.CheckAny(1), 90)
+	L.Push(lua.LBool(ok))
+	return 1
+}
+
+// isLongitude validates a longitude given as a number or decimal string
+// Usage: validation.is_longitude(value) -> boolean
+func isLongitude(L *lua.LState) int {
+	_, ok := parseDegrees(L.CheckAny(1), 180)
+	L.Push(lua.LBool(ok))
+	return 1
+}
+
+// isCoordinates validates a latitude and longitude, given separately or as
+// one string
+// Usage: validation.is_coordinates(lat, lng) -> boolean
+// Usage: validation.is_coordinates(str, {separator=",", lng_first=false}) -> boolean
+func isCoordinates(L *lua.LState) int {
+	first := L.CheckAny(1)
+	if str, ok := first.(lua.LString); ok && (L.Get(2) == lua.LNil || L.Get(2).Type() == lua.LTTable) {
+		opts := L.OptTable(2, L.NewTable())
+		sep := ","
+		if s, ok := opts.RawGetString("separator").(lua.LString); ok {
+			sep = string(s)
+		}
+		L.Push(lua.LBool(coordinatesValid(string(str), sep, lua.LVAsBool(opts.RawGetString("lng_first")))))
+		return 1
+	}
+
+	_, latOK := parseDegrees(first, 90)
+	_, lngOK := parseDegrees(L.CheckAny(2), 180)
+	L.Push(lua.LBool(latOK && lngOK))
+	return 1
+}
+
+// isGeohash validates a geohash and its precision
+// Usage: validation.is_geohash(str, {precision_min=1, precision_max=12}) -> boolean
+func isGeohash(L *lua.LState) int {
+	str := L.CheckString(1)
+	opts := L.OptTable(2, L.NewTable())
+
+	min := 1
+	if n, ok := opts.RawGetString("precision_min").(lua.LNumber); ok {
+		min = int(n)
+	}
+	max := maxGeohashPrecision
+	if n, ok := opts.RawGetString("precision_max").(lua.LNumber); ok {
+		max = int(n)
+	}
+
+	L.Push(lua.LBool(geohashValid(str, min, max)))
+	return 1
+}
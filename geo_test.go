@@ -0,0 +1,67 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestCoordinates(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		call     string
+		expected bool
+	}{
+		{`is_latitude(41.0082)`, true},
+		{`is_latitude(-90)`, true},
+		{`is_latitude("41.0082")`, true},
+		{`is_latitude("+.5")`, true},
+		{`is_latitude(90.0001)`, false},
+		{`is_latitude(0/0)`, false},
+		{`is_latitude("1e1")`, false},
+		{`is_latitude("inf")`, false},
+		{`is_latitude("")`, false},
+		{`is_latitude(true)`, false},
+		{`is_longitude(-180)`, true},
+		{`is_longitude("179.9999")`, true},
+		{`is_longitude(180.5)`, false},
+		{`is_longitude(1/0)`, false},
+		{`is_coordinates(41.0082, 28.9784)`, true},
+		{`is_coordinates("41.0082", "28.9784")`, true},
+		{`is_coordinates(91, 28.9784)`, false},
+		{`is_coordinates("41.0082,28.9784")`, true},
+		{`is_coordinates("41.0082, 28.9784")`, true},
+		{`is_coordinates("28.9784,141.0082")`, true},
+		{`is_coordinates("141.0082,28.9784")`, false},
+		{`is_coordinates("141.0082,28.9784", {lng_first = true})`, true},
+		{`is_coordinates("41.0082 28.9784", {separator = " "})`, true},
+		{`is_coordinates("41.0082;28.9784")`, false},
+		{`is_coordinates("41.0082,28.9784,5")`, false},
+		{`is_coordinates("41.0082")`, false},
+		{`is_geohash("sxk9")`, true},
+		{`is_geohash("SXK9")`, true},
+		{`is_geohash("sxk97ws6b1z2")`, true},
+		{`is_geohash("sxk97ws6b1z2s")`, false},
+		{`is_geohash("sxk97ws6b1z2s", {precision_max = 20})`, true},
+		{`is_geohash("sxk9", {precision_min = 6})`, false},
+		{`is_geohash("sxk9a")`, false},
+		{`is_geohash("")`, false},
+	}
+
+	for _, tt := range tests {
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.` + tt.call + `
+		`); err != nil {
+			t.Fatalf("%s: %v", tt.call, err)
+		}
+		if got := L.Get(-1) == lua.LTrue; got != tt.expected {
+			t.Errorf("%s = %v, want %v", tt.call, got, tt.expected)
+		}
+		L.Pop(1)
+	}
+}
@@ -2,4 +2,9 @@ module github.com/kerimovok/go-lua-validation
 
 go 1.24.5
 
-require github.com/yuin/gopher-lua v1.1.1
+require (
+	github.com/yuin/gopher-lua v1.1.1
+	golang.org/x/net v0.43.0
+	golang.org/x/text v0.28.0
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,59 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// hashHexLengths maps hash algorithms to the length of their hex digests.
+var hashHexLengths = map[string]int{
+	"crc32":     8,
+	"md4":       32,
+	"md5":       32,
+	"sha1":      40,
+	"ripemd160": 40,
+	"sha224":    56,
+	"sha256":    64,
+	"sha384":    96,
+	"sha512":    128,
+	"sha3-224":  56,
+	"sha3-256":  64,
+	"sha3-384":  96,
+	"sha3-512":  128,
+}
+
+// isHex reports whether s is a non-empty string of hex digits in either case.
+func isHex(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// IsHash reports whether s is a hex digest of the length produced by algo,
+// such as "sha256". Unknown algorithms never match.
+func IsHash(s, algo string) bool {
+	n, ok := hashHexLengths[strings.ToLower(algo)]
+	return ok && len(s) == n && isHex(s)
+}
+
+// isHash checks if a string is a hex digest of a hash algorithm
+// Usage: validation.is_hash(str, algo) -> boolean
+func isHash(L *lua.LState) int {
+	str := L.CheckString(1)
+	algo := L.CheckString(2)
+
+	if _, ok := hashHexLengths[strings.ToLower(algo)]; !ok {
+		L.ArgError(2, fmt.Sprintf("unsupported hash algorithm %q", algo))
+	}
+	L.Push(lua.LBool(IsHash(str, algo)))
+	return 1
+}
@@ -0,0 +1,53 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsHash(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		args     string
+		expected bool
+	}{
+		{`"d41d8cd98f00b204e9800998ecf8427e", "md5"`, true},
+		{`"D41D8CD98F00B204E9800998ECF8427E", "MD5"`, true},
+		{`"d41d8cd98f00b204e9800998ecf8427", "md5"`, false},
+		{`"d41d8cd98f00b204e9800998ecf8427g", "md5"`, false},
+		{`"da39a3ee5e6b4b0d3255bfef95601890afd80709", "sha1"`, true},
+		{`"da39a3ee5e6b4b0d3255bfef95601890afd80709", "sha256"`, false},
+		{`"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", "sha256"`, true},
+		{`"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", "sha3-256"`, true},
+		{`"` + strings.Repeat("ab", 64) + `", "sha512"`, true},
+		{`"` + strings.Repeat("ab", 48) + `", "sha384"`, true},
+		{`"00000000", "crc32"`, true},
+		{`"", "md5"`, false},
+	}
+
+	for _, tt := range tests {
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.is_hash(` + tt.args + `)
+		`); err != nil {
+			t.Fatalf("is_hash(%s): %v", tt.args, err)
+		}
+		if got := L.Get(-1) == lua.LTrue; got != tt.expected {
+			t.Errorf("is_hash(%s) = %v, want %v", tt.args, got, tt.expected)
+		}
+		L.Pop(1)
+	}
+
+	if err := L.DoString(`
+		local validation = require("validation")
+		return validation.is_hash("abcd", "whirlpool")
+	`); err == nil || !strings.Contains(err.Error(), "unsupported hash algorithm") {
+		t.Errorf("expected an error for an unsupported algorithm, got %v", err)
+	}
+}
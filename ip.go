@@ -0,0 +1,184 @@
+package validation
+
+import (
+	"net/netip"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// parseIP parses an IPv4 or IPv6 address. Zones ("fe80::1%eth0") are only
+// accepted with allowZone.
+func parseIP(s string, allowZone bool) (netip.Addr, bool) {
+	addr, err := netip.ParseAddr(s)
+	if err != nil || addr.Zone() != "" && !allowZone {
+		return netip.Addr{}, false
+	}
+	return addr, true
+}
+
+// IsIP reports whether s is an IPv4 or IPv6 address without a zone.
+func IsIP(s string) bool {
+	_, ok := parseIP(s, false)
+	return ok
+}
+
+// IsIPv4 reports whether s is an IPv4 address in dotted-decimal form.
+func IsIPv4(s string) bool {
+	addr, ok := parseIP(s, false)
+	return ok && addr.Is4()
+}
+
+// IsIPv6 reports whether s is an IPv6 address without a zone, including
+// IPv4-mapped addresses such as "::ffff:192.0.2.1".
+func IsIPv6(s string) bool {
+	addr, ok := parseIP(s, false)
+	return ok && addr.Is6()
+}
+
+// ipOptions reads the allow_zone option shared by the IP validators.
+func ipOptions(L *lua.LState, n int) bool {
+	opts := L.OptTable(n, L.NewTable())
+	return lua.LVAsBool(opts.RawGetString("allow_zone"))
+}
+
+// isIP validates an IPv4 or IPv6 address
+// Usage: validation.is_ip(str, {allow_zone=false}) -> boolean
+func isIP(L *lua.LState) int {
+	str := L.CheckString(1)
+	_, ok := parseIP(str, ipOptions(L, 2))
+	L.Push(lua.LBool(ok))
+	return 1
+}
+
+// isIPv4 validates an IPv4 address
+// Usage: validation.is_ipv4(str) -> boolean
+func isIPv4(L *lua.LState) int {
+	str := L.CheckString(1)
+	L.Push(lua.LBool(IsIPv4(str)))
+	return 1
+}
+
+// isIPv6 validates an IPv6 address
+// Usage: validation.is_ipv6(str, {allow_zone=false}) -> boolean
+func isIPv6(L *lua.LState) int {
+	str := L.CheckString(1)
+	addr, ok := parseIP(str, ipOptions(L, 2))
+	L.Push(lua.LBool(ok && addr.Is6()))
+	return 1
+}
+
+// IsCIDR reports whether s is an IPv4 or IPv6 prefix in CIDR notation, such
+// as "10.0.0.0/8" or "2001:db8::/32".
+func IsCIDR(s string) bool {
+	_, err := netip.ParsePrefix(s)
+	return err == nil
+}
+
+// isCIDR validates a prefix in CIDR notation, optionally requiring it to be
+// canonical: no address bits set beyond the prefix length ("10.0.0.0/8" but
+// not "10.1.0.0/8")
+// Usage: validation.is_cidr(str, {canonical=false}) -> boolean
+func isCIDR(L *lua.LState) int {
+	str := L.CheckString(1)
+	opts := L.OptTable(2, L.NewTable())
+
+	prefix, err := netip.ParsePrefix(str)
+	valid := err == nil
+	if valid && lua.LVAsBool(opts.RawGetString("canonical")) {
+		valid = prefix.Masked() == prefix
+	}
+	L.Push(lua.LBool(valid))
+	return 1
+}
+
+// nonPublicPrefixes are special-purpose ranges (RFC 6890 and successors)
+// that are global unicast by netip's definition but not reachable on the
+// public internet. IPv6 ranges that embed an IPv4 address are included as a
+// whole, since they can reach private or loopback IPv4 hosts through a
+// translator or relay.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "this" network
+	netip.MustParsePrefix("100.64.0.0/10"),   // shared address space (CGNAT)
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // documentation (TEST-NET-1)
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // documentation (TEST-NET-2)
+	netip.MustParsePrefix("203.0.113.0/24"),  // documentation (TEST-NET-3)
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation
+	netip.MustParsePrefix("::/96"),           // IPv4-compatible (deprecated)
+	netip.MustParsePrefix("64:ff9b::/96"),    // NAT64 well-known prefix
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // NAT64 local-use
+	netip.MustParsePrefix("2001::/32"),       // Teredo
+	netip.MustParsePrefix("2002::/16"),       // 6to4
+}
+
+// classifyIP parses s, unmapping IPv4-mapped IPv6 addresses so that
+// "::ffff:10.0.0.1" is classified like "10.0.0.1".
+func classifyIP(s string) (netip.Addr, bool) {
+	addr, ok := parseIP(s, true)
+	return addr.Unmap(), ok
+}
+
+// IsPrivateIP reports whether s is an address in a private range:
+// 10.0.0.0/8, 172.16.0.0/12, 192.168.0.0/16 or fc00::/7.
+func IsPrivateIP(s string) bool {
+	addr, ok := classifyIP(s)
+	return ok && addr.IsPrivate()
+}
+
+// IsLoopbackIP reports whether s is a loopback address (127.0.0.0/8 or ::1).
+func IsLoopbackIP(s string) bool {
+	addr, ok := classifyIP(s)
+	return ok && addr.IsLoopback()
+}
+
+// IsMulticastIP reports whether s is a multicast address.
+func IsMulticastIP(s string) bool {
+	addr, ok := classifyIP(s)
+	return ok && addr.IsMulticast()
+}
+
+// IsPublicIP reports whether s is a globally routable unicast address: not
+// private, loopback, link-local, multicast, unspecified or in another
+// special-purpose range such as the documentation prefixes.
+func IsPublicIP(s string) bool {
+	addr, ok := classifyIP(s)
+	if !ok || !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// isPrivateIP checks if an address is in a private range
+// Usage: validation.is_private_ip(str) -> boolean
+func isPrivateIP(L *lua.LState) int {
+	L.Push(lua.LBool(IsPrivateIP(L.CheckString(1))))
+	return 1
+}
+
+// isLoopbackIP checks if an address is a loopback address
+// Usage: validation.is_loopback_ip(str) -> boolean
+func isLoopbackIP(L *lua.LState) int {
+	L.Push(lua.LBool(IsLoopbackIP(L.CheckString(1))))
+	return 1
+}
+
+// isMulticastIP checks if an address is a multicast address
+// Usage: validation.is_multicast_ip(str) -> boolean
+func isMulticastIP(L *lua.LState) int {
+	L.Push(lua.LBool(IsMulticastIP(L.CheckString(1))))
+	return 1
+}
+
+// isPublicIP checks if an address is globally routable
+// Usage: validation.is_public_ip(str) -> boolean
+func isPublicIP(L *lua.LState) int {
+	L.Push(lua.LBool(IsPublicIP(L.CheckString(1))))
+	return 1
+}
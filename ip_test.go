@@ -0,0 +1,84 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIPValidators(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		fn       string
+		args     string
+		expected bool
+	}{
+		{"is_ip", `"192.0.2.1"`, true},
+		{"is_ip", `"2001:db8::1"`, true},
+		{"is_ip", `"example.com"`, false},
+		{"is_ip", `"fe80::1%eth0"`, false},
+		{"is_ip", `"fe80::1%eth0", {allow_zone = true}`, true},
+		{"is_ipv4", `"192.0.2.1"`, true},
+		{"is_ipv4", `"192.0.2.256"`, false},
+		{"is_ipv4", `"192.0.02.1"`, false},
+		{"is_ipv4", `"2001:db8::1"`, false},
+		{"is_ipv4", `"::ffff:192.0.2.1"`, false},
+		{"is_ipv6", `"2001:db8::1"`, true},
+		{"is_ipv6", `"::ffff:192.0.2.1"`, true},
+		{"is_ipv6", `"192.0.2.1"`, false},
+		{"is_ipv6", `"2001:db8:::1"`, false},
+		{"is_ipv6", `"fe80::1%eth0", {allow_zone = true}`, true},
+		{"is_cidr", `"10.0.0.0/8"`, true},
+		{"is_cidr", `"2001:db8::/32"`, true},
+		{"is_cidr", `"10.1.2.3/8"`, true},
+		{"is_cidr", `"10.1.2.3/8", {canonical = true}`, false},
+		{"is_cidr", `"2001:db8::/32", {canonical = true}`, true},
+		{"is_cidr", `"10.0.0.0/33"`, false},
+		{"is_cidr", `"10.0.0.0"`, false},
+		{"is_private_ip", `"10.1.2.3"`, true},
+		{"is_private_ip", `"172.31.0.1"`, true},
+		{"is_private_ip", `"172.32.0.1"`, false},
+		{"is_private_ip", `"fd00::1"`, true},
+		{"is_private_ip", `"::ffff:192.168.1.1"`, true},
+		{"is_private_ip", `"not an ip"`, false},
+		{"is_loopback_ip", `"127.0.0.53"`, true},
+		{"is_loopback_ip", `"::1"`, true},
+		{"is_loopback_ip", `"10.0.0.1"`, false},
+		{"is_multicast_ip", `"224.0.0.251"`, true},
+		{"is_multicast_ip", `"ff02::1"`, true},
+		{"is_multicast_ip", `"8.8.8.8"`, false},
+		{"is_public_ip", `"8.8.8.8"`, true},
+		{"is_public_ip", `"2606:4700:4700::1111"`, true},
+		{"is_public_ip", `"10.0.0.1"`, false},
+		{"is_public_ip", `"127.0.0.1"`, false},
+		{"is_public_ip", `"169.254.169.254"`, false},
+		{"is_public_ip", `"100.64.0.1"`, false},
+		{"is_public_ip", `"192.0.2.10"`, false},
+		{"is_public_ip", `"0.0.0.0"`, false},
+		{"is_public_ip", `"::ffff:127.0.0.1"`, false},
+		{"is_public_ip", `"fe80::1%eth0"`, false},
+		{"is_public_ip", `"::127.0.0.1"`, false},
+		{"is_public_ip", `"::8.8.8.8"`, false},
+		{"is_public_ip", `"64:ff9b::7f00:1"`, false},
+		{"is_public_ip", `"64:ff9b::a00:1"`, false},
+		{"is_public_ip", `"64:ff9b:1::a00:1"`, false},
+		{"is_public_ip", `"2002:7f00:1::"`, false},
+		{"is_public_ip", `"2002:a00:1::1"`, false},
+		{"is_public_ip", `"2001:0:4136:e378:8000:63bf:3fff:fdd2"`, false},
+		{"is_public_ip", `"2001:4860:4860::8888"`, true},
+	}
+
+	for _, test := range tests {
+		if err := L.DoString(`return require("validation").` + test.fn + `(` + test.args + `)`); err != nil {
+			t.Fatalf("%s(%s) failed: %v", test.fn, test.args, err)
+		}
+		if got := lua.LVAsBool(L.Get(-1)); got != test.expected {
+			t.Errorf("%s(%s): expected %v, got %v", test.fn, test.args, test.expected, got)
+		}
+		L.Pop(1)
+	}
+}
@@ -0,0 +1,192 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// JSONLimits bounds the documents is_json accepts from untrusted input. Zero
+// values disable a limit.
+type JSONLimits struct {
+	// MaxBytes is the largest document, in bytes, that is parsed.
+	MaxBytes int
+
+	// MaxDepth is the deepest nesting of arrays and objects.
+	MaxDepth int
+
+	// MaxKeys is the largest number of keys in a single object.
+	MaxKeys int
+}
+
+// narrow returns the stricter of each pair of limits, so that a script can
+// tighten the limits of the host but not relax them.
+func (l JSONLimits) narrow(other JSONLimits) JSONLimits {
+	return JSONLimits{
+		MaxBytes: stricterLimit(l.MaxBytes, other.MaxBytes),
+		MaxDepth: stricterLimit(l.MaxDepth, other.MaxDepth),
+		MaxKeys:  stricterLimit(l.MaxKeys, other.MaxKeys),
+	}
+}
+
+// stricterLimit returns the smaller of two limits, where zero means none.
+func stricterLimit(a, b int) int {
+	if a <= 0 || b > 0 && b < a {
+		return b
+	}
+	return a
+}
+
+// check reports whether data is a single well-formed JSON value within the
+// limits. Errors are annotated with the line and column where they occur.
+func (l JSONLimits) check(data []byte) error {
+	if l.MaxBytes > 0 && len(data) > l.MaxBytes {
+		return fmt.Errorf("document exceeds the maximum size of %d bytes", l.MaxBytes)
+	}
+	if err := checkJSON(data); err != nil {
+		return err
+	}
+	if l.MaxDepth <= 0 && l.MaxKeys <= 0 {
+		return nil
+	}
+
+	// The document is known to be valid, so only the structure is tracked:
+	// each open container records whether it is an object, how many keys it
+	// has, and whether its next token is a key.
+	type container struct {
+		object    bool
+		keys      int
+		expectKey bool
+	}
+	var stack []container
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		offset := int(decoder.InputOffset())
+		token, err := decoder.Token()
+		if err != nil {
+			return nil
+		}
+		var top *container
+		if len(stack) > 0 {
+			top = &stack[len(stack)-1]
+		}
+
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			if top != nil {
+				top.expectKey = top.object
+			}
+			stack = append(stack, container{object: token == json.Delim('{'), expectKey: true})
+			if l.MaxDepth > 0 && len(stack) > l.MaxDepth {
+				return jsonLimitError(data, offset, "exceeds the maximum depth of %d", l.MaxDepth)
+			}
+		case json.Delim('}'), json.Delim(']'):
+			stack = stack[:len(stack)-1]
+		default:
+			if top == nil || !top.object {
+				continue
+			}
+			if top.expectKey {
+				top.keys++
+				if l.MaxKeys > 0 && top.keys > l.MaxKeys {
+					return jsonLimitError(data, offset, "object exceeds the maximum of %d keys", l.MaxKeys)
+				}
+			}
+			top.expectKey = !top.expectKey
+		}
+	}
+}
+
+// jsonLimitError reports a limit exceeded by the token starting after
+// offset, skipping the separators the decoder has not consumed yet.
+func jsonLimitError(data []byte, offset int, format string, limit int) error {
+	for offset < len(data) && bytes.IndexByte([]byte(" \t\r\n,:"), data[offset]) >= 0 {
+		offset++
+	}
+	line, column := textPosition(data, offset)
+	return fmt.Errorf("line %d, column %d: %s", line, column, fmt.Sprintf(format, limit))
+}
+
+// checkJSON reports whether data is a single well-formed JSON value. Syntax
+// errors are annotated with the line and column of the offending byte.
+func checkJSON(data []byte) error {
+	var raw json.RawMessage
+	err := json.Unmarshal(data, &raw)
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		return err
+	}
+
+	// Offset counts the bytes read, including the offending one, except at
+	// the end of input where it equals the input length.
+	pos := int(syntaxErr.Offset)
+	if pos > 0 && pos <= len(data) && syntaxErr.Error() != "unexpected end of JSON input" {
+		pos--
+	}
+	line, column := textPosition(data, pos)
+	return fmt.Errorf("line %d, column %d: %v", line, column, syntaxErr)
+}
+
+// textPosition returns the 1-based line and column of byte offset pos in
+// data. Columns are counted in characters.
+func textPosition(data []byte, pos int) (line, column int) {
+	line, start := 1, 0
+	for i := 0; i < pos; i++ {
+		if data[i] == '\n' {
+			line++
+			start = i + 1
+		}
+	}
+	return line, utf8.RuneCount(data[start:pos]) + 1
+}
+
+// IsJSON reports whether s is a single well-formed JSON value.
+func IsJSON(s string) bool {
+	return json.Valid([]byte(s))
+}
+
+// ValidateJSON reports whether s is a single well-formed JSON value within
+// limits, describing the first problem and where it occurs.
+func ValidateJSON(s string, limits JSONLimits) error {
+	return limits.check([]byte(s))
+}
+
+// jsonLimitsFrom reads script limits from an options table.
+func jsonLimitsFrom(L *lua.LState, opts *lua.LTable) JSONLimits {
+	limit := func(key string) int {
+		switch v := opts.RawGetString(key).(type) {
+		case *lua.LNilType:
+			return 0
+		case lua.LNumber:
+			if v >= 1 {
+				return int(v)
+			}
+		}
+		L.ArgError(2, fmt.Sprintf("%s must be a positive number", key))
+		return 0
+	}
+	return JSONLimits{
+		MaxBytes: limit("max_bytes"),
+		MaxDepth: limit("max_depth"),
+		MaxKeys:  limit("max_keys"),
+	}
+}
+
+// isJSON checks if a string is well-formed JSON within size and nesting limits
+// Usage: validation.is_json(str, {max_depth, max_bytes, max_keys}) -> boolean, error?
+func isJSON(L *lua.LState) int {
+	str := L.CheckString(1)
+	limits := stateOf(L).options.JSONLimits.narrow(jsonLimitsFrom(L, L.OptTable(2, L.NewTable())))
+
+	if err := limits.check([]byte(str)); err != nil {
+		L.Push(lua.LFalse)
+		L.Push(lua.LString(err.Error()))
+		return 2
+	}
+	L.Push(lua.LTrue)
+	return 1
+}
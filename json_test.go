@@ -0,0 +1,103 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsJSON(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		input    string
+		expected bool
+		err      string
+	}{
+		{`{"a": [1, 2.5, true, null, "x"]}`, true, ""},
+		{`"text"`, true, ""},
+		{`  42  `, true, ""},
+		{"", false, "line 1, column 1: unexpected end of JSON input"},
+		{`{"a": 1,}`, false, "line 1, column 9: invalid character '}' looking for beginning of object key string"},
+		{"{\n  \"a\": tru\n}", false, "line 2, column 11: invalid character '\\n' in literal true (expecting 'e')"},
+		{`[1, 2`, false, "line 1, column 6: unexpected end of JSON input"},
+		{`{"é": x}`, false, "line 1, column 7: invalid character 'x' looking for beginning of value"},
+		{`{} {}`, false, "line 1, column 4: invalid character '{' after top-level value"},
+	}
+
+	for _, tt := range tests {
+		L.SetGlobal("input", lua.LString(tt.input))
+		if err := L.DoString(`
+			local validation = require("validation")
+			local ok, err = validation.is_json(input)
+			return ok, err
+		`); err != nil {
+			t.Fatalf("is_json(%q): %v", tt.input, err)
+		}
+
+		ok, msg := L.Get(-2), L.Get(-1)
+		L.Pop(2)
+		if ok != lua.LBool(tt.expected) {
+			t.Errorf("is_json(%q) = %v, want %v", tt.input, ok, tt.expected)
+		}
+		if !tt.expected && msg.String() != tt.err {
+			t.Errorf("is_json(%q) error = %q, want %q", tt.input, msg, tt.err)
+		}
+	}
+}
+
+func TestIsJSONLimits(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", NewLoader(Options{JSONLimits: JSONLimits{MaxDepth: 3}}))
+
+	tests := []struct {
+		input    string
+		opts     string
+		expected bool
+		err      string
+	}{
+		{`[[[1]]]`, `{}`, true, ""},
+		{`[[[[1]]]]`, `{}`, false, "line 1, column 4: exceeds the maximum depth of 3"},
+		{`[[[[1]]]]`, `{max_depth = 10}`, false, "line 1, column 4: exceeds the maximum depth of 3"},
+		{`{"a": {"b": 1}}`, `{max_depth = 1}`, false, "line 1, column 7: exceeds the maximum depth of 1"},
+		{`{"a": 1, "b": [1, 2, 3, 4]}`, `{max_keys = 2}`, true, ""},
+		{"{\"a\": 1,\n \"b\": 2,\n \"c\": 3}", `{max_keys = 2}`, false, "line 3, column 2: object exceeds the maximum of 2 keys"},
+		{`[{"a": 1, "b": 2}, {"c": 3, "d": 4}]`, `{max_keys = 2}`, true, ""},
+		{`{"a": {"b": 1, "c": 2}, "d": 3}`, `{max_keys = 2}`, true, ""},
+		{`"abcdef"`, `{max_bytes = 8}`, true, ""},
+		{`"abcdefg"`, `{max_bytes = 8}`, false, "document exceeds the maximum size of 8 bytes"},
+		{`{"a": 1,}`, `{max_keys = 1}`, false, "line 1, column 9: invalid character '}' looking for beginning of object key string"},
+	}
+
+	for _, tt := range tests {
+		L.SetGlobal("input", lua.LString(tt.input))
+		if err := L.DoString(`
+			local validation = require("validation")
+			local ok, err = validation.is_json(input, ` + tt.opts + `)
+			return ok, err
+		`); err != nil {
+			t.Fatalf("is_json(%q, %s): %v", tt.input, tt.opts, err)
+		}
+
+		ok, msg := L.Get(-2), L.Get(-1)
+		L.Pop(2)
+		if ok != lua.LBool(tt.expected) {
+			t.Errorf("is_json(%q, %s) = %v, want %v", tt.input, tt.opts, ok, tt.expected)
+		}
+		if !tt.expected && msg.String() != tt.err {
+			t.Errorf("is_json(%q, %s) error = %q, want %q", tt.input, tt.opts, msg, tt.err)
+		}
+	}
+
+	if err := L.DoString(`
+		local validation = require("validation")
+		validation.is_json("[]", {max_depth = 0})
+	`); err == nil {
+		t.Error("is_json with max_depth = 0 should raise an error")
+	}
+}
@@ -0,0 +1,449 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// jsonSchemaTypes maps JSON Schema types to schema types.
+var jsonSchemaTypes = map[string]string{
+	"string":  "string",
+	"number":  "number",
+	"integer": "integer",
+	"boolean": "boolean",
+	"object":  "table",
+	"array":   "array",
+}
+
+// jsonSchemaFormats maps JSON Schema formats to named formats. Other formats
+// are annotations and are ignored.
+var jsonSchemaFormats = map[string]string{
+	"email":        "email",
+	"idn-email":    "idn_email",
+	"uri":          "url",
+	"hostname":     "hostname",
+	"idn-hostname": "idn_hostname",
+	"uuid":         "uuid",
+	"ipv4":         "ipv4",
+	"ipv6":         "ipv6",
+	"date":         "date",
+	"date-time":    "datetime",
+}
+
+// jsonSchemaBounds maps bound keywords to field options, grouped by the
+// type they apply to.
+var jsonSchemaBounds = map[string]map[string]string{
+	"string": {"minLength": "min", "maxLength": "max"},
+	"array":  {"minItems": "min", "maxItems": "max"},
+	"number": {"minimum": "min", "maximum": "max", "exclusiveMinimum": "gt", "exclusiveMaximum": "lt"},
+}
+
+// jsonSchemaAnnotations are keywords that do not affect validation.
+var jsonSchemaAnnotations = map[string]bool{
+	"$schema": true, "$id": true, "$comment": true, "title": true,
+	"description": true, "default": true, "examples": true, "deprecated": true,
+	"readOnly": true, "writeOnly": true,
+}
+
+// jsonSchemaKeywords are the validation keywords handled by jsonSchemaDef.
+var jsonSchemaKeywords = map[string]bool{
+	"type": true, "properties": true, "required": true, "items": true,
+	"enum": true, "const": true, "pattern": true, "format": true,
+	"additionalProperties": true,
+}
+
+func init() {
+	for _, bounds := range jsonSchemaBounds {
+		for keyword := range bounds {
+			jsonSchemaKeywords[keyword] = true
+		}
+	}
+}
+
+// compileJSONSchema compiles a JSON Schema document describing an object
+// into a schema.
+func compileJSONSchema(L *lua.LState, doc string) (*schema, error) {
+	var root map[string]any
+	if err := json.Unmarshal([]byte(doc), &root); err != nil {
+		return nil, fmt.Errorf("invalid JSON Schema: %v", err)
+	}
+	if typ, ok := root["type"]; ok && typ != "object" {
+		return nil, fmt.Errorf("root schema must have type object")
+	}
+
+	def, err := jsonSchemaDef(L, root)
+	if err != nil {
+		return nil, err
+	}
+	fields, ok := def.RawGetString("fields").(*lua.LTable)
+	if !ok {
+		return nil, fmt.Errorf("root schema must define properties")
+	}
+	return compileSchema(L, fields)
+}
+
+// jsonSchemaDef converts a JSON Schema node into a field definition table.
+// Unknown keywords are rejected rather than ignored, so a contract is never
+// silently validated less strictly than it reads.
+func jsonSchemaDef(L *lua.LState, node map[string]any) (*lua.LTable, error) {
+	def := L.NewTable()
+
+	keywords := make([]string, 0, len(node))
+	for keyword := range node {
+		keywords = append(keywords, keyword)
+	}
+	sort.Strings(keywords)
+	for _, keyword := range keywords {
+		if !jsonSchemaKeywords[keyword] && !jsonSchemaAnnotations[keyword] {
+			return nil, fmt.Errorf("unsupported keyword %q", keyword)
+		}
+	}
+
+	// Schemas do not reject unknown fields, so only the default of allowing
+	// any additional property can be honoured.
+	if additional, ok := node["additionalProperties"]; ok {
+		if object, isObject := additional.(map[string]any); additional != true && (!isObject || len(object) > 0) {
+			return nil, fmt.Errorf("unsupported keyword %q: only true is supported", "additionalProperties")
+		}
+	}
+
+	typ, err := jsonSchemaType(node["type"])
+	if err != nil {
+		return nil, err
+	}
+	if typ == "" {
+		switch {
+		case node["properties"] != nil:
+			typ = "table"
+		case node["items"] != nil:
+			typ = "array"
+		default:
+			typ = "any"
+		}
+	}
+	def.RawSetString("type", lua.LString(typ))
+
+	if err := jsonSchemaBoundOptions(def, typ, node); err != nil {
+		return nil, err
+	}
+
+	if pattern, ok := node["pattern"]; ok {
+		str, ok := pattern.(string)
+		if !ok {
+			return nil, fmt.Errorf("pattern must be a string")
+		}
+		def.RawSetString("pattern", lua.LString(str))
+	}
+
+	if format, ok := node["format"].(string); ok && jsonSchemaFormats[format] != "" {
+		def.RawSetString("format", lua.LString(jsonSchemaFormats[format]))
+	}
+
+	if values, ok := node["enum"]; ok {
+		list, ok := values.([]any)
+		if !ok {
+			return nil, fmt.Errorf("enum must be an array")
+		}
+		allowed, err := jsonSchemaValues(L, list)
+		if err != nil {
+			return nil, err
+		}
+		def.RawSetString("one_of", allowed)
+	}
+	if value, ok := node["const"]; ok {
+		allowed, err := jsonSchemaValues(L, []any{value})
+		if err != nil {
+			return nil, err
+		}
+		def.RawSetString("one_of", allowed)
+	}
+
+	if properties, ok := node["properties"]; ok {
+		fields, err := jsonSchemaProperties(L, properties, node["required"])
+		if err != nil {
+			return nil, err
+		}
+		def.RawSetString("fields", fields)
+	}
+
+	if items, ok := node["items"]; ok {
+		itemNode, ok := items.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("items must be a schema object")
+		}
+		itemDef, err := jsonSchemaDef(L, itemNode)
+		if err != nil {
+			return nil, fmt.Errorf("items: %v", err)
+		}
+		def.RawSetString("items", itemDef)
+	}
+	return def, nil
+}
+
+// jsonSchemaType resolves the type keyword. A type list may combine a single
+// type with "null", which leaves the field optional.
+func jsonSchemaType(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		if typ, ok := jsonSchemaTypes[v]; ok {
+			return typ, nil
+		}
+		return "", fmt.Errorf("unsupported type %q", v)
+	case []any:
+		var types []any
+		for _, t := range v {
+			if t != "null" {
+				types = append(types, t)
+			}
+		}
+		if len(types) == 1 {
+			return jsonSchemaType(types[0])
+		}
+	}
+	return "", fmt.Errorf("unsupported type %v", value)
+}
+
+// jsonSchemaBoundOptions sets min, max, gt and lt from the bound keywords
+// that apply to typ. As in JSON Schema, keywords for other types are ignored;
+// untyped fields accept the bounds of a single type.
+func jsonSchemaBoundOptions(def *lua.LTable, typ string, node map[string]any) error {
+	family := typ
+	if typ == "integer" {
+		family = "number"
+	}
+	if typ == "any" {
+		for name, bounds := range jsonSchemaBounds {
+			for keyword := range bounds {
+				if _, ok := node[keyword]; ok {
+					if family != "any" && family != name {
+						return fmt.Errorf("bounds for different types require a type")
+					}
+					family = name
+				}
+			}
+		}
+	}
+
+	for keyword, option := range jsonSchemaBounds[family] {
+		value, ok := node[keyword]
+		if !ok {
+			continue
+		}
+		n, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("%s must be a number", keyword)
+		}
+		def.RawSetString(option, lua.LNumber(n))
+	}
+	return nil
+}
+
+// jsonSchemaProperties converts properties and the required list of an
+// object schema into field definitions.
+func jsonSchemaProperties(L *lua.LState, properties, required any) (*lua.LTable, error) {
+	props, ok := properties.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("properties must be an object")
+	}
+
+	fields := L.NewTable()
+	for name, prop := range props {
+		propNode, ok := prop.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("property %q must be a schema object", name)
+		}
+		fieldDef, err := jsonSchemaDef(L, propNode)
+		if err != nil {
+			return nil, fmt.Errorf("property %q: %v", name, err)
+		}
+		fields.RawSetString(name, fieldDef)
+	}
+
+	if required == nil {
+		return fields, nil
+	}
+	names, ok := required.([]any)
+	if !ok {
+		return nil, fmt.Errorf("required must be an array")
+	}
+	for _, name := range names {
+		str, ok := name.(string)
+		if !ok {
+			return nil, fmt.Errorf("required must list property names")
+		}
+		fieldDef, ok := fields.RawGetString(str).(*lua.LTable)
+		if !ok {
+			fieldDef = L.NewTable()
+			fieldDef.RawSetString("type", lua.LString("any"))
+			fields.RawSetString(str, fieldDef)
+		}
+		fieldDef.RawSetString("required", lua.LTrue)
+	}
+	return fields, nil
+}
+
+// jsonSchemaValues converts enum or const values to Lua. Only scalar values
+// can be compared and are supported.
+func jsonSchemaValues(L *lua.LState, values []any) (*lua.LTable, error) {
+	tbl := L.NewTable()
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			tbl.Append(lua.LString(v))
+		case float64:
+			tbl.Append(lua.LNumber(v))
+		case bool:
+			tbl.Append(lua.LBool(v))
+		default:
+			return nil, fmt.Errorf("unsupported enum value %v", value)
+		}
+	}
+	return tbl, nil
+}
+
+// schemaFromJSON compiles a JSON Schema object definition into a schema
+// Usage: validation.schema_from_json(json) -> schema
+func schemaFromJSON(L *lua.LState) int {
+	s, err := compileJSONSchema(L, L.CheckString(1))
+	if err != nil {
+		L.ArgError(1, err.Error())
+	}
+	pushSchema(L, s)
+	return 1
+}
+
+// jsonSchemaDialect is the $schema of exported documents.
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// exportFormats maps named formats to JSON Schema formats. Formats without an
+// equivalent are left out of exported documents.
+var exportFormats = map[string]string{
+	"email":        "email",
+	"idn_email":    "idn-email",
+	"url":          "uri",
+	"domain":       "hostname",
+	"idn_domain":   "idn-hostname",
+	"hostname":     "hostname",
+	"idn_hostname": "idn-hostname",
+	"uuid":         "uuid",
+	"ipv4":         "ipv4",
+	"ipv6":         "ipv6",
+	"date":         "date",
+	"datetime":     "date-time",
+}
+
+// jsonSchema returns the JSON Schema of an object validated by s.
+func (s *schema) jsonSchema() map[string]any {
+	properties := map[string]any{}
+	required := []string{}
+	for _, name := range s.names {
+		f := s.fields[name]
+		properties[name] = f.jsonSchema()
+		if f.required {
+			required = append(required, name)
+		}
+	}
+
+	node := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		node["required"] = required
+	}
+	return node
+}
+
+// jsonSchema returns the JSON Schema of a value validated by f. Validators
+// added with validation.register and Lua patterns cannot be expressed and
+// are left out.
+func (f *field) jsonSchema() map[string]any {
+	node := map[string]any{}
+	if f.fields != nil {
+		node = f.fields.jsonSchema()
+	}
+	for typ, name := range jsonSchemaTypes {
+		if f.typ == name {
+			node["type"] = typ
+		}
+	}
+	if f.items != nil {
+		node["items"] = f.items.jsonSchema()
+	}
+
+	for _, r := range f.rules {
+		switch arg := r.arg.(type) {
+		case lua.LNumber:
+			exportBound(node, f.typ, r.name, float64(arg))
+		case lua.LString:
+			if r.name == "pattern" {
+				node["pattern"] = string(arg)
+			} else if format, ok := exportFormats[string(arg)]; ok && r.name == "format" {
+				node["format"] = format
+			}
+		case *lua.LTable:
+			var values []any
+			for i := 1; i <= arg.Len(); i++ {
+				switch v := arg.RawGetInt(i).(type) {
+				case lua.LString:
+					values = append(values, string(v))
+				case lua.LNumber:
+					values = append(values, float64(v))
+				case lua.LBool:
+					values = append(values, bool(v))
+				}
+			}
+			node["enum"] = values
+		}
+	}
+	return node
+}
+
+// exportBound sets the JSON Schema keywords for a bound rule. Fields of type
+// any get the keywords of every type they bound, since each keyword only
+// applies to values of its own type.
+func exportBound(node map[string]any, typ, ruleName string, limit float64) {
+	bound := ruleOption(ruleName)
+	numbers := map[string]string{"min": "minimum", "max": "maximum", "gt": "exclusiveMinimum", "lt": "exclusiveMaximum"}
+	if typ == "number" || typ == "integer" || typ == "any" {
+		node[numbers[bound]] = limit
+	}
+
+	// Lengths and item counts are whole numbers, so exclusive bounds become
+	// inclusive ones.
+	count := limit
+	switch bound {
+	case "min":
+		count = math.Ceil(limit)
+	case "max":
+		count = math.Floor(limit)
+	case "gt":
+		count, bound = math.Floor(limit)+1, "min"
+	case "lt":
+		count, bound = math.Ceil(limit)-1, "max"
+	}
+	if typ == "string" || typ == "any" {
+		node[bound+"Length"] = count
+	}
+	if typ == "array" || typ == "any" {
+		node[bound+"Items"] = count
+	}
+}
+
+// schemaToJSONSchema exports the schema as a JSON Schema document
+// Usage: schema:to_json_schema() -> string
+func schemaToJSONSchema(L *lua.LState) int {
+	s := checkSchema(L, 1)
+
+	node := s.jsonSchema()
+	node["$schema"] = jsonSchemaDialect
+	data, err := json.MarshalIndent(node, "", "  ")
+	if err != nil {
+		L.RaiseError("%v", err)
+	}
+	L.Push(lua.LString(data))
+	return 1
+}
@@ -0,0 +1,166 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestSchemaFromJSON(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local s = validation.schema_from_json([[{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"required": ["email", "name"],
+			"properties": {
+				"email": {"type": "string", "format": "email"},
+				"name": {"type": "string", "minLength": 2, "maxLength": 50},
+				"age": {"type": ["integer", "null"], "minimum": 18, "exclusiveMaximum": 130},
+				"role": {"enum": ["admin", "member"]},
+				"address": {
+					"type": "object",
+					"required": ["zip"],
+					"properties": {"zip": {"type": "string", "pattern": "^[0-9]{5}$"}}
+				},
+				"tags": {"type": "array", "maxItems": 2, "items": {"type": "string"}}
+			}
+		}]])
+		local ok = s:validate({email="ada@example.com", name="Ada", age=36, role="admin", address={zip="12345"}, tags={"a"}})
+		local _, errors = s:validate({email="nope", name="A", age=130, role="owner", address={}, tags={"a", "b", "c"}})
+		return ok, errors.email, errors.name, errors.age, errors.role, errors["address.zip"], errors.tags
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("SchemaFromJSON test failed: %v", err)
+	}
+
+	ok := L.Get(-7).(lua.LBool)
+	if !bool(ok) {
+		t.Error("Expected true for valid table")
+	}
+
+	expected := []string{
+		"email must be a valid email",
+		"name must be at least 2 characters",
+		"age must be less than 130",
+		"role must be one of admin, member",
+		"address.zip is required",
+		"tags must have at most 2 items",
+	}
+	for i, message := range expected {
+		if got := L.Get(i - len(expected)).String(); got != message {
+			t.Errorf("Expected %q, got %q", message, got)
+		}
+	}
+}
+
+func TestSchemaFromJSONErrors(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		doc      string
+		expected string
+	}{
+		{`{"type": "object"`, "invalid JSON Schema"},
+		{`{"type": "array", "items": {}}`, "root schema must have type object"},
+		{`{"type": "object", "properties": {"id": {"$ref": "#/$defs/id"}}}`, `property "id": unsupported keyword "$ref"`},
+		{`{"type": "object", "properties": {"id": {"type": "string"}}, "additionalProperties": false}`, `unsupported keyword "additionalProperties": only true is supported`},
+		{`{"type": "object", "properties": {"tags": {"type": "object", "properties": {}, "additionalProperties": {"type": "string"}}}}`, `property "tags": unsupported keyword "additionalProperties": only true is supported`},
+		{`{"properties": {"id": {"type": ["string", "number"]}}}`, "unsupported type"},
+	}
+
+	for _, test := range tests {
+		L.SetGlobal("doc", lua.LString(test.doc))
+		err := L.DoString(`require("validation").schema_from_json(doc)`)
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("schema_from_json(%s): expected error containing %q, got %v", test.doc, test.expected, err)
+		}
+	}
+}
+
+func TestSchemaToJSONSchema(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local s = validation.schema({
+			email = {type = "string", required = true, format = "email"},
+			age = {type = "integer", min = 18, lt = 130},
+			role = {one_of = {"admin", "member"}},
+			tags = validation.rule():array():max(2):items(validation.rule():string():gt(1)),
+		})
+		local doc = s:to_json_schema()
+		local imported = validation.schema_from_json(doc)
+		local ok = imported:validate({email="ada@example.com", age=36, role="admin", tags={"ab"}})
+		local _, errors = imported:validate({age=130, role="owner", tags={"a"}})
+		return doc, ok, errors.email, errors.age, errors.role, errors["tags[1]"]
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("SchemaToJSONSchema test failed: %v", err)
+	}
+
+	doc := L.Get(-6).String()
+	for _, fragment := range []string{
+		`"$schema": "https://json-schema.org/draft/2020-12/schema"`,
+		`"required": [
+    "email"
+  ]`,
+		`"exclusiveMaximum": 130`,
+		`"minLength": 2`,
+		`"format": "email"`,
+	} {
+		if !strings.Contains(doc, fragment) {
+			t.Errorf("Expected JSON Schema to contain %s, got %s", fragment, doc)
+		}
+	}
+
+	if ok := L.Get(-5).(lua.LBool); !bool(ok) {
+		t.Error("Expected true for valid table")
+	}
+
+	expected := []string{
+		"email is required",
+		"age must be less than 130",
+		"role must be one of admin, member",
+		"tags[1] must be at least 2 characters",
+	}
+	for i, message := range expected {
+		if got := L.Get(i - len(expected)).String(); got != message {
+			t.Errorf("Expected %q, got %q", message, got)
+		}
+	}
+}
+
+func TestSchemaFromJSONAdditionalProperties(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	for _, value := range []string{"true", "{}"} {
+		L.SetGlobal("doc", lua.LString(`{"type": "object", "properties": {"id": {"type": "string"}}, "additionalProperties": `+value+`}`))
+		if err := L.DoString(`return (require("validation").schema_from_json(doc):validate({id = "a", extra = 1}))`); err != nil {
+			t.Fatalf("additionalProperties %s: %v", value, err)
+		}
+		if got := L.Get(-1); got != lua.LTrue {
+			t.Errorf("additionalProperties %s: expected extra fields to be accepted, got %v", value, got)
+		}
+		L.Pop(1)
+	}
+}
@@ -0,0 +1,110 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// jwtParts is a JWT in JWS compact serialization split into its decoded
+// parts.
+type jwtParts struct {
+	header, claims map[string]any
+	signingInput   string // the encoded header and payload joined by "."
+	signature      []byte
+}
+
+// jwtBase64 decodes the unpadded base64url parts of a JWT.
+var jwtBase64 = base64Options{urlSafe: true, maxSize: defaultMaxBase64Size}
+
+// parseJWT splits a compact JWT into its three parts and decodes them. The
+// header and payload must be JSON objects and the header must name an
+// algorithm. Only tokens with alg "none" may have an empty signature.
+func parseJWT(token string) (*jwtParts, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("token must have 3 parts, got %d", len(parts))
+	}
+
+	header, err := decodeJWTObject(parts[0], "header")
+	if err != nil {
+		return nil, err
+	}
+	alg, ok := header["alg"].(string)
+	if !ok || alg == "" {
+		return nil, fmt.Errorf("header has no alg")
+	}
+	claims, err := decodeJWTObject(parts[1], "payload")
+	if err != nil {
+		return nil, err
+	}
+
+	jwt := &jwtParts{header: header, claims: claims, signingInput: parts[0] + "." + parts[1]}
+	if parts[2] == "" {
+		if alg != "none" {
+			return nil, fmt.Errorf("signature is missing")
+		}
+		return jwt, nil
+	}
+	if jwt.signature, err = decodeBase64(parts[2], jwtBase64); err != nil {
+		return nil, fmt.Errorf("invalid signature encoding: %v", err)
+	}
+	return jwt, nil
+}
+
+// decodeJWTObject decodes a base64url part of a JWT holding a JSON object.
+func decodeJWTObject(part, name string) (map[string]any, error) {
+	data, err := decodeBase64(part, jwtBase64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s encoding: %v", name, err)
+	}
+	obj, err := decodeJSONObject(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", name, err)
+	}
+	return obj, nil
+}
+
+// decodeJSONObject decodes a single JSON object, keeping numbers as
+// json.Number so that large integers survive the conversion to Lua.
+func decodeJSONObject(data []byte) (map[string]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var obj map[string]any
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		return nil, fmt.Errorf("not a JSON object")
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after JSON object")
+	}
+	return obj, nil
+}
+
+// IsJWT reports whether s is structurally a JWT: three base64url parts whose
+// header and payload decode to JSON objects. The signature is not verified.
+func IsJWT(s string) bool {
+	_, err := parseJWT(s)
+	return err == nil
+}
+
+// isJWT checks the structure of a JWT and returns its decoded claims and header
+// Usage: validation.is_jwt(str) -> boolean, claims_or_error, header?
+func isJWT(L *lua.LState) int {
+	jwt, err := parseJWT(L.CheckString(1))
+	if err != nil {
+		L.Push(lua.LBool(false))
+		L.Push(lua.LString(err.Error()))
+		return 2
+	}
+	L.Push(lua.LBool(true))
+	L.Push(toLua(L, jwt.claims))
+	L.Push(toLua(L, jwt.header))
+	return 3
+}
@@ -0,0 +1,268 @@
+package validation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// testJWTSecret is an HMAC key long enough for HS256.
+const testJWTSecret = "0123456789abcdef0123456789abcdef"
+
+// testJWT builds a compact token from raw header and payload JSON and an
+// already encoded signature.
+func testJWT(header, payload, signature string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(header)) + "." + enc.EncodeToString([]byte(payload)) + "." + signature
+}
+
+func TestIsJWT(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		name     string
+		token    string
+		expected bool
+	}{
+		{"valid", testJWT(`{"alg":"HS256","typ":"JWT"}`, `{"sub":"42","exp":1700000000}`, "c2lnbmF0dXJl"), true},
+		{"unsecured", testJWT(`{"alg":"none"}`, `{"sub":"42"}`, ""), true},
+		{"missing signature", testJWT(`{"alg":"HS256"}`, `{"sub":"42"}`, ""), false},
+		{"padded signature", testJWT(`{"alg":"HS256"}`, `{"sub":"42"}`, "c2lnbmF0dXJlMQ=="), false},
+		{"no alg", testJWT(`{"typ":"JWT"}`, `{"sub":"42"}`, "c2ln"), false},
+		{"array payload", testJWT(`{"alg":"HS256"}`, `[1,2]`, "c2ln"), false},
+		{"invalid json", testJWT(`{"alg":"HS256"}`, `{"sub":`, "c2ln"), false},
+		{"trailing data", testJWT(`{"alg":"HS256"}`, `{"sub":"42"} {}`, "c2ln"), false},
+		{"two parts", "eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiI0MiJ9", false},
+		{"not base64url", "eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiI0MiJ9+.c2ln", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		L.SetGlobal("token", lua.LString(tt.token))
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.is_jwt(token)
+		`); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := L.Get(1) == lua.LTrue; got != tt.expected {
+			t.Errorf("%s: is_jwt = %v (%v), want %v", tt.name, got, L.Get(2), tt.expected)
+		}
+		L.SetTop(0)
+	}
+}
+
+func TestIsJWTClaims(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	L.SetGlobal("token", lua.LString(testJWT(`{"alg":"HS256","kid":"k1"}`, `{"sub":"42","exp":1700000000,"roles":["admin"]}`, "c2ln")))
+	if err := L.DoString(`
+		local validation = require("validation")
+		local ok, claims, header = validation.is_jwt(token)
+		return ok, claims.sub, claims.exp, claims.roles[1], header.kid
+	`); err != nil {
+		t.Fatalf("is_jwt claims failed: %v", err)
+	}
+
+	want := []lua.LValue{lua.LTrue, lua.LString("42"), lua.LNumber(1700000000), lua.LString("admin"), lua.LString("k1")}
+	for i, w := range want {
+		if got := L.Get(i + 1); got != w {
+			t.Errorf("value %d = %v, want %v", i+1, got, w)
+		}
+	}
+}
+
+// signJWT signs a header and payload with sign, which receives the signing
+// input and returns the raw signature.
+func signJWT(header, payload string, sign func(input []byte) []byte) string {
+	enc := base64.RawURLEncoding
+	input := enc.EncodeToString([]byte(header)) + "." + enc.EncodeToString([]byte(payload))
+	return input + "." + enc.EncodeToString(sign([]byte(input)))
+}
+
+func pemPublicKey(t *testing.T, pub crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestVerifyJWT(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	now := time.Unix(1700000000, 0)
+	L.PreloadModule("validation", NewLoader(Options{EnableJWTVerification: true, Now: func() time.Time { return now }}))
+
+	hs256 := func(secret string) func([]byte) []byte {
+		return func(input []byte) []byte {
+			mac := hmac.New(sha256.New, []byte(secret))
+			mac.Write(input)
+			return mac.Sum(nil)
+		}
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs256 := func(input []byte) []byte {
+		sum := sha256.Sum256(input)
+		sig, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, sum[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	es256 := func(input []byte) []byte {
+		sum := sha256.Sum256(input)
+		r, s, err := ecdsa.Sign(rand.Reader, ecKey, sum[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	eddsa := func(input []byte) []byte { return ed25519.Sign(edKey, input) }
+
+	rsaPEM := pemPublicKey(t, &rsaKey.PublicKey)
+	claims := `{"sub":"42","iss":"auth","aud":["api","web"],"exp":1700000060,"nbf":1699999990}`
+	hsToken := signJWT(`{"alg":"HS256"}`, claims, hs256(testJWTSecret))
+	parts := strings.Split(hsToken, ".")
+	tampered := parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"admin"}`)) + "." + parts[2]
+
+	tests := []struct {
+		name     string
+		token    string
+		key      string
+		opts     string
+		expected bool
+	}{
+		{"HS256", hsToken, testJWTSecret, `{algs = {"HS256"}}`, true},
+		{"HS256 empty secret", signJWT(`{"alg":"HS256"}`, claims, hs256("")), "", `{algs = {"HS256"}}`, false},
+		{"HS256 short secret", signJWT(`{"alg":"HS256"}`, claims, hs256("secret")), "secret", `{algs = {"HS256"}}`, false},
+		{"HS256 wrong secret", signJWT(`{"alg":"HS256"}`, claims, hs256(testJWTSecret)), strings.Repeat("x", 32), `{algs = {"HS256"}}`, false},
+		{"RS256", signJWT(`{"alg":"RS256"}`, claims, rs256), rsaPEM, `{algs = {"RS256"}}`, true},
+		{"ES256", signJWT(`{"alg":"ES256"}`, claims, es256), pemPublicKey(t, &ecKey.PublicKey), `{algs = {"ES256"}}`, true},
+		{"EdDSA", signJWT(`{"alg":"EdDSA"}`, claims, eddsa), pemPublicKey(t, edPub), `{algs = {"EdDSA"}}`, true},
+		{"RS256 with EC key", signJWT(`{"alg":"RS256"}`, claims, rs256), pemPublicKey(t, &ecKey.PublicKey), `{algs = {"RS256"}}`, false},
+		{"alg not allowed", signJWT(`{"alg":"HS256"}`, claims, hs256(testJWTSecret)), testJWTSecret, `{algs = {"RS256"}}`, false},
+		{"HS256 signed with public key", signJWT(`{"alg":"HS256"}`, claims, hs256(rsaPEM)), rsaPEM, `{algs = {"HS256"}}`, false},
+		{"alg none", testJWT(`{"alg":"none"}`, claims, ""), testJWTSecret, `{algs = {"HS256"}}`, false},
+		{"tampered payload", tampered, testJWTSecret, `{algs = {"HS256"}}`, false},
+		{"expired", signJWT(`{"alg":"HS256"}`, `{"exp":1700000000}`, hs256(testJWTSecret)), testJWTSecret, `{algs = {"HS256"}}`, false},
+		{"expired within leeway", signJWT(`{"alg":"HS256"}`, `{"exp":1699999990}`, hs256(testJWTSecret)), testJWTSecret, `{algs = {"HS256"}, leeway = 30}`, true},
+		{"not yet valid", signJWT(`{"alg":"HS256"}`, `{"nbf":1700000100}`, hs256(testJWTSecret)), testJWTSecret, `{algs = {"HS256"}}`, false},
+		{"exp not a number", signJWT(`{"alg":"HS256"}`, `{"exp":"tomorrow"}`, hs256(testJWTSecret)), testJWTSecret, `{algs = {"HS256"}}`, false},
+		{"issuer", signJWT(`{"alg":"HS256"}`, claims, hs256(testJWTSecret)), testJWTSecret, `{algs = {"HS256"}, issuer = "auth", audience = "web"}`, true},
+		{"wrong issuer", signJWT(`{"alg":"HS256"}`, claims, hs256(testJWTSecret)), testJWTSecret, `{algs = {"HS256"}, issuer = "other"}`, false},
+		{"wrong audience", signJWT(`{"alg":"HS256"}`, claims, hs256(testJWTSecret)), testJWTSecret, `{algs = {"HS256"}, audience = "admin"}`, false},
+	}
+
+	for _, tt := range tests {
+		L.SetGlobal("token", lua.LString(tt.token))
+		L.SetGlobal("key", lua.LString(tt.key))
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.verify_jwt(token, key, ` + tt.opts + `)
+		`); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := L.Get(1) == lua.LTrue; got != tt.expected {
+			t.Errorf("%s: verify_jwt = %v (%v), want %v", tt.name, got, L.Get(2), tt.expected)
+		}
+		L.SetTop(0)
+	}
+}
+
+func TestVerifyJWTRestrictedAlgorithms(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", NewLoader(Options{EnableJWTVerification: true, JWTAlgorithms: []string{"RS256"}}))
+
+	L.SetGlobal("secret", lua.LString(testJWTSecret))
+	L.SetGlobal("token", lua.LString(signJWT(`{"alg":"HS256"}`, `{"sub":"42"}`, func(input []byte) []byte {
+		mac := hmac.New(sha256.New, []byte(testJWTSecret))
+		mac.Write(input)
+		return mac.Sum(nil)
+	})))
+	if err := L.DoString(`
+		local validation = require("validation")
+		local ok, err = validation.verify_jwt(token, secret, {algs = {"HS256"}})
+		return ok, err
+	`); err != nil {
+		t.Fatalf("verify_jwt failed: %v", err)
+	}
+	if L.Get(-2) != lua.LFalse {
+		t.Error("expected HS256 to be rejected when Options.JWTAlgorithms allows only RS256")
+	}
+	if msg := L.Get(-1).String(); !strings.Contains(msg, "not allowed") {
+		t.Errorf("unexpected error message: %q", msg)
+	}
+}
+
+func TestVerifyJWTOptIn(t *testing.T) {
+	token := signJWT(`{"alg":"HS256"}`, `{"sub":"42"}`, func(input []byte) []byte {
+		mac := hmac.New(sha256.New, []byte(testJWTSecret))
+		mac.Write(input)
+		return mac.Sum(nil)
+	})
+
+	L := lua.NewState()
+	defer L.Close()
+	L.PreloadModule("validation", Loader)
+	if err := L.DoString(`return require("validation").verify_jwt == nil`); err != nil {
+		t.Fatalf("require failed: %v", err)
+	}
+	if L.Get(-1) != lua.LTrue {
+		t.Error("expected verify_jwt to be left out of the default loader")
+	}
+
+	L = lua.NewState()
+	defer L.Close()
+	L.PreloadModule("validation", NewLoader(Options{EnableJWTVerification: true}))
+	L.SetGlobal("token", lua.LString(token))
+	L.SetGlobal("secret", lua.LString(testJWTSecret))
+	if err := L.DoString(`require("validation").verify_jwt(token, secret)`); err == nil || !strings.Contains(err.Error(), "algs must list") {
+		t.Errorf("expected an error for missing algs, got %v", err)
+	}
+
+	L = lua.NewState()
+	defer L.Close()
+	L.PreloadModule("validation", NewLoader(Options{EnableJWTVerification: true, JWTAlgorithms: []string{"HS256"}}))
+	L.SetGlobal("token", lua.LString(token))
+	L.SetGlobal("secret", lua.LString(testJWTSecret))
+	if err := L.DoString(`return (require("validation").verify_jwt(token, secret))`); err != nil {
+		t.Fatalf("verify_jwt failed: %v", err)
+	}
+	if L.Get(-1) != lua.LTrue {
+		t.Error("expected Options.JWTAlgorithms to stand in for algs")
+	}
+}
@@ -0,0 +1,309 @@
+package validation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	// Register the hash functions used by the JWS algorithms.
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// jwtAlgorithm verifies the signature of a JWS algorithm with a key given
+// as a shared secret or a PEM-encoded public key.
+type jwtAlgorithm struct {
+	hash   crypto.Hash
+	verify func(key string, hash crypto.Hash, input, sig []byte) error
+}
+
+// jwtAlgorithms are the signature algorithms verify_jwt supports. "none" is
+// deliberately absent.
+var jwtAlgorithms = map[string]jwtAlgorithm{
+	"HS256": {crypto.SHA256, verifyHMAC},
+	"HS384": {crypto.SHA384, verifyHMAC},
+	"HS512": {crypto.SHA512, verifyHMAC},
+	"RS256": {crypto.SHA256, verifyRSA},
+	"RS384": {crypto.SHA384, verifyRSA},
+	"RS512": {crypto.SHA512, verifyRSA},
+	"PS256": {crypto.SHA256, verifyRSAPSS},
+	"PS384": {crypto.SHA384, verifyRSAPSS},
+	"PS512": {crypto.SHA512, verifyRSAPSS},
+	"ES256": {crypto.SHA256, verifyECDSA},
+	"ES384": {crypto.SHA384, verifyECDSA},
+	"ES512": {crypto.SHA512, verifyECDSA},
+	"EdDSA": {0, verifyEdDSA},
+}
+
+// ecdsaCurves maps the hash of each ES algorithm to the curve it requires.
+var ecdsaCurves = map[crypto.Hash]elliptic.Curve{
+	crypto.SHA256: elliptic.P256(),
+	crypto.SHA384: elliptic.P384(),
+	crypto.SHA512: elliptic.P521(),
+}
+
+var errJWTSignature = fmt.Errorf("signature is invalid")
+
+// verifyHMAC checks an HS signature. PEM keys are refused so that a token
+// switched to HS256 cannot be signed with a public key used as the secret.
+// Secrets shorter than the hash output are refused as RFC 7518 requires, so
+// an empty key from an unset variable cannot verify forged tokens.
+func verifyHMAC(key string, hash crypto.Hash, input, sig []byte) error {
+	if len(key) < hash.Size() {
+		return fmt.Errorf("HMAC key must be at least %d bytes", hash.Size())
+	}
+	if strings.Contains(key, "-----BEGIN") {
+		return fmt.Errorf("HMAC key must be a shared secret, not a PEM key")
+	}
+	mac := hmac.New(hash.New, []byte(key))
+	mac.Write(input)
+	if !hmac.Equal(mac.Sum(nil), sig) {
+		return errJWTSignature
+	}
+	return nil
+}
+
+func verifyRSA(key string, hash crypto.Hash, input, sig []byte) error {
+	pub, err := publicKey[*rsa.PublicKey](key)
+	if err != nil {
+		return err
+	}
+	if rsa.VerifyPKCS1v15(pub, hash, digest(hash, input), sig) != nil {
+		return errJWTSignature
+	}
+	return nil
+}
+
+func verifyRSAPSS(key string, hash crypto.Hash, input, sig []byte) error {
+	pub, err := publicKey[*rsa.PublicKey](key)
+	if err != nil {
+		return err
+	}
+	opts := &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}
+	if rsa.VerifyPSS(pub, hash, digest(hash, input), sig, opts) != nil {
+		return errJWTSignature
+	}
+	return nil
+}
+
+// verifyECDSA checks an ES signature, which is R and S concatenated as
+// fixed-size big-endian integers.
+func verifyECDSA(key string, hash crypto.Hash, input, sig []byte) error {
+	pub, err := publicKey[*ecdsa.PublicKey](key)
+	if err != nil {
+		return err
+	}
+	if pub.Curve != ecdsaCurves[hash] {
+		return fmt.Errorf("key curve %s does not match the algorithm", pub.Curve.Params().Name)
+	}
+	size := (pub.Curve.Params().BitSize + 7) / 8
+	if len(sig) != 2*size {
+		return errJWTSignature
+	}
+	r, s := new(big.Int).SetBytes(sig[:size]), new(big.Int).SetBytes(sig[size:])
+	if !ecdsa.Verify(pub, digest(hash, input), r, s) {
+		return errJWTSignature
+	}
+	return nil
+}
+
+func verifyEdDSA(key string, _ crypto.Hash, input, sig []byte) error {
+	pub, err := publicKey[ed25519.PublicKey](key)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(pub, input, sig) {
+		return errJWTSignature
+	}
+	return nil
+}
+
+func digest(hash crypto.Hash, input []byte) []byte {
+	h := hash.New()
+	h.Write(input)
+	return h.Sum(nil)
+}
+
+// publicKey parses a PEM-encoded public key, PKCS #1 RSA public key or
+// certificate and checks that it is of type K.
+func publicKey[K crypto.PublicKey](key string) (K, error) {
+	var zero K
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return zero, fmt.Errorf("key is not PEM-encoded")
+	}
+
+	var pub any
+	var err error
+	switch block.Type {
+	case "PUBLIC KEY":
+		pub, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		pub, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		var cert *x509.Certificate
+		if cert, err = x509.ParseCertificate(block.Bytes); err == nil {
+			pub = cert.PublicKey
+		}
+	default:
+		return zero, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return zero, fmt.Errorf("invalid key: %v", err)
+	}
+	k, ok := pub.(K)
+	if !ok {
+		return zero, fmt.Errorf("key type %T does not match the algorithm", pub)
+	}
+	return k, nil
+}
+
+// jwtVerifyOptions are the checks verifyJWT applies besides the signature.
+type jwtVerifyOptions struct {
+	algs     []string
+	now      time.Time
+	leeway   time.Duration
+	issuer   string
+	audience string
+}
+
+// checkJWT parses a token, verifies its signature with key and checks the
+// exp, nbf, iss and aud claims.
+func checkJWT(token, key string, opts jwtVerifyOptions) (*jwtParts, error) {
+	jwt, err := parseJWT(token)
+	if err != nil {
+		return nil, err
+	}
+
+	name, _ := jwt.header["alg"].(string)
+	alg, ok := jwtAlgorithms[name]
+	if !ok || !slices.Contains(opts.algs, name) {
+		return nil, fmt.Errorf("algorithm %q is not allowed", name)
+	}
+	if err := alg.verify(key, alg.hash, []byte(jwt.signingInput), jwt.signature); err != nil {
+		return nil, err
+	}
+
+	if exp, ok, err := numericClaim(jwt.claims, "exp"); err != nil {
+		return nil, err
+	} else if ok && !opts.now.Before(exp.Add(opts.leeway)) {
+		return nil, fmt.Errorf("token has expired")
+	}
+	if nbf, ok, err := numericClaim(jwt.claims, "nbf"); err != nil {
+		return nil, err
+	} else if ok && opts.now.Before(nbf.Add(-opts.leeway)) {
+		return nil, fmt.Errorf("token is not valid yet")
+	}
+	if opts.issuer != "" && jwt.claims["iss"] != opts.issuer {
+		return nil, fmt.Errorf("issuer is not %q", opts.issuer)
+	}
+	if opts.audience != "" && !hasAudience(jwt.claims["aud"], opts.audience) {
+		return nil, fmt.Errorf("audience does not include %q", opts.audience)
+	}
+	return jwt, nil
+}
+
+// numericClaim reads a NumericDate claim in seconds since the epoch.
+func numericClaim(claims map[string]any, name string) (time.Time, bool, error) {
+	value, ok := claims[name]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	n, isNumber := value.(json.Number)
+	f, err := n.Float64()
+	if !isNumber || err != nil {
+		return time.Time{}, false, fmt.Errorf("%s claim is not a number", name)
+	}
+	sec, frac := math.Modf(f)
+	return time.Unix(int64(sec), int64(frac*1e9)), true, nil
+}
+
+// hasAudience reports whether an aud claim, a string or an array of
+// strings, contains audience.
+func hasAudience(aud any, audience string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == audience
+	case []any:
+		return slices.Contains(v, any(audience))
+	}
+	return false
+}
+
+// allowedJWTAlgorithms returns the algorithms a script may use: the list it
+// asked for narrowed to Options.JWTAlgorithms, or the configured list if the
+// script did not ask for any. There is no default, so a token can never pick
+// an algorithm family, such as HMAC instead of RSA, the caller did not
+// expect.
+func allowedJWTAlgorithms(requested, configured []string) []string {
+	if len(requested) == 0 {
+		return configured
+	}
+	if len(configured) == 0 {
+		return requested
+	}
+	var allowed []string
+	for _, name := range requested {
+		if slices.Contains(configured, name) {
+			allowed = append(allowed, name)
+		}
+	}
+	return allowed
+}
+
+// verifyJWT verifies the signature and time claims of a JWT and returns its claims and header
+// Usage: validation.verify_jwt(token, key, {algs={"HS256","RS256"}, leeway=0, issuer=nil, audience=nil}) -> boolean, claims_or_error, header?
+func verifyJWT(L *lua.LState) int {
+	token := L.CheckString(1)
+	key := L.CheckString(2)
+	opts := L.OptTable(3, L.NewTable())
+	st := stateOf(L)
+
+	var requested []string
+	if tbl, ok := opts.RawGetString("algs").(*lua.LTable); ok {
+		for i := 1; i <= tbl.Len(); i++ {
+			name := lua.LVAsString(tbl.RawGetInt(i))
+			if _, ok := jwtAlgorithms[name]; !ok {
+				L.ArgError(3, fmt.Sprintf("unsupported JWT algorithm %q", name))
+			}
+			requested = append(requested, name)
+		}
+	}
+	if len(requested) == 0 && len(st.options.JWTAlgorithms) == 0 {
+		L.ArgError(3, "algs must list the accepted algorithms")
+	}
+	o := jwtVerifyOptions{
+		algs:     allowedJWTAlgorithms(requested, st.options.JWTAlgorithms),
+		now:      st.options.now(),
+		issuer:   lua.LVAsString(opts.RawGetString("issuer")),
+		audience: lua.LVAsString(opts.RawGetString("audience")),
+	}
+	if n, ok := opts.RawGetString("leeway").(lua.LNumber); ok {
+		o.leeway = time.Duration(float64(n) * float64(time.Second))
+	}
+
+	jwt, err := checkJWT(token, key, o)
+	if err != nil {
+		L.Push(lua.LBool(false))
+		L.Push(lua.LString(err.Error()))
+		return 2
+	}
+	L.Push(lua.LBool(true))
+	L.Push(toLua(L, jwt.claims))
+	L.Push(toLua(L, jwt.header))
+	return 3
+}
@@ -0,0 +1,57 @@
+package validation
+
+import (
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+	"golang.org/x/text/language"
+)
+
+// IsLanguageTag reports whether s is a well-formed BCP 47 language tag such
+// as "en-US" or "zh-Hant-TW" whose language, script and region subtags are
+// registered. Subtags must be separated by hyphens, so POSIX locales such as
+// "en_US" are rejected. Case is not significant.
+func IsLanguageTag(s string) bool {
+	if strings.ContainsRune(s, '_') || !uniqueLanguageSubtags(s) {
+		return false
+	}
+	_, err := language.Parse(s)
+	return err == nil
+}
+
+// uniqueLanguageSubtags reports whether s repeats no variant or extension
+// singleton, which RFC 5646 forbids but language.Parse tolerates. Subtags
+// after the private use singleton are not checked.
+func uniqueLanguageSubtags(s string) bool {
+	seen := map[string]bool{}
+	inExtension := false
+	for i, subtag := range strings.Split(strings.ToLower(s), "-") {
+		switch {
+		case subtag == "x":
+			return true
+		case len(subtag) == 1:
+			inExtension = true
+		case i == 0 || inExtension || !isLanguageVariant(subtag):
+			continue
+		}
+		if seen[subtag] {
+			return false
+		}
+		seen[subtag] = true
+	}
+	return true
+}
+
+// isLanguageVariant reports whether a subtag has the shape of a variant:
+// five to eight characters, or four starting with a digit.
+func isLanguageVariant(subtag string) bool {
+	return len(subtag) >= 5 || len(subtag) == 4 && subtag[0] >= '0' && subtag[0] <= '9'
+}
+
+// isLanguageTag checks if a string is a valid BCP 47 language tag
+// Usage: validation.is_language_tag(str) -> boolean
+func isLanguageTag(L *lua.LState) int {
+	str := L.CheckString(1)
+	L.Push(lua.LBool(IsLanguageTag(str)))
+	return 1
+}
@@ -0,0 +1,54 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsLanguageTag(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"en", true},
+		{"en-US", true},
+		{"EN-us", true},
+		{"zh-Hant-TW", true},
+		{"es-419", true},
+		{"sl-rozaj-biske", true},
+		{"de-CH-1901", true},
+		{"en-u-ca-gregory", true},
+		{"en-US-x-twain", true},
+		{"x-private", true},
+		{"en_US", false},
+		{"xx", false},
+		{"xx-US", false},
+		{"en-Xyzw", false},
+		{"en--US", false},
+		{"en-", false},
+		{"english", false},
+		{"en-US-US", false},
+		{"de-1901-1901", false},
+		{"en-a-bbb-a-ccc", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.is_language_tag("` + tt.input + `")
+		`); err != nil {
+			t.Fatalf("is_language_tag(%q): %v", tt.input, err)
+		}
+		if got := L.Get(-1) == lua.LTrue; got != tt.expected {
+			t.Errorf("is_language_tag(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+		L.Pop(1)
+	}
+}
@@ -0,0 +1,46 @@
+package validation
+
+import (
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/pm"
+)
+
+// matchLuaPattern reports whether str contains a match of a Lua pattern, as
+// string.find does. Patterns are unanchored unless they start with ^ or end
+// with $.
+func matchLuaPattern(pattern, str string) (bool, error) {
+	matches, err := pm.Find(pattern, []byte(str), 0, 1)
+	if err != nil {
+		return false, err
+	}
+	return len(matches) > 0, nil
+}
+
+// checkLuaPattern reports syntax errors in a Lua pattern.
+func checkLuaPattern(pattern string) error {
+	_, err := matchLuaPattern(pattern, "")
+	return err
+}
+
+// validateLuaPattern validates a string against a Lua pattern
+// Usage: validation.validate_lua_pattern(str, pattern) -> boolean, error?
+func validateLuaPattern(L *lua.LState) int {
+	str := L.CheckString(1)
+	pattern := L.CheckString(2)
+
+	limits := stateOf(L).options.RegexLimits
+	var matched bool
+	err := limits.checkPattern(pattern)
+	if err == nil {
+		matched, err = limits.run(str, func() (bool, error) {
+			return matchLuaPattern(pattern, str)
+		})
+	}
+	if err != nil {
+		L.Push(lua.LBool(false))
+		L.Push(lua.LString(err.Error()))
+		return 2
+	}
+	L.Push(lua.LBool(matched))
+	return 1
+}
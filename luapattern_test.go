@@ -0,0 +1,101 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestValidateLuaPattern(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local _, err = validation.validate_lua_pattern("abc", "[a")
+		return validation.validate_lua_pattern("SKU-123", "^SKU%-%d+$"),
+			validation.validate_lua_pattern("SKU-12a", "^SKU%-%d+$"),
+			validation.validate_lua_pattern("order 42", "%d+"),
+			err
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("ValidateLuaPattern test failed: %v", err)
+	}
+
+	expected := []bool{true, false, true}
+	for i, want := range expected {
+		if got := lua.LVAsBool(L.Get(i - 4)); got != want {
+			t.Errorf("Result %d: expected %v, got %v", i+1, want, got)
+		}
+	}
+	if got := L.Get(-1).String(); !strings.Contains(got, "unexpected EOS") {
+		t.Errorf("Expected malformed pattern error, got %q", got)
+	}
+}
+
+func TestSchemaLuaPattern(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local s = validation.schema({sku = {type = "string", lua_pattern = "^SKU%-%d+$"}})
+		local ok = s:validate({sku = "SKU-1"})
+		local _, errors = s:validate({sku = "sku-1"})
+		local compiled = pcall(validation.schema, {sku = {lua_pattern = "[a"}})
+		return ok, errors.sku, compiled
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("SchemaLuaPattern test failed: %v", err)
+	}
+
+	if ok := L.Get(-3).(lua.LBool); !bool(ok) {
+		t.Error("Expected true for matching value")
+	}
+	if got := L.Get(-2).String(); got != "sku must match the pattern ^SKU%-%d+$" {
+		t.Errorf("Unexpected message %q", got)
+	}
+	if compiled := L.Get(-1).(lua.LBool); bool(compiled) {
+		t.Error("Expected malformed Lua pattern to be rejected")
+	}
+}
+
+func TestLuaPatternSchemaLimits(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", NewLoader(Options{RegexLimits: RegexLimits{
+		MaxPatternLength: 8,
+		MaxSubjectLength: 8,
+	}}))
+
+	script := `
+		local validation = require("validation")
+		local _, pattern_err = pcall(validation.schema, {x = {type = "string", lua_pattern = "^%a+%d+%a+$"}})
+		local check = validation.rule():string():lua_pattern("^%a+%d$"):compile("x")
+		return pattern_err, (check("abc1")), (check("abc")), (check("abcdefgh1"))
+	`
+
+	if err := L.DoString(script); err != nil {
+		t.Fatalf("LuaPatternSchemaLimits test failed: %v", err)
+	}
+
+	if got := L.Get(-4).String(); !strings.Contains(got, "pattern exceeds the maximum length of 8 bytes") {
+		t.Errorf("Expected pattern length error, got %q", got)
+	}
+	expected := []lua.LValue{lua.LTrue, lua.LFalse, lua.LFalse}
+	for i, want := range expected {
+		if got := L.Get(i - len(expected)); got != want {
+			t.Errorf("check %d: expected %v, got %v", i+1, want, got)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package validation
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// macFormats maps format names to the separator they use.
+var macFormats = map[string]string{
+	"colon":  ":", // 00:00:5e:00:53:01
+	"hyphen": "-", // 00-00-5e-00-53-01
+	"dot":    ".", // 0000.5e00.5301
+}
+
+// IsMAC reports whether s is an EUI-48 or EUI-64 MAC address separated by
+// colons, hyphens or dots.
+func IsMAC(s string) bool {
+	if !strings.ContainsAny(s, ":-.") {
+		return false
+	}
+	hw, err := net.ParseMAC(s)
+	return err == nil && (len(hw) == 6 || len(hw) == 8)
+}
+
+// isMAC validates a MAC address, optionally in a specific format
+// Usage: validation.is_mac(str, {format="colon"|"hyphen"|"dot"}) -> boolean
+func isMAC(L *lua.LState) int {
+	str := L.CheckString(1)
+	opts := L.OptTable(2, L.NewTable())
+
+	valid := IsMAC(str)
+	if format, ok := opts.RawGetString("format").(lua.LString); ok {
+		sep, known := macFormats[string(format)]
+		if !known {
+			L.ArgError(2, fmt.Sprintf("unknown MAC address format %q", string(format)))
+		}
+		valid = valid && strings.Contains(str, sep)
+	}
+	L.Push(lua.LBool(valid))
+	return 1
+}
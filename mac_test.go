@@ -0,0 +1,47 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsMAC(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		args     string
+		expected bool
+	}{
+		{`"00:00:5e:00:53:01"`, true},
+		{`"00-00-5E-00-53-01"`, true},
+		{`"0000.5e00.5301"`, true},
+		{`"02:00:5e:10:00:00:00:01"`, true},
+		{`"00:00:5e:00:53"`, false},
+		{`"00:00:5e:00:53:zz"`, false},
+		{`"00005e005301"`, false},
+		{`"00:00:5e:00:53:01", {format = "colon"}`, true},
+		{`"00-00-5e-00-53-01", {format = "colon"}`, false},
+		{`"0000.5e00.5301", {format = "dot"}`, true},
+		{`"00-00-5e-00-53-01", {format = "hyphen"}`, true},
+	}
+
+	for _, test := range tests {
+		if err := L.DoString(`return require("validation").is_mac(` + test.args + `)`); err != nil {
+			t.Fatalf("is_mac(%s) failed: %v", test.args, err)
+		}
+		if got := lua.LVAsBool(L.Get(-1)); got != test.expected {
+			t.Errorf("is_mac(%s): expected %v, got %v", test.args, test.expected, got)
+		}
+		L.Pop(1)
+	}
+
+	err := L.DoString(`require("validation").is_mac("00:00:5e:00:53:01", {format = "cisco"})`)
+	if err == nil || !strings.Contains(err.Error(), `unknown MAC address format "cisco"`) {
+		t.Errorf("Expected unknown format error, got %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+package validation
+
+import (
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// defaultLocale is the locale whose messages are built in and used as the
+// final fallback for every other locale.
+const defaultLocale = "en"
+
+// defaultMessages holds the English message templates, keyed by rule name.
+// Placeholders such as {field} and {min} are replaced with the failing
+// field's path and the rule's parameters.
+var defaultMessages = map[string]string{
+	"required":    "{field} is required",
+	"type":        "{field} must be of type {type}",
+	"min":         "{field} must be at least {min}",
+	"max":         "{field} must be at most {max}",
+	"min_length":  "{field} must be at least {min} characters",
+	"max_length":  "{field} must be at most {max} characters",
+	"min_items":   "{field} must have at least {min} items",
+	"max_items":   "{field} must have at most {max} items",
+	"gt":          "{field} must be greater than {gt}",
+	"lt":          "{field} must be less than {lt}",
+	"gt_length":   "{field} must be longer than {gt} characters",
+	"lt_length":   "{field} must be shorter than {lt} characters",
+	"gt_items":    "{field} must have more than {gt} items",
+	"lt_items":    "{field} must have fewer than {lt} items",
+	"pattern":     "{field} must match the pattern {pattern}",
+	"lua_pattern": "{field} must match the pattern {pattern}",
+	"one_of":      "{field} must be one of {values}",
+	"format":      "{field} must be a valid {format}",
+	"utf8":        "{field} must be valid UTF-8",
+	"invalid":     "{field} is invalid",
+
+	"validator_failed": "{fn} failed",
+
+	"subdomain_too_short": "must be at least {min} characters",
+	"subdomain_too_long":  "must be at most {max} characters",
+	"subdomain_invalid":   "must contain only letters, digits and hyphens and must not start or end with a hyphen",
+	"subdomain_reserved":  "{value} is reserved",
+
+	"cron_too_frequent": "schedule can fire every {interval}, more often than {min}",
+}
+
+// formatMessage replaces {name} placeholders in a template with params.
+// Unknown placeholders are left untouched.
+func formatMessage(template string, params map[string]string) string {
+	pairs := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(template)
+}
+
+// message renders the template for key in the active locale. Lookups fall
+// back from a regional locale ("de-AT") to its language ("de"), then to the
+// default message of a registered validator and the built-in English
+// messages, and finally to the generic "invalid" message.
+func (st *moduleState) message(key string, params map[string]string) string {
+	return formatMessage(st.template(key), params)
+}
+
+func (st *moduleState) template(key string) string {
+	for _, locale := range localeChain(st.locale) {
+		if template, ok := st.catalogs[locale][key]; ok {
+			return template
+		}
+	}
+	if template, ok := st.validatorMessages[key]; ok {
+		return template
+	}
+	if template, ok := defaultMessages[key]; ok {
+		return template
+	}
+	if key != "invalid" {
+		return st.template("invalid")
+	}
+	return key
+}
+
+// localeChain lists a locale followed by its less specific parents.
+func localeChain(locale string) []string {
+	chain := []string{locale}
+	for {
+		i := strings.LastIndexAny(locale, "-_")
+		if i < 0 {
+			return chain
+		}
+		locale = locale[:i]
+		chain = append(chain, locale)
+	}
+}
+
+// setLocale selects the locale used for validation messages
+// Usage: validation.set_locale(locale)
+func setLocale(L *lua.LState) int {
+	stateOf(L).locale = L.CheckString(1)
+	return 0
+}
+
+// getLocale returns the locale used for validation messages
+// Usage: validation.get_locale() -> string
+func getLocale(L *lua.LState) int {
+	L.Push(lua.LString(stateOf(L).locale))
+	return 1
+}
+
+// registerMessages adds or replaces message templates for a locale
+// Usage: validation.register_messages(locale, {required="{field} ist erforderlich", ...})
+func registerMessages(L *lua.LState) int {
+	locale := L.CheckString(1)
+	messages := L.CheckTable(2)
+
+	st := stateOf(L)
+	catalog, ok := st.catalogs[locale]
+	if !ok {
+		catalog = map[string]string{}
+		st.catalogs[locale] = catalog
+	}
+	messages.ForEach(func(key, value lua.LValue) {
+		template, ok := value.(lua.LString)
+		if !ok {
+			L.ArgError(2, "message for "+key.String()+" must be a string")
+		}
+		catalog[key.String()] = string(template)
+	})
+	return 0
+}
@@ -0,0 +1,152 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestRegisterMessages(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		validation.register_messages("de", {
+			required = "{field} ist erforderlich",
+			min_length = "{field} muss mindestens {min} Zeichen lang sein",
+		})
+		validation.set_locale("de")
+
+		local s = validation.schema({
+			name = {type="string", required=true, min=3},
+			email = {type="string", required=true},
+			age = {type="number"},
+		})
+		local _, errors = s:validate({name="Al", age="old"})
+		return validation.get_locale(), errors.name, errors.email, errors.age
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("RegisterMessages test failed: %v", err)
+	}
+
+	locale := L.Get(-4).String()
+	name := L.Get(-3).String()
+	email := L.Get(-2).String()
+	age := L.Get(-1).String()
+
+	if locale != "de" {
+		t.Errorf("Expected locale de, got %q", locale)
+	}
+	if name != "name muss mindestens 3 Zeichen lang sein" {
+		t.Errorf("Unexpected name error: %q", name)
+	}
+	if email != "email ist erforderlich" {
+		t.Errorf("Unexpected email error: %q", email)
+	}
+	if age != "age must be of type number" {
+		t.Errorf("Expected English fallback for missing translation, got %q", age)
+	}
+}
+
+func TestLocaleFallback(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		validation.register_messages("tr", {validator_failed = "{fn} başarısız oldu"})
+		validation.set_locale("tr-TR")
+
+		local _, errors = validation.validate_all({{fn="is_number", args={"x"}}})
+		local _, reason = validation.is_available_subdomain("ab", {min=3})
+		return errors[1], reason
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("Locale fallback test failed: %v", err)
+	}
+
+	failed := L.Get(-2).String()
+	reason := L.Get(-1).String()
+
+	if failed != "is_number başarısız oldu" {
+		t.Errorf("Expected tr message for tr-TR locale, got %q", failed)
+	}
+	if reason != "must be at least 3 characters" {
+		t.Errorf("Expected English fallback, got %q", reason)
+	}
+}
+
+func TestLocaleIsPerState(t *testing.T) {
+	L1 := lua.NewState()
+	defer L1.Close()
+	L2 := lua.NewState()
+	defer L2.Close()
+
+	L1.PreloadModule("validation", Loader)
+	L2.PreloadModule("validation", Loader)
+
+	if err := L1.DoString(`require("validation").set_locale("de")`); err != nil {
+		t.Fatalf("set_locale failed: %v", err)
+	}
+	if err := L2.DoString(`return require("validation").get_locale()`); err != nil {
+		t.Fatalf("get_locale failed: %v", err)
+	}
+
+	if locale := L2.Get(-1).String(); locale != "en" {
+		t.Errorf("Expected other state to keep locale en, got %q", locale)
+	}
+}
+
+func TestCustomMessageTemplates(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local s = validation.schema({
+			username = {type="string", required=true, min=3, messages={
+				min = "{field} needs {min}+ characters",
+				required = "please choose a {field}",
+			}},
+			age = {type="number", min=18, message="you must be {min} or older"},
+			email = validation.rule():string():email():message("{field} looks wrong"),
+		})
+		local _, short = s:validate({username="ab", age=12, email="nope"})
+		local _, missing = s:validate({})
+		return short.username, short.age, short.email, missing.username
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("Custom message templates test failed: %v", err)
+	}
+
+	short := L.Get(-4).String()
+	age := L.Get(-3).String()
+	email := L.Get(-2).String()
+	missing := L.Get(-1).String()
+
+	if short != "username needs 3+ characters" {
+		t.Errorf("Unexpected min message: %q", short)
+	}
+	if age != "you must be 18 or older" {
+		t.Errorf("Unexpected field message: %q", age)
+	}
+	if email != "email looks wrong" {
+		t.Errorf("Unexpected rule builder message: %q", email)
+	}
+	if missing != "please choose a username" {
+		t.Errorf("Unexpected required message: %q", missing)
+	}
+}
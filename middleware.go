@@ -0,0 +1,179 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/parse"
+)
+
+// DefaultMaxBodyBytes is the largest request body read by the middleware
+// when MiddlewareOptions.MaxBodyBytes is zero.
+const DefaultMaxBodyBytes = 1 << 20
+
+// MiddlewareOptions configures NewMiddleware.
+type MiddlewareOptions struct {
+	// Options configures the validation module loaded for each request.
+	Options Options
+
+	// MaxBodyBytes is the largest request body read, in bytes. Larger
+	// bodies are answered with 413. Zero uses DefaultMaxBodyBytes.
+	MaxBodyBytes int64
+
+	// ErrorLog receives script errors, which are answered with 500. Nil
+	// uses the standard logger of the log package.
+	ErrorLog *log.Logger
+}
+
+// NewMiddleware returns an http.Handler wrapper that validates JSON request
+// bodies with the Lua script at scriptPath. The script receives the decoded
+// body and a table with the request method and path as arguments (...) and
+// returns whether the body is valid and, if not, a table of field errors or
+// a message:
+//
+//	local body = ...
+//	return users:validate(body)
+//
+// Invalid requests are answered with 400 and a JSON object
+// {"errors": {...}}; the body is passed on unchanged otherwise. Each request
+// runs in a fresh Lua state with the validation module preloaded, and the
+// script is cancelled when the request context is done. NewMiddleware
+// returns an error if the script cannot be read or compiled.
+func NewMiddleware(scriptPath string, opts MiddlewareOptions) (func(http.Handler) http.Handler, error) {
+	proto, err := compileScript(scriptPath)
+	if err != nil {
+		return nil, fmt.Errorf("validation: %v", err)
+	}
+	maxBytes := opts.MaxBodyBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBodyBytes
+	}
+	errorLog := opts.ErrorLog
+	if errorLog == nil {
+		errorLog = log.Default()
+	}
+	loader := NewLoader(opts.Options)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBytes))
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					writeErrors(w, http.StatusRequestEntityTooLarge, map[string]string{
+						"body": fmt.Sprintf("request body exceeds %d bytes", maxBytes),
+					})
+					return
+				}
+				writeErrors(w, http.StatusBadRequest, map[string]string{"body": "could not read request body"})
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(data))
+
+			var body any
+			if len(bytes.TrimSpace(data)) > 0 {
+				decoder := json.NewDecoder(bytes.NewReader(data))
+				decoder.UseNumber()
+				if err := decoder.Decode(&body); err != nil {
+					writeErrors(w, http.StatusBadRequest, map[string]string{"body": "invalid JSON: " + err.Error()})
+					return
+				}
+			}
+
+			errs, err := runScript(proto, loader, r, body)
+			if err != nil {
+				errorLog.Printf("validation: %s %s: script %s: %v", r.Method, r.URL.Path, scriptPath, err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+			if errs != nil {
+				writeErrors(w, http.StatusBadRequest, errs)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+// Middleware is like NewMiddleware with default options but panics if the
+// script cannot be read or compiled. It simplifies setup at program start.
+func Middleware(scriptPath string) func(http.Handler) http.Handler {
+	middleware, err := NewMiddleware(scriptPath, MiddlewareOptions{})
+	if err != nil {
+		panic(err.Error())
+	}
+	return middleware
+}
+
+// compileScript reads and compiles a Lua script once so it can be run in
+// many states.
+func compileScript(path string) (*lua.FunctionProto, error) {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return compileSource(string(source), path)
+}
+
+func compileSource(source, name string) (*lua.FunctionProto, error) {
+	chunk, err := parse.Parse(strings.NewReader(source), name)
+	if err != nil {
+		return nil, err
+	}
+	return lua.Compile(chunk, name)
+}
+
+// runScript runs a validation script for a request in a fresh state bound
+// to the request context.
+func runScript(proto *lua.FunctionProto, loader lua.LGFunction, r *http.Request, body any) (map[string]string, error) {
+	L := lua.NewState()
+	defer L.Close()
+	L.SetContext(r.Context())
+	L.PreloadModule("validation", loader)
+
+	request := L.NewTable()
+	request.RawSetString("method", lua.LString(r.Method))
+	request.RawSetString("path", lua.LString(r.URL.Path))
+	return runValidation(L, L.NewFunctionFromProto(proto), toLua(L, body), request)
+}
+
+// runValidation calls a validation script with args and returns the field
+// errors it reports, or nil if it returns true. A message returned instead
+// of an errors table is reported for "body".
+func runValidation(L *lua.LState, fn *lua.LFunction, args ...lua.LValue) (map[string]string, error) {
+	defer L.SetTop(0)
+
+	L.Push(fn)
+	for _, arg := range args {
+		L.Push(arg)
+	}
+	if err := L.PCall(len(args), 2, nil); err != nil {
+		return nil, err
+	}
+
+	ok, result := L.Get(-2), L.Get(-1)
+	if lua.LVAsBool(ok) {
+		return nil, nil
+	}
+	switch v := result.(type) {
+	case *lua.LTable:
+		return errorsMap(v), nil
+	case lua.LString:
+		return map[string]string{"body": string(v)}, nil
+	}
+	return map[string]string{"body": "body is invalid"}, nil
+}
+
+func writeErrors(w http.ResponseWriter, status int, errs map[string]string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{"errors": errs})
+}
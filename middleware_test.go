@@ -0,0 +1,143 @@
+package validation
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMiddleware(t *testing.T) {
+	script := filepath.Join(t.TempDir(), "users.lua")
+	err := os.WriteFile(script, []byte(`
+		local validation = require("validation")
+		local body, request = ...
+		if request.method ~= "POST" then
+			return true
+		end
+		return validation.validate_fields(body or {}, {
+			email = "required|email",
+			name = "required|string|min:2",
+		})
+	`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler := Middleware(script)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		w.Write(data)
+	}))
+
+	tests := []struct {
+		method   string
+		body     string
+		status   int
+		expected string
+	}{
+		{"POST", `{"email": "ada@example.com", "name": "Ada"}`, http.StatusOK, `{"email": "ada@example.com", "name": "Ada"}`},
+		{"POST", `{"email": "nope", "name": "A"}`, http.StatusBadRequest, `{"errors":{"email":"email must be a valid email","name":"name must be at least 2 characters"}}`},
+		{"POST", `{"email": `, http.StatusBadRequest, `"body":"invalid JSON: unexpected EOF"`},
+		{"GET", ``, http.StatusOK, ``},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, "/users", strings.NewReader(test.body))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != test.status {
+			t.Errorf("%s %s: expected status %d, got %d", test.method, test.body, test.status, rec.Code)
+		}
+		if got := strings.TrimSpace(rec.Body.String()); !strings.Contains(got, test.expected) {
+			t.Errorf("%s %s: expected body containing %s, got %s", test.method, test.body, test.expected, got)
+		}
+	}
+}
+
+func TestMiddlewareScriptError(t *testing.T) {
+	script := filepath.Join(t.TempDir(), "broken.lua")
+	if err := os.WriteFile(script, []byte(`error("boom")`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var logged bytes.Buffer
+	middleware, err := NewMiddleware(script, MiddlewareOptions{ErrorLog: log.New(&logged, "", 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	middleware(http.NotFoundHandler()).ServeHTTP(rec, httptest.NewRequest("POST", "/", strings.NewReader(`{}`)))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", rec.Code)
+	}
+	if !strings.Contains(logged.String(), "boom") {
+		t.Errorf("Expected script error to be logged, got %q", logged.String())
+	}
+
+	if _, err := NewMiddleware(filepath.Join(t.TempDir(), "missing.lua"), MiddlewareOptions{}); err == nil {
+		t.Error("Expected error for missing script")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for missing script")
+		}
+	}()
+	Middleware(filepath.Join(t.TempDir(), "missing.lua"))
+}
+
+func TestMiddlewareOptions(t *testing.T) {
+	script := filepath.Join(t.TempDir(), "options.lua")
+	err := os.WriteFile(script, []byte(`
+		local validation = require("validation")
+		local body = ...
+		if validation.validate_email_mx == nil then
+			return false, "network validators should be enabled"
+		end
+		if body.spin then
+			while true do end
+		end
+		return body.tags[1] == nil and body.tags[2] == "x", "null array items should keep their positions"
+	`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	middleware, err := NewMiddleware(script, MiddlewareOptions{
+		Options:      Options{EnableNetwork: true},
+		MaxBodyBytes: 64,
+		ErrorLog:     log.New(io.Discard, "", 0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/", strings.NewReader(`{"tags": [null, "x"]}`)))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/", strings.NewReader(`{"tags": ["`+strings.Repeat("x", 64)+`"]}`)))
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status 413, got %d", rec.Code)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/", strings.NewReader(`{"spin": true}`)).WithContext(ctx))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected cancelled script to answer 500, got %d", rec.Code)
+	}
+}
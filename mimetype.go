@@ -0,0 +1,85 @@
+package validation
+
+import (
+	"fmt"
+	"mime"
+	"regexp"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// mimeTypeName matches an RFC 6838 restricted-name, the syntax of both the
+// type and the subtype of a media type.
+var mimeTypeName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9!#$&^_.+-]{0,126}$`)
+
+// parseMediaType parses a media type such as "text/html; charset=utf-8" and
+// returns the lower-cased type/subtype and its parameters, whose names are
+// lower-cased too. Wildcards such as "image/*" are not media types.
+func parseMediaType(s string) (string, map[string]string, error) {
+	mediaType, params, err := mime.ParseMediaType(s)
+	if err != nil {
+		return "", nil, err
+	}
+	typ, subtype, ok := strings.Cut(mediaType, "/")
+	if !ok {
+		return "", nil, fmt.Errorf("media type %q has no subtype", mediaType)
+	}
+	if !mimeTypeName.MatchString(typ) || !mimeTypeName.MatchString(subtype) {
+		return "", nil, fmt.Errorf("invalid media type %q", mediaType)
+	}
+	return mediaType, params, nil
+}
+
+// mimeTypeAllowed reports whether mediaType matches one of allowed, where
+// "type/*" matches every subtype.
+func mimeTypeAllowed(mediaType string, allowed []string) bool {
+	for _, a := range allowed {
+		a = strings.ToLower(a)
+		if a == mediaType || strings.HasSuffix(a, "/*") && strings.HasPrefix(mediaType, a[:len(a)-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMIMEType reports whether s is a media type such as "text/html" with
+// optional parameters, like the value of a Content-Type header.
+func IsMIMEType(s string) bool {
+	_, _, err := parseMediaType(s)
+	return err == nil
+}
+
+// isMIMEType checks if a string is a media type and returns its type/subtype
+// and parameters
+// Usage: validation.is_mime_type(str, {allowed_mime_types={...}}) -> boolean, media_type_or_error, params?
+func isMIMEType(L *lua.LState) int {
+	str := L.CheckString(1)
+	opts := L.OptTable(2, L.NewTable())
+
+	var allowed []string
+	if tbl, ok := opts.RawGetString("allowed_mime_types").(*lua.LTable); ok {
+		for i := 1; i <= tbl.Len(); i++ {
+			allowed = append(allowed, lua.LVAsString(tbl.RawGetInt(i)))
+		}
+	}
+
+	mediaType, params, err := parseMediaType(str)
+	if err == nil && len(allowed) > 0 && !mimeTypeAllowed(mediaType, allowed) {
+		err = fmt.Errorf("media type %q is not allowed", mediaType)
+	}
+	if err != nil {
+		L.Push(lua.LFalse)
+		L.Push(lua.LString(err.Error()))
+		return 2
+	}
+
+	tbl := L.CreateTable(0, len(params))
+	for name, value := range params {
+		tbl.RawSetString(name, lua.LString(value))
+	}
+	L.Push(lua.LTrue)
+	L.Push(lua.LString(mediaType))
+	L.Push(tbl)
+	return 3
+}
@@ -0,0 +1,62 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsMIMEType(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		input    string
+		opts     string
+		expected bool
+		result   string
+	}{
+		{"text/html", `{}`, true, "text/html"},
+		{"Text/HTML; Charset=UTF-8", `{}`, true, "text/html"},
+		{"application/vnd.api+json", `{}`, true, "application/vnd.api+json"},
+		{`multipart/form-data; boundary="a b"`, `{}`, true, "multipart/form-data"},
+		{"text", `{}`, false, `media type "text" has no subtype`},
+		{"text/", `{}`, false, "mime: expected token after slash"},
+		{"image/*", `{}`, false, `invalid media type "image/*"`},
+		{"text/plain; charset", `{}`, false, "mime: invalid media parameter"},
+		{"text/plain; a=1; a=2", `{}`, false, "mime: duplicate parameter name"},
+		{"text/" + strings.Repeat("x", 128), `{}`, false, `invalid media type "text/` + strings.Repeat("x", 128) + `"`},
+		{"image/png", `{allowed_mime_types = {"image/*", "application/pdf"}}`, true, "image/png"},
+		{"application/PDF", `{allowed_mime_types = {"image/*", "application/pdf"}}`, true, "application/pdf"},
+		{"text/plain", `{allowed_mime_types = {"image/*"}}`, false, `media type "text/plain" is not allowed`},
+	}
+
+	for _, tt := range tests {
+		L.SetGlobal("input", lua.LString(tt.input))
+		if err := L.DoString(`
+			local validation = require("validation")
+			local ok, result = validation.is_mime_type(input, ` + tt.opts + `)
+			return ok, result
+		`); err != nil {
+			t.Fatalf("is_mime_type(%q): %v", tt.input, err)
+		}
+
+		ok, result := L.Get(-2), L.Get(-1)
+		L.Pop(2)
+		if ok != lua.LBool(tt.expected) || result.String() != tt.result {
+			t.Errorf("is_mime_type(%q, %s) = %v, %q, want %v, %q", tt.input, tt.opts, ok, result, tt.expected, tt.result)
+		}
+	}
+
+	if err := L.DoString(`
+		local validation = require("validation")
+		local ok, media_type, params = validation.is_mime_type("text/html; Charset=utf-8; q=0.5")
+		assert(ok and media_type == "text/html")
+		assert(params.charset == "utf-8" and params.q == "0.5")
+	`); err != nil {
+		t.Fatalf("is_mime_type params: %v", err)
+	}
+}
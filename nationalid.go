@@ -0,0 +1,110 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+var (
+	nationalIDsMu sync.RWMutex
+	nationalIDs   = map[string]func(string) bool{
+		"GB": isNINO,
+		"TR": isTCKN,
+		"US": isSSN,
+	}
+)
+
+// RegisterNationalID adds or replaces the national identifier check of a
+// country, given as an ISO 3166-1 alpha-2 code, for is_national_id in every
+// Lua state.
+func RegisterNationalID(country string, fn func(string) bool) error {
+	if len(country) != 2 {
+		return fmt.Errorf("country %q must be an ISO 3166-1 alpha-2 code", country)
+	}
+	if fn == nil {
+		return fmt.Errorf("country %q: function must not be nil", country)
+	}
+
+	nationalIDsMu.Lock()
+	defer nationalIDsMu.Unlock()
+	nationalIDs[strings.ToUpper(country)] = fn
+	return nil
+}
+
+// nationalIDCheck returns the check registered for a country.
+func nationalIDCheck(country string) (func(string) bool, bool) {
+	nationalIDsMu.RLock()
+	defer nationalIDsMu.RUnlock()
+	fn, ok := nationalIDs[strings.ToUpper(country)]
+	return fn, ok
+}
+
+// IsNationalID reports whether s is a national identifier of country, given
+// as an ISO 3166-1 alpha-2 code. Countries without a registered check fail.
+func IsNationalID(s, country string) bool {
+	fn, ok := nationalIDCheck(country)
+	return ok && fn(s)
+}
+
+// isSSN validates a US Social Security number, AAA-GG-SSSS with optional
+// hyphens. Area 000, 666 and 900-999, group 00 and serial 0000 are never
+// issued.
+func isSSN(s string) bool {
+	if len(s) == 11 && s[3] == '-' && s[6] == '-' {
+		s = s[:3] + s[4:6] + s[7:]
+	}
+	if len(s) != 9 || !isDigits(s) {
+		return false
+	}
+	area, group, serial := s[:3], s[3:5], s[5:]
+	return area != "000" && area != "666" && area[0] != '9' && group != "00" && serial != "0000"
+}
+
+// ninoInvalidPrefixes are NINO prefixes that are not allocated.
+var ninoInvalidPrefixes = buildCodeSet("BG GB KN NK NT TN ZZ")
+
+// isNINO validates a UK National Insurance number such as "QQ 12 34 56 C":
+// two prefix letters, six digits and a suffix A-D, spaces optional.
+func isNINO(s string) bool {
+	s = strings.ToUpper(strings.ReplaceAll(s, " ", ""))
+	if len(s) != 9 || !isUpperAlpha(s[:2]) || !isDigits(s[2:8]) || s[8] < 'A' || s[8] > 'D' {
+		return false
+	}
+	first, second := s[0], s[1]
+	return !strings.ContainsRune("DFIQUV", rune(first)) && !strings.ContainsRune("DFIOQUV", rune(second)) &&
+		!ninoInvalidPrefixes[s[:2]]
+}
+
+// isTCKN validates a Turkish identity number (T.C. Kimlik No): 11 digits not
+// starting with 0, with two check digits.
+func isTCKN(s string) bool {
+	if len(s) != 11 || !isDigits(s) || s[0] == '0' {
+		return false
+	}
+	d := make([]int, 11)
+	for i := range d {
+		d[i] = int(s[i] - '0')
+	}
+	odd := d[0] + d[2] + d[4] + d[6] + d[8]
+	even := d[1] + d[3] + d[5] + d[7]
+	if ((odd*7-even)%10+10)%10 != d[9] {
+		return false
+	}
+	return (odd+even+d[9])%10 == d[10]
+}
+
+// isNationalID checks if a string is a national identifier of a country
+// Usage: validation.is_national_id(str, country) -> boolean
+func isNationalID(L *lua.LState) int {
+	str := L.CheckString(1)
+	country := L.CheckString(2)
+	fn, ok := nationalIDCheck(country)
+	if !ok {
+		L.ArgError(2, fmt.Sprintf("unsupported national ID country %q", country))
+	}
+	L.Push(lua.LBool(fn(str)))
+	return 1
+}
@@ -0,0 +1,85 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsNationalID(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		args     string
+		expected bool
+	}{
+		{`"123-45-6789", "US"`, true},
+		{`"123456789", "us"`, true},
+		{`"000-45-6789", "US"`, false},
+		{`"666-45-6789", "US"`, false},
+		{`"912-45-6789", "US"`, false},
+		{`"123-00-6789", "US"`, false},
+		{`"123-45-0000", "US"`, false},
+		{`"123-456-789", "US"`, false},
+		{`"AB 12 34 56 C", "GB"`, true},
+		{`"ab123456a", "GB"`, true},
+		{`"AB123456E", "GB"`, false},
+		{`"DA123456A", "GB"`, false},
+		{`"AO123456A", "GB"`, false},
+		{`"GB123456A", "GB"`, false},
+		{`"10000000146", "TR"`, true},
+		{`"10000000147", "TR"`, false},
+		{`"01000000146", "TR"`, false},
+		{`"1000000014", "TR"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			err := L.DoString(`return require("validation").is_national_id(` + tt.args + `)`)
+			if err != nil {
+				t.Fatalf("is_national_id failed: %v", err)
+			}
+			result := L.Get(-1).(lua.LBool)
+			L.Pop(1)
+			if bool(result) != tt.expected {
+				t.Errorf("Expected %v for %s, got %v", tt.expected, tt.args, result)
+			}
+		})
+	}
+
+	err := L.DoString(`require("validation").is_national_id("123", "ZZ")`)
+	if err == nil || !strings.Contains(err.Error(), `unsupported national ID country "ZZ"`) {
+		t.Errorf("Expected unsupported country error, got %v", err)
+	}
+}
+
+func TestRegisterNationalID(t *testing.T) {
+	if err := RegisterNationalID("XA", func(s string) bool { return s == "XA-1" }); err != nil {
+		t.Fatalf("RegisterNationalID failed: %v", err)
+	}
+	if err := RegisterNationalID("XAB", func(string) bool { return true }); err == nil {
+		t.Error("Expected an error for a three-letter code")
+	}
+	if err := RegisterNationalID("XB", nil); err == nil {
+		t.Error("Expected an error for a nil function")
+	}
+
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	if err := L.DoString(`
+		local validation = require("validation")
+		return validation.is_national_id("XA-1", "xa"), validation.is_national_id("XA-2", "XA")
+	`); err != nil {
+		t.Fatalf("is_national_id failed: %v", err)
+	}
+	if !bool(L.Get(-2).(lua.LBool)) || bool(L.Get(-1).(lua.LBool)) {
+		t.Error("Expected the registered check to be used")
+	}
+}
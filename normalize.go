@@ -0,0 +1,76 @@
+package validation
+
+import (
+	"net/mail"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// emailProvider describes how a mail provider aliases mailbox names.
+type emailProvider struct {
+	domain     string // canonical domain of the provider
+	ignoreCase bool   // the local part is not case-sensitive
+	ignoreDots bool   // dots in the local part are not significant
+}
+
+// emailProviders lists providers with aliasing rules beyond plus-addressing,
+// keyed by domain.
+var emailProviders = map[string]emailProvider{
+	"gmail.com":      {domain: "gmail.com", ignoreCase: true, ignoreDots: true},
+	"googlemail.com": {domain: "gmail.com", ignoreCase: true, ignoreDots: true},
+	"outlook.com":    {domain: "outlook.com", ignoreCase: true},
+	"hotmail.com":    {domain: "hotmail.com", ignoreCase: true},
+	"live.com":       {domain: "live.com", ignoreCase: true},
+	"yahoo.com":      {domain: "yahoo.com", ignoreCase: true},
+	"icloud.com":     {domain: "icloud.com", ignoreCase: true},
+}
+
+// NormalizeEmail returns the canonical form of an email address for
+// deduplication and whether the address is valid. The domain is lowercased
+// and any display name and plus-addressing tag ("+tag") are removed. Local
+// parts are case-sensitive (RFC 5321), so they are only rewritten further by
+// the rules of known providers: Gmail, Outlook, Hotmail, Live, Yahoo and
+// iCloud ignore case, Gmail also ignores dots, and googlemail.com is
+// rewritten to gmail.com.
+// With allowIDN, internationalized domains are accepted and returned in
+// punycode.
+func NormalizeEmail(email string, allowIDN bool) (string, bool) {
+	if !checkEmail(email, allowIDN) {
+		return "", false
+	}
+	addr, _ := mail.ParseAddress(email)
+	local, domain, _ := splitEmail(addr.Address, allowIDN)
+	domain = strings.ToLower(domain)
+
+	if plus := strings.IndexByte(local, '+'); plus > 0 {
+		local = local[:plus]
+	}
+	if provider, ok := emailProviders[domain]; ok {
+		domain = provider.domain
+		if provider.ignoreCase {
+			local = strings.ToLower(local)
+		}
+		if provider.ignoreDots {
+			local = strings.ReplaceAll(local, ".", "")
+		}
+	}
+	return local + "@" + domain, true
+}
+
+// normalizeEmail returns the canonical form of an email address
+// Usage: validation.normalize_email(email, {allow_idn=false}) -> string | nil, boolean
+func normalizeEmail(L *lua.LState) int {
+	email := L.CheckString(1)
+	opts := L.OptTable(2, L.NewTable())
+
+	normalized, ok := NormalizeEmail(email, lua.LVAsBool(opts.RawGetString("allow_idn")))
+	if !ok {
+		L.Push(lua.LNil)
+		L.Push(lua.LBool(false))
+		return 2
+	}
+	L.Push(lua.LString(normalized))
+	L.Push(lua.LBool(true))
+	return 2
+}
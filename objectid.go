@@ -0,0 +1,53 @@
+package validation
+
+import (
+	"encoding/hex"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// objectIDEpoch is the earliest plausible ObjectID timestamp, shortly before
+// MongoDB was first released.
+var objectIDEpoch = time.Date(2009, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// objectIDClockSkew is how far in the future a plausible ObjectID
+// timestamp may be.
+const objectIDClockSkew = 24 * time.Hour
+
+// IsObjectID reports whether s is a MongoDB ObjectID: 24 hex characters.
+func IsObjectID(s string) bool {
+	if len(s) != 24 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isHexDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// objectIDTime returns the creation time embedded in the first four bytes of
+// a valid ObjectID.
+func objectIDTime(s string) time.Time {
+	b, _ := hex.DecodeString(s[:8])
+	seconds := int64(b[0])<<24 | int64(b[1])<<16 | int64(b[2])<<8 | int64(b[3])
+	return time.Unix(seconds, 0)
+}
+
+// isObjectID validates a MongoDB ObjectID, optionally requiring a plausible
+// embedded timestamp (not before 2009 and at most a day in the future)
+// Usage: validation.is_object_id(str, {check_timestamp=false}) -> boolean
+func isObjectID(L *lua.LState) int {
+	str := L.CheckString(1)
+	opts := L.OptTable(2, L.NewTable())
+
+	valid := IsObjectID(str)
+	if valid && lua.LVAsBool(opts.RawGetString("check_timestamp")) {
+		created := objectIDTime(str)
+		valid = !created.Before(objectIDEpoch) && !created.After(stateOf(L).options.now().Add(objectIDClockSkew))
+	}
+	L.Push(lua.LBool(valid))
+	return 1
+}
@@ -0,0 +1,43 @@
+package validation
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsObjectID(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	L.PreloadModule("validation", NewLoader(Options{Now: func() time.Time { return now }}))
+
+	future := fmt.Sprintf("%08x0000000000000000", now.Add(48*time.Hour).Unix())
+
+	tests := []struct {
+		args     string
+		expected bool
+	}{
+		{`"507f1f77bcf86cd799439011"`, true},
+		{`"507F1F77BCF86CD799439011"`, true},
+		{`"507f1f77bcf86cd79943901"`, false},
+		{`"507f1f77bcf86cd79943901g"`, false},
+		{`"507f1f77bcf86cd799439011", {check_timestamp = true}`, true},
+		{`"000000000000000000000000", {check_timestamp = true}`, false},
+		{`"` + future + `"`, true},
+		{`"` + future + `", {check_timestamp = true}`, false},
+	}
+
+	for _, test := range tests {
+		if err := L.DoString(`return require("validation").is_object_id(` + test.args + `)`); err != nil {
+			t.Fatalf("is_object_id(%s) failed: %v", test.args, err)
+		}
+		if got := lua.LVAsBool(L.Get(-1)); got != test.expected {
+			t.Errorf("is_object_id(%s): expected %v, got %v", test.args, test.expected, got)
+		}
+		L.Pop(1)
+	}
+}
@@ -0,0 +1,452 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// openAPIMethods are the operations of an OpenAPI path item.
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// openAPIAnnotations are OpenAPI schema keywords that do not affect
+// validation and are dropped before a schema is compiled.
+var openAPIAnnotations = []string{"nullable", "example", "xml", "externalDocs"}
+
+// openAPIParamLocations are the places a parameter can be sent, in the
+// order they are validated.
+var openAPIParamLocations = []string{"path", "query", "header", "cookie"}
+
+// openAPIOperation holds the compiled checks of a single operation.
+// Parameters have one schema per location, since a path and a query
+// parameter may share a name.
+type openAPIOperation struct {
+	params    map[string]*openAPIParams
+	body      *field
+	responses map[string]*field
+}
+
+// openAPIParams holds the compiled parameters of one location.
+type openAPIParams struct {
+	schema *schema
+	types  map[string]string
+}
+
+// openAPIParamKey identifies a parameter by location and name.
+type openAPIParamKey struct {
+	in, name string
+}
+
+// openAPIRefs resolves local $ref pointers of an OpenAPI document.
+type openAPIRefs struct {
+	doc map[string]any
+}
+
+// parseOpenAPI compiles every operation of an OpenAPI 3 JSON document that
+// has an operationId.
+func parseOpenAPI(L *lua.LState, data []byte) (map[string]*openAPIOperation, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %v", err)
+	}
+	if version, _ := doc["openapi"].(string); !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q", version)
+	}
+
+	refs := &openAPIRefs{doc: doc}
+	paths, _ := doc["paths"].(map[string]any)
+	names := make([]string, 0, len(paths))
+	for path := range paths {
+		names = append(names, path)
+	}
+	sort.Strings(names)
+
+	operations := map[string]*openAPIOperation{}
+	for _, path := range names {
+		item, err := refs.object(paths[path])
+		if err != nil {
+			return nil, fmt.Errorf("path %s: %v", path, err)
+		}
+		for _, method := range openAPIMethods {
+			node, ok := item[method].(map[string]any)
+			if !ok {
+				continue
+			}
+			id, _ := node["operationId"].(string)
+			if id == "" {
+				continue
+			}
+			if _, ok := operations[id]; ok {
+				return nil, fmt.Errorf("duplicate operationId %q", id)
+			}
+			op, err := refs.operation(L, item["parameters"], node)
+			if err != nil {
+				return nil, fmt.Errorf("operation %s: %v", id, err)
+			}
+			operations[id] = op
+		}
+	}
+	return operations, nil
+}
+
+// operation compiles the parameters, request body and responses of an
+// operation. Operation parameters override path item parameters with the
+// same location and name.
+func (r *openAPIRefs) operation(L *lua.LState, shared any, node map[string]any) (*openAPIOperation, error) {
+	op := &openAPIOperation{params: map[string]*openAPIParams{}, responses: map[string]*field{}}
+
+	params := map[openAPIParamKey]map[string]any{}
+	for _, list := range []any{shared, node["parameters"]} {
+		entries, _ := list.([]any)
+		for _, entry := range entries {
+			param, err := r.object(entry)
+			if err != nil {
+				return nil, err
+			}
+			name, _ := param["name"].(string)
+			in, _ := param["in"].(string)
+			if !slices.Contains(openAPIParamLocations, in) {
+				return nil, fmt.Errorf("parameter %q: unsupported location %q", name, in)
+			}
+			params[openAPIParamKey{in, name}] = param
+		}
+	}
+
+	defs := map[string]*lua.LTable{}
+	for key, param := range params {
+		def := L.NewTable()
+		if param["schema"] != nil {
+			node, err := r.schema(param["schema"], nil)
+			if err != nil {
+				return nil, fmt.Errorf("parameter %q: %v", key.name, err)
+			}
+			if def, err = jsonSchemaDef(L, node); err != nil {
+				return nil, fmt.Errorf("parameter %q: %v", key.name, err)
+			}
+		}
+		if param["required"] == true || key.in == "path" {
+			def.RawSetString("required", lua.LTrue)
+		}
+		location, ok := op.params[key.in]
+		if !ok {
+			location = &openAPIParams{types: map[string]string{}}
+			op.params[key.in], defs[key.in] = location, L.NewTable()
+		}
+		if typ, ok := def.RawGetString("type").(lua.LString); ok {
+			location.types[key.name] = string(typ)
+		}
+		defs[key.in].RawSetString(key.name, def)
+	}
+	for in, location := range op.params {
+		s, err := compileSchema(L, defs[in])
+		if err != nil {
+			return nil, err
+		}
+		location.schema = s
+	}
+
+	if node["requestBody"] != nil {
+		body, err := r.object(node["requestBody"])
+		if err != nil {
+			return nil, err
+		}
+		f, err := r.content(L, body)
+		if err != nil {
+			return nil, fmt.Errorf("request body: %v", err)
+		}
+		if f != nil {
+			f.required = body["required"] == true
+			op.body = f
+		}
+	}
+
+	responses, _ := node["responses"].(map[string]any)
+	for status, entry := range responses {
+		response, err := r.object(entry)
+		if err != nil {
+			return nil, err
+		}
+		f, err := r.content(L, response)
+		if err != nil {
+			return nil, fmt.Errorf("response %s: %v", status, err)
+		}
+		if f != nil {
+			op.responses[status] = f
+		}
+	}
+	return op, nil
+}
+
+// content compiles the JSON schema of a request body or response. The
+// application/json schema is used if there is one, then the first other
+// +json media type in sorted order. Bodies without a JSON media type with a
+// schema are not validated.
+func (r *openAPIRefs) content(L *lua.LState, node map[string]any) (*field, error) {
+	content, _ := node["content"].(map[string]any)
+	mediaTypes := make([]string, 0, len(content))
+	for mediaType := range content {
+		if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+	}
+	slices.SortFunc(mediaTypes, func(a, b string) int {
+		switch {
+		case a == b:
+			return 0
+		case a == "application/json":
+			return -1
+		case b == "application/json":
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+
+	for _, mediaType := range mediaTypes {
+		media, ok := content[mediaType].(map[string]any)
+		if !ok || media["schema"] == nil {
+			continue
+		}
+		schemaNode, err := r.schema(media["schema"], nil)
+		if err != nil {
+			return nil, err
+		}
+		def, err := jsonSchemaDef(L, schemaNode)
+		if err != nil {
+			return nil, err
+		}
+		return compileField(L, def)
+	}
+	return nil, nil
+}
+
+// object returns a document object, following $ref if present.
+func (r *openAPIRefs) object(node any) (map[string]any, error) {
+	for range 32 {
+		m, ok := node.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected an object")
+		}
+		ref, ok := m["$ref"].(string)
+		if !ok {
+			return m, nil
+		}
+		target, err := r.lookup(ref)
+		if err != nil {
+			return nil, err
+		}
+		node = target
+	}
+	return nil, fmt.Errorf("too many nested references")
+}
+
+// schema returns a copy of a schema object with references resolved and
+// OpenAPI 3.0 specifics translated to JSON Schema. Recursive schemas are not
+// supported; stack holds the references being resolved.
+func (r *openAPIRefs) schema(node any, stack []string) (map[string]any, error) {
+	m, ok := node.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("schema must be an object")
+	}
+	if ref, ok := m["$ref"].(string); ok {
+		for _, seen := range stack {
+			if seen == ref {
+				return nil, fmt.Errorf("recursive reference %s", ref)
+			}
+		}
+		target, err := r.lookup(ref)
+		if err != nil {
+			return nil, err
+		}
+		return r.schema(target, append(stack, ref))
+	}
+
+	out := make(map[string]any, len(m))
+	for key, value := range m {
+		out[key] = value
+	}
+	for _, keyword := range openAPIAnnotations {
+		delete(out, keyword)
+	}
+	for _, bound := range []string{"Minimum", "Maximum"} {
+		exclusive, ok := out["exclusive"+bound].(bool)
+		if !ok {
+			continue
+		}
+		delete(out, "exclusive"+bound)
+		if limit, ok := out[strings.ToLower(bound)]; ok && exclusive {
+			out["exclusive"+bound] = limit
+			delete(out, strings.ToLower(bound))
+		}
+	}
+
+	if properties, ok := m["properties"].(map[string]any); ok {
+		resolved := make(map[string]any, len(properties))
+		for name, prop := range properties {
+			p, err := r.schema(prop, stack)
+			if err != nil {
+				return nil, fmt.Errorf("property %q: %v", name, err)
+			}
+			resolved[name] = p
+		}
+		out["properties"] = resolved
+	}
+	if items, ok := m["items"]; ok {
+		item, err := r.schema(items, stack)
+		if err != nil {
+			return nil, fmt.Errorf("items: %v", err)
+		}
+		out["items"] = item
+	}
+	return out, nil
+}
+
+// lookup resolves a local JSON pointer such as "#/components/schemas/User".
+func (r *openAPIRefs) lookup(ref string) (any, error) {
+	pointer, ok := strings.CutPrefix(ref, "#/")
+	if !ok {
+		return nil, fmt.Errorf("unsupported reference %s", ref)
+	}
+
+	var node any = r.doc
+	for _, token := range strings.Split(pointer, "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		m, ok := node.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unresolved reference %s", ref)
+		}
+		if node, ok = m[token]; !ok {
+			return nil, fmt.Errorf("unresolved reference %s", ref)
+		}
+	}
+	return node, nil
+}
+
+// validateRequest checks request parameters and body. params holds a table
+// per location, such as params.query, or the parameters of every location
+// by name. Parameters given per location are reported as "query.id" rather
+// than "id". String parameters declared as numbers or booleans are converted
+// first, as they usually come from a path or query string.
+func (op *openAPIOperation) validateRequest(L *lua.LState, body lua.LValue, params *lua.LTable) []fieldError {
+	if params == nil {
+		params = L.NewTable()
+	}
+
+	var errs []fieldError
+	for _, in := range openAPIParamLocations {
+		location, ok := op.params[in]
+		if !ok {
+			continue
+		}
+		source, prefix := params, ""
+		if tbl, ok := params.RawGetString(in).(*lua.LTable); ok {
+			source, prefix = tbl, in+"."
+		}
+
+		values := L.NewTable()
+		for _, name := range location.schema.names {
+			value := source.RawGetString(name)
+			switch location.types[name] {
+			case "number", "integer":
+				if n, ok := toNumber(value); ok {
+					value = n
+				}
+			case "boolean":
+				if b, ok := toBoolean(value); ok {
+					value = b
+				}
+			}
+			values.RawSetString(name, value)
+		}
+		errs = location.schema.validateFields(L, prefix, values, errs)
+	}
+
+	if op.body != nil {
+		errs = op.body.validate(L, "body", body, errs)
+	}
+	return errs
+}
+
+// openAPIExports are the functions of the validation.openapi table.
+var openAPIExports = map[string]lua.LGFunction{
+	"load":              openAPILoad,
+	"validate_request":  openAPIValidateRequest,
+	"validate_response": openAPIValidateResponse,
+}
+
+// loadOpenAPI builds the validation.openapi table, compiling the document
+// from the loader options if one is set.
+func loadOpenAPI(L *lua.LState, st *moduleState) *lua.LTable {
+	if len(st.options.OpenAPI) > 0 {
+		operations, err := parseOpenAPI(L, st.options.OpenAPI)
+		if err != nil {
+			L.RaiseError("validation: %v", err)
+		}
+		st.operations, st.documentOperations = operations, operations
+	}
+	return L.SetFuncs(L.NewTable(), openAPIExports)
+}
+
+// openAPILoad replaces the OpenAPI document used for validation
+// Usage: validation.openapi.load(json) -> nil
+func openAPILoad(L *lua.LState) int {
+	operations, err := parseOpenAPI(L, []byte(L.CheckString(1)))
+	if err != nil {
+		L.ArgError(1, err.Error())
+	}
+	stateOf(L).operations = operations
+	return 0
+}
+
+func checkOperation(L *lua.LState, n int) *openAPIOperation {
+	id := L.CheckString(n)
+	operations := stateOf(L).operations
+	if operations == nil {
+		L.RaiseError("no OpenAPI document loaded")
+	}
+	op, ok := operations[id]
+	if !ok {
+		L.ArgError(n, fmt.Sprintf("unknown operation %q", id))
+	}
+	return op
+}
+
+// openAPIValidateRequest validates a request body and parameters against an operation
+// Usage: validation.openapi.validate_request(op_id, body, params) -> boolean, table
+func openAPIValidateRequest(L *lua.LState) int {
+	op := checkOperation(L, 1)
+	body := L.Get(2)
+	params := L.OptTable(3, nil)
+
+	errs := op.validateRequest(L, body, params)
+	L.Push(lua.LBool(len(errs) == 0))
+	L.Push(fieldErrorsTable(L, errs))
+	return 2
+}
+
+// openAPIValidateResponse validates a response body against an operation
+// Usage: validation.openapi.validate_response(op_id, status, body) -> boolean, table
+func openAPIValidateResponse(L *lua.LState) int {
+	op := checkOperation(L, 1)
+	status := L.CheckAny(2).String()
+	body := L.Get(3)
+
+	f, ok := op.responses[status]
+	if !ok && len(status) == 3 {
+		f, ok = op.responses[status[:1]+"XX"]
+	}
+	if !ok {
+		f = op.responses["default"]
+	}
+
+	var errs []fieldError
+	if f != nil {
+		errs = f.validate(L, "body", body, nil)
+	}
+	L.Push(lua.LBool(len(errs) == 0))
+	L.Push(fieldErrorsTable(L, errs))
+	return 2
+}
@@ -0,0 +1,215 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+const testOpenAPI = `{
+	"openapi": "3.0.3",
+	"info": {"title": "Users", "version": "1.0.0"},
+	"paths": {
+		"/users/{id}": {
+			"parameters": [{"name": "id", "in": "path", "schema": {"type": "integer", "minimum": 1}}],
+			"put": {
+				"operationId": "updateUser",
+				"parameters": [{"$ref": "#/components/parameters/Notify"}],
+				"requestBody": {
+					"required": true,
+					"content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}
+				},
+				"responses": {
+					"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}},
+					"4XX": {"description": "Error", "content": {"application/json": {"schema": {
+						"type": "object", "required": ["error"], "properties": {"error": {"type": "string"}}
+					}}}}
+				}
+			}
+		}
+	},
+	"components": {
+		"parameters": {
+			"Notify": {"name": "notify", "in": "query", "schema": {"type": "boolean"}}
+		},
+		"schemas": {
+			"User": {
+				"type": "object",
+				"required": ["email"],
+				"properties": {
+					"email": {"type": "string", "format": "email", "example": "ada@example.com"},
+					"age": {"type": "integer", "minimum": 0, "exclusiveMinimum": true, "nullable": true}
+				}
+			}
+		}
+	}
+}`
+
+func TestOpenAPIValidateRequest(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", NewLoader(Options{OpenAPI: []byte(testOpenAPI)}))
+
+	script := `
+		local openapi = require("validation").openapi
+		local ok = openapi.validate_request("updateUser", {email="ada@example.com", age=36}, {id="7", notify="true"})
+		local _, errors = openapi.validate_request("updateUser", {email="nope", age=0}, {id="0", notify="maybe"})
+		local _, missing = openapi.validate_request("updateUser", nil, {})
+		return ok, errors["body.email"], errors["body.age"], errors.id, errors.notify, missing.body, missing.id
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("OpenAPI request test failed: %v", err)
+	}
+
+	ok := L.Get(-7).(lua.LBool)
+	if !bool(ok) {
+		t.Error("Expected true for valid request")
+	}
+
+	expected := []string{
+		"body.email must be a valid email",
+		"body.age must be greater than 0",
+		"id must be at least 1",
+		"notify must be of type boolean",
+		"body is required",
+		"id is required",
+	}
+	for i, message := range expected {
+		if got := L.Get(i - len(expected)).String(); got != message {
+			t.Errorf("Expected %q, got %q", message, got)
+		}
+	}
+}
+
+func TestOpenAPIValidateResponse(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+	L.SetGlobal("doc", lua.LString(testOpenAPI))
+
+	script := `
+		local openapi = require("validation").openapi
+		openapi.load(doc)
+		local ok = openapi.validate_response("updateUser", 200, {email="ada@example.com"})
+		local _, errors = openapi.validate_response("updateUser", 200, {age=3})
+		local _, failure = openapi.validate_response("updateUser", "404", {})
+		return ok, errors["body.email"], failure["body.error"]
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("OpenAPI response test failed: %v", err)
+	}
+
+	if ok := L.Get(-3).(lua.LBool); !bool(ok) {
+		t.Error("Expected true for valid response")
+	}
+	if got := L.Get(-2).String(); got != "body.email is required" {
+		t.Errorf("Expected missing email, got %q", got)
+	}
+	if got := L.Get(-1).String(); got != "body.error is required" {
+		t.Errorf("Expected missing error, got %q", got)
+	}
+}
+
+func TestOpenAPIParameterLocations(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", NewLoader(Options{OpenAPI: []byte(`{
+		"openapi": "3.0.3",
+		"paths": {"/items/{id}": {"get": {
+			"operationId": "getItem",
+			"parameters": [
+				{"name": "id", "in": "path", "schema": {"type": "integer"}},
+				{"name": "id", "in": "query", "schema": {"type": "string", "enum": ["a", "b"]}}
+			]
+		}}}
+	}`)}))
+
+	script := `
+		local openapi = require("validation").openapi
+		local ok = openapi.validate_request("getItem", nil, {path = {id = "7"}, query = {id = "a"}})
+		local _, errors = openapi.validate_request("getItem", nil, {path = {id = "a"}, query = {id = "7"}})
+		return ok, errors["path.id"], errors["query.id"]
+	`
+	if err := L.DoString(script); err != nil {
+		t.Fatalf("OpenAPI parameter location test failed: %v", err)
+	}
+
+	if ok := L.Get(-3).(lua.LBool); !bool(ok) {
+		t.Error("Expected true for parameters sent per location")
+	}
+	if got := L.Get(-2).String(); got != "path.id must be of type integer" {
+		t.Errorf("Expected path id type error, got %q", got)
+	}
+	if got := L.Get(-1).String(); got != "query.id must be one of a, b" {
+		t.Errorf("Expected query id enum error, got %q", got)
+	}
+}
+
+func TestOpenAPIContentMediaTypes(t *testing.T) {
+	for range 20 {
+		L := lua.NewState()
+		L.PreloadModule("validation", NewLoader(Options{OpenAPI: []byte(`{
+			"openapi": "3.0.3",
+			"paths": {"/": {"post": {
+				"operationId": "create",
+				"requestBody": {"content": {
+					"application/problem+json": {"schema": {"type": "object", "required": ["title"], "properties": {"title": {"type": "string"}}}},
+					"application/vnd.api+json": {},
+					"application/json": {"schema": {"type": "object", "required": ["name"], "properties": {"name": {"type": "string"}}}}
+				}}
+			}}}
+		}`)}))
+
+		if err := L.DoString(`
+			local openapi = require("validation").openapi
+			local _, errors = openapi.validate_request("create", {title = "x"})
+			return errors["body.name"]
+		`); err != nil {
+			L.Close()
+			t.Fatalf("OpenAPI media type test failed: %v", err)
+		}
+		if got := L.Get(-1).String(); got != "body.name is required" {
+			L.Close()
+			t.Fatalf("Expected the application/json schema to be used, got %q", got)
+		}
+		L.Close()
+	}
+}
+
+func TestOpenAPIErrors(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		script   string
+		expected string
+	}{
+		{`require("validation").openapi.validate_request("updateUser", {})`, "no OpenAPI document loaded"},
+		{`require("validation").openapi.load('{"swagger": "2.0"}')`, `unsupported OpenAPI version ""`},
+		{`local openapi = require("validation").openapi
+		  openapi.load('{"openapi": "3.1.0", "paths": {}}')
+		  openapi.validate_request("missing", {})`, `unknown operation "missing"`},
+		{`require("validation").openapi.load([[{"openapi": "3.1.0", "paths": {"/": {"post": {
+			"operationId": "loop",
+			"requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Node"}}}}
+		  }}}, "components": {"schemas": {"Node": {"type": "object", "properties": {"next": {"$ref": "#/components/schemas/Node"}}}}}}]])`,
+			"recursive reference #/components/schemas/Node"},
+	}
+
+	for _, test := range tests {
+		err := L.DoString(test.script)
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("Expected error containing %q, got %v", test.expected, err)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package validation
+
+import (
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// Options configures the module created by NewLoader.
+type Options struct {
+	// EnableNetwork exposes validators that perform network I/O, such as
+	// DNS lookups. They are left out unless it is set.
+	EnableNetwork bool
+
+	// EnableJWTVerification exposes verify_jwt, which accepts signed tokens
+	// as proof of identity. It is left out unless it is set.
+	EnableJWTVerification bool
+
+	// Only restricts the module to the listed validator groups (see groups)
+	// or individual validator names. An empty list exposes everything.
+	Only []string
+
+	// OpenAPI is an OpenAPI 3 document in JSON whose operations can be
+	// checked with validation.openapi.
+	OpenAPI []byte
+
+	// Resolver performs the DNS lookups of network validators such as
+	// validate_email_mx. Nil uses net.DefaultResolver.
+	Resolver Resolver
+
+	// Now returns the current time for validators that compare against it,
+	// such as is_card_expiry. Nil uses time.Now; tests can fix the clock.
+	Now func() time.Time
+
+	// RegexLimits bounds pattern size, subject size and match time of
+	// validate_regex, matches_all and matches_any.
+	RegexLimits RegexLimits
+
+	// JWTAlgorithms restricts the signature algorithms verify_jwt accepts,
+	// such as "RS256". Scripts can narrow the list further but not widen it.
+	// When it is empty, scripts must list the algorithms they accept.
+	JWTAlgorithms []string
+
+	// JSONLimits bounds the size and nesting of documents checked by
+	// is_json. Scripts can tighten the limits but not relax them.
+	JSONLimits JSONLimits
+}
+
+// now returns the current time of the configured clock.
+func (o Options) now() time.Time {
+	if o.Now != nil {
+		return o.Now()
+	}
+	return time.Now()
+}
+
+// groups assigns every module function to a group that can be selected with
+// Options.Only. Validators added with RegisterGoValidator belong to "custom".
+var groups = map[string][]string{
+	"core": {
+		"is_empty", "is_blank", "is_present", "is_string", "is_number", "is_table",
+		"is_boolean", "is_nil", "equals", "deep_equals", "coerce_number", "coerce_boolean",
+	},
+	"string": {
+		"min_length", "max_length", "validate_regex", "match", "matches_all", "matches_any",
+		"matches_pattern", "patterns", "validate_lua_pattern", "validate_password",
+		"starts_with", "ends_with", "contains", "not_starts_with", "not_ends_with", "not_contains",
+		"is_utf8", "is_ascii", "is_printable", "is_alpha", "is_alphanumeric", "is_numeric_string",
+		"is_lowercase", "is_uppercase", "is_snake_case", "is_constant_case", "is_kebab_case", "is_camel_case", "is_pascal_case",
+		"is_slug", "is_username",
+	},
+	"number": {
+		"in_range",
+	},
+	"format": {
+		"validate_email", "is_disposable_email", "normalize_email", "validate_url",
+		"validate_domain", "is_domain", "validate_hostname", "is_hostname", "is_available_subdomain",
+		"domain_to_ascii", "domain_to_unicode",
+		"is_semver", "semvers_sorted", "semver_satisfies", "is_cron", "cron_not_more_frequent_than",
+		"is_uuid", "is_ulid", "is_object_id",
+		"is_ip", "is_ipv4", "is_ipv6", "is_cidr", "is_mac", "is_port",
+		"is_private_ip", "is_loopback_ip", "is_multicast_ip", "is_public_ip",
+		"is_phone_e164", "validate_phone",
+		"is_credit_card", "card_brand", "is_card_expiry", "is_cvv",
+		"is_iban", "is_bic", "is_vat", "is_national_id", "is_postal_code",
+		"is_isbn", "is_ean", "is_upc",
+		"is_country_code", "is_currency_code", "is_language_tag",
+		"is_date", "is_datetime", "date_before", "date_after", "date_between", "min_age", "max_age", "is_duration",
+		"is_weekday", "is_weekend", "is_business_day",
+		"is_timezone", "is_latitude", "is_longitude", "is_coordinates", "is_geohash",
+		"is_hex_color", "is_rgb", "is_hsl",
+		"is_base64", "is_data_uri", "is_jwt", "is_hash", "is_password_hash",
+		"is_json", "is_yaml", "is_xml", "validate_csv",
+		"is_mime_type", "has_allowed_extension", "is_safe_path", "detect_content_type",
+	},
+	"schema": {
+		"schema", "rule", "load_schema", "parse_rules", "validate_fields", "parse_tag", "schema_from_tags",
+		"schema_from_json", "validate_all", "register", "set_locale", "get_locale", "register_messages",
+		"openapi",
+	},
+	"network": {
+		"validate_email_mx",
+	},
+	"jwt": {
+		"verify_jwt",
+	},
+}
+
+// optInGroups are the groups left out of the module unless their option is
+// set.
+var optInGroups = map[string]func(o Options) bool{
+	"network": func(o Options) bool { return o.EnableNetwork },
+	"jwt":     func(o Options) bool { return o.EnableJWTVerification },
+}
+
+// customGroup is the group of validators added with RegisterGoValidator.
+const customGroup = "custom"
+
+// NewLoader returns a loader for a module exposing the validators selected by
+// opts. Loading raises a Lua error if Only names an unknown group or validator.
+func NewLoader(opts Options) lua.LGFunction {
+	return func(L *lua.LState) int {
+		registerSchemaType(L)
+		registerRuleType(L)
+
+		enabled := opts.enabled(L)
+		st := stateOf(L)
+		st.options, st.enabled = opts, enabled
+
+		mod := L.NewTable()
+		for name, fn := range exports {
+			if enabled(name) {
+				mod.RawSetString(name, L.NewFunction(fn))
+			}
+		}
+		for name, build := range moduleTables {
+			if enabled(name) {
+				mod.RawSetString(name, build(L, st))
+			}
+		}
+		if enabled(customGroup) {
+			loadGoValidators(L, mod)
+		}
+		for name, fn := range moduleClosures {
+			if enabled(name) {
+				mod.RawSetString(name, L.NewClosure(fn, mod))
+			}
+		}
+		L.Push(mod)
+		return 1
+	}
+}
+
+// enabled returns a predicate reporting whether a function name, or the
+// custom group, is exposed under the options.
+func (o Options) enabled(L *lua.LState) func(name string) bool {
+	groupOf := map[string]string{customGroup: customGroup}
+	for group, names := range groups {
+		for _, name := range names {
+			groupOf[name] = group
+		}
+	}
+
+	selected := map[string]bool{}
+	for _, entry := range o.Only {
+		_, isGroup := groups[entry]
+		_, isName := groupOf[entry]
+		if !isGroup && !isName {
+			L.RaiseError("validation: unknown group or validator %q", entry)
+		}
+		selected[entry] = true
+	}
+
+	return func(name string) bool {
+		group := groupOf[name]
+		if optIn, ok := optInGroups[group]; ok && !optIn(o) {
+			return false
+		}
+		return len(o.Only) == 0 || selected[group] || selected[name]
+	}
+}
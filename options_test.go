@@ -0,0 +1,100 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestGroupsCoverExports(t *testing.T) {
+	seen := map[string]string{}
+	for group, names := range groups {
+		for _, name := range names {
+			if other, ok := seen[name]; ok {
+				t.Errorf("%s is in groups %s and %s", name, other, group)
+			}
+			seen[name] = group
+		}
+	}
+	for name := range exports {
+		if _, ok := seen[name]; !ok {
+			t.Errorf("%s is not assigned to a group", name)
+		}
+	}
+	for name := range moduleClosures {
+		if _, ok := seen[name]; !ok {
+			t.Errorf("%s is not assigned to a group", name)
+		}
+	}
+	for name := range moduleTables {
+		if _, ok := seen[name]; !ok {
+			t.Errorf("%s is not assigned to a group", name)
+		}
+	}
+	for name := range seen {
+		_, export := exports[name]
+		_, closure := moduleClosures[name]
+		_, table := moduleTables[name]
+		if !export && !closure && !table {
+			t.Errorf("group entry %s is not a module function or table", name)
+		}
+	}
+}
+
+func TestNewLoaderOnly(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", NewLoader(Options{Only: []string{"string", "number", "is_nil"}}))
+
+	script := `
+		local validation = require("validation")
+		return validation.min_length ~= nil, validation.in_range ~= nil, validation.is_nil ~= nil,
+			validation.validate_email == nil, validation.schema == nil, validation.validate_all == nil
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("NewLoader only test failed: %v", err)
+	}
+
+	checks := []string{"min_length exposed", "in_range exposed", "is_nil exposed", "validate_email hidden", "schema hidden", "validate_all hidden"}
+	for i, check := range checks {
+		if !bool(L.Get(i - len(checks)).(lua.LBool)) {
+			t.Errorf("Expected %s", check)
+		}
+	}
+}
+
+func TestNewLoaderHidesCustomValidators(t *testing.T) {
+	if err := RegisterStringValidator("is_loader_test_flag", func(s string) bool { return s == "on" }); err != nil {
+		t.Fatalf("RegisterStringValidator failed: %v", err)
+	}
+
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", NewLoader(Options{Only: []string{"schema"}}))
+
+	err := L.DoString(`
+		local validation = require("validation")
+		assert(validation.is_loader_test_flag == nil, "custom validator should be hidden")
+		validation.schema({flag = {custom="is_loader_test_flag"}})
+	`)
+	if err == nil || !strings.Contains(err.Error(), `unknown validator "is_loader_test_flag"`) {
+		t.Errorf("Expected hidden custom validator to be unknown to schemas, got %v", err)
+	}
+}
+
+func TestNewLoaderUnknownGroup(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", NewLoader(Options{Only: []string{"strings"}}))
+
+	err := L.DoString(`require("validation")`)
+	if err == nil || !strings.Contains(err.Error(), `unknown group or validator "strings"`) {
+		t.Errorf("Expected unknown group error, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package validation
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// PasswordPolicy lists the requirements checked by ValidatePassword. Zero
+// lengths are not checked.
+type PasswordPolicy struct {
+	MinLength     int
+	MaxLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+// validatePassword checks a password against a policy table and reports the
+// requirements it does not meet
+// Usage: validation.validate_password(str, policy) -> boolean, table
+func validatePassword(L *lua.LState) int {
+	str := L.CheckString(1)
+	opts := L.OptTable(2, L.NewTable())
+
+	policy := PasswordPolicy{
+		RequireUpper:  lua.LVAsBool(opts.RawGetString("require_upper")),
+		RequireLower:  lua.LVAsBool(opts.RawGetString("require_lower")),
+		RequireDigit:  lua.LVAsBool(opts.RawGetString("require_digit")),
+		RequireSymbol: lua.LVAsBool(opts.RawGetString("require_symbol")),
+	}
+	if minLen, ok := opts.RawGetString("min_length").(lua.LNumber); ok {
+		policy.MinLength = int(minLen)
+	}
+	if maxLen, ok := opts.RawGetString("max_length").(lua.LNumber); ok {
+		policy.MaxLength = int(maxLen)
+	}
+
+	failures := L.NewTable()
+	for _, failure := range ValidatePassword(str, policy) {
+		failures.Append(lua.LString(failure))
+	}
+
+	L.Push(lua.LBool(failures.Len() == 0))
+	L.Push(failures)
+	return 2
+}
+
+// ValidatePassword returns the names of the policy requirements ("min_length",
+// "max_length", "require_upper", "require_lower", "require_digit",
+// "require_symbol") that str does not meet. Length is counted in characters,
+// and character classes are detected with the unicode package so non-ASCII
+// letters and digits count towards the policy.
+func ValidatePassword(str string, policy PasswordPolicy) []string {
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range str {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	var failures []string
+	length := utf8.RuneCountInString(str)
+	if policy.MinLength > 0 && length < policy.MinLength {
+		failures = append(failures, "min_length")
+	}
+	if policy.MaxLength > 0 && length > policy.MaxLength {
+		failures = append(failures, "max_length")
+	}
+	if policy.RequireUpper && !hasUpper {
+		failures = append(failures, "require_upper")
+	}
+	if policy.RequireLower && !hasLower {
+		failures = append(failures, "require_lower")
+	}
+	if policy.RequireDigit && !hasDigit {
+		failures = append(failures, "require_digit")
+	}
+	if policy.RequireSymbol && !hasSymbol {
+		failures = append(failures, "require_symbol")
+	}
+	return failures
+}
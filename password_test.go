@@ -0,0 +1,88 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestValidatePasswordStrong(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local policy = {min_length=8, require_upper=true, require_lower=true, require_digit=true, require_symbol=true}
+		local ok, failures = validation.validate_password("Str0ng!Pass", policy)
+		return ok, #failures
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("ValidatePassword test failed: %v", err)
+	}
+
+	ok := L.Get(-2).(lua.LBool)
+	count := L.Get(-1).(lua.LNumber)
+
+	if !bool(ok) {
+		t.Error("Expected true for strong password")
+	}
+	if count != 0 {
+		t.Errorf("Expected no failures, got %v", count)
+	}
+}
+
+func TestValidatePasswordWeak(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local policy = {min_length=8, require_upper=true, require_lower=true, require_digit=true, require_symbol=true}
+		local ok, failures = validation.validate_password("weak", policy)
+		return ok, table.concat(failures, ",")
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("ValidatePassword test failed: %v", err)
+	}
+
+	ok := L.Get(-2).(lua.LBool)
+	failures := L.Get(-1).String()
+
+	if bool(ok) {
+		t.Error("Expected false for weak password")
+	}
+	expected := "min_length,require_upper,require_digit,require_symbol"
+	if failures != expected {
+		t.Errorf("Expected failures %q, got %q", expected, failures)
+	}
+}
+
+func TestValidatePasswordUnicode(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		return validation.validate_password("Ärger", {min_length=5, require_upper=true, require_lower=true})
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("ValidatePassword test failed: %v", err)
+	}
+
+	ok := L.Get(-2).(lua.LBool)
+	if !bool(ok) {
+		t.Error("Expected true for 'Ärger' with unicode uppercase and 5 characters")
+	}
+}
@@ -0,0 +1,114 @@
+package validation
+
+import (
+	"regexp"
+	"strconv"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// passwordHashFormat recognizes one password hash encoding. params converts
+// the submatches of pattern into the algorithm name and its parameters, and
+// reports false if a parameter is out of range.
+type passwordHashFormat struct {
+	pattern *regexp.Regexp
+	params  func(m []string) (string, map[string]int, bool)
+}
+
+// passwordHashFormats are the modular crypt and PHC string formats
+// recognized by is_password_hash.
+var passwordHashFormats = []passwordHashFormat{
+	// bcrypt: $2b$12$ followed by a 22-character salt and 31-character hash.
+	{
+		regexp.MustCompile(`^\$(2[abxy]?)\$(\d{2})\$[./A-Za-z0-9]{53}$`),
+		func(m []string) (string, map[string]int, bool) {
+			cost, _ := strconv.Atoi(m[2])
+			return "bcrypt", map[string]int{"cost": cost}, cost >= 4 && cost <= 31
+		},
+	},
+	// Argon2 PHC string: $argon2id$v=19$m=65536,t=3,p=4$salt$hash.
+	{
+		regexp.MustCompile(`^\$(argon2(?:id|i|d))\$(?:v=(\d+)\$)?m=(\d+),t=(\d+),p=(\d+)(?:,[a-z]+=[A-Za-z0-9+/]*)*\$[A-Za-z0-9+/]+\$[A-Za-z0-9+/]+$`),
+		func(m []string) (string, map[string]int, bool) {
+			params := map[string]int{"version": 16}
+			if m[2] != "" {
+				params["version"], _ = strconv.Atoi(m[2])
+			}
+			ok := positiveParams(params, map[string]string{"memory": m[3], "iterations": m[4], "parallelism": m[5]})
+			return m[1], params, ok
+		},
+	},
+	// scrypt PHC string: $scrypt$ln=17,r=8,p=1$salt$hash.
+	{
+		regexp.MustCompile(`^\$scrypt\$ln=(\d+),r=(\d+),p=(\d+)\$[A-Za-z0-9+/.]+\$[A-Za-z0-9+/.]+$`),
+		func(m []string) (string, map[string]int, bool) {
+			params := map[string]int{}
+			ok := positiveParams(params, map[string]string{"ln": m[1], "r": m[2], "p": m[3]})
+			return "scrypt", params, ok && params["ln"] < 64
+		},
+	},
+	// PBKDF2 modular crypt: $pbkdf2-sha256$29000$salt$hash.
+	{
+		regexp.MustCompile(`^\$pbkdf2(-sha256|-sha512)?\$(\d+)\$[./A-Za-z0-9]+\$[./A-Za-z0-9]+$`),
+		func(m []string) (string, map[string]int, bool) {
+			digest := m[1]
+			if digest == "" {
+				digest = "-sha1"
+			}
+			params := map[string]int{}
+			ok := positiveParams(params, map[string]string{"iterations": m[2]})
+			return "pbkdf2" + digest, params, ok
+		},
+	},
+}
+
+// positiveParams parses the decimal values into params and reports whether
+// they are all positive integers.
+func positiveParams(params map[string]int, values map[string]string) bool {
+	ok := true
+	for name, value := range values {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			ok = false
+		}
+		params[name] = n
+	}
+	return ok
+}
+
+// parsePasswordHash identifies the algorithm of an encoded password hash and
+// returns its parameters.
+func parsePasswordHash(s string) (string, map[string]int, bool) {
+	for _, f := range passwordHashFormats {
+		if m := f.pattern.FindStringSubmatch(s); m != nil {
+			return f.params(m)
+		}
+	}
+	return "", nil, false
+}
+
+// IsPasswordHash reports whether s is a bcrypt, Argon2, scrypt or PBKDF2
+// password hash in modular crypt or PHC string format.
+func IsPasswordHash(s string) bool {
+	_, _, ok := parsePasswordHash(s)
+	return ok
+}
+
+// isPasswordHash checks if a string is an encoded password hash and returns its algorithm and parameters
+// Usage: validation.is_password_hash(str) -> boolean, algorithm?, params?
+func isPasswordHash(L *lua.LState) int {
+	algorithm, params, ok := parsePasswordHash(L.CheckString(1))
+	if !ok {
+		L.Push(lua.LBool(false))
+		return 1
+	}
+
+	tbl := L.CreateTable(0, len(params))
+	for name, value := range params {
+		tbl.RawSetString(name, lua.LNumber(value))
+	}
+	L.Push(lua.LBool(true))
+	L.Push(lua.LString(algorithm))
+	L.Push(tbl)
+	return 3
+}
@@ -0,0 +1,61 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsPasswordHash(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		hash      string
+		algorithm string
+		params    map[string]int
+	}{
+		{"$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy", "bcrypt", map[string]int{"cost": 10}},
+		{"$2b$12$R9h/cIPz0gi.URNNX3kh2OPST9/PgBkqquzi.Ss7KIUgO2t0jWMUW", "bcrypt", map[string]int{"cost": 12}},
+		{"$argon2id$v=19$m=65536,t=3,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG", "argon2id", map[string]int{"version": 19, "memory": 65536, "iterations": 3, "parallelism": 4}},
+		{"$argon2i$m=4096,t=3,p=1$c29tZXNhbHQ$iWh06vD8Fy27wf9npn6FXWiCX4K6pW6Ue1Bnzz07Z8A", "argon2i", map[string]int{"version": 16, "memory": 4096, "iterations": 3, "parallelism": 1}},
+		{"$scrypt$ln=16,r=8,p=1$aM15713r3Xsvxbi31lqr1Q$nFNh2CVHVjNldFVKDHDlm4CbdRSCdEBsjjJxD+iCs5E", "scrypt", map[string]int{"ln": 16, "r": 8, "p": 1}},
+		{"$pbkdf2-sha256$29000$N2bMOSfkHCOEcE6pFeI8Jw$SmRHJVxtpnmvN7l5ZEpzGN5Ux2rAklLUM31pGJbSzqM", "pbkdf2-sha256", map[string]int{"iterations": 29000}},
+		{"$pbkdf2$131000$r5J2rlVKCSGEEEKA0Dpn7A$2wQ3HqNYRsAXLeXJeXq7Yn0ZtEc", "pbkdf2-sha1", map[string]int{"iterations": 131000}},
+		{"$2b$03$R9h/cIPz0gi.URNNX3kh2OPST9/PgBkqquzi.Ss7KIUgO2t0jWMUW", "", nil},
+		{"$2b$12$R9h/cIPz0gi.URNNX3kh2OPST9/PgBkqquzi.Ss7KIUgO2t0jWMU", "", nil},
+		{"$argon2id$v=19$m=0,t=3,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG", "", nil},
+		{"$argon2id$v=19$t=3,m=65536,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG", "", nil},
+		{"$pbkdf2-md5$1000$c2FsdA$aGFzaA", "", nil},
+		{"5f4dcc3b5aa765d61d8327deb882cf99", "", nil},
+		{"hunter2", "", nil},
+	}
+
+	for _, tt := range tests {
+		L.SetGlobal("hash", lua.LString(tt.hash))
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.is_password_hash(hash)
+		`); err != nil {
+			t.Fatalf("is_password_hash(%q): %v", tt.hash, err)
+		}
+		valid := tt.algorithm != ""
+		if got := L.Get(1) == lua.LTrue; got != valid {
+			t.Errorf("is_password_hash(%q) = %v, want %v", tt.hash, got, valid)
+		}
+		if valid {
+			if got := L.Get(2); got != lua.LString(tt.algorithm) {
+				t.Errorf("is_password_hash(%q) algorithm = %v, want %s", tt.hash, got, tt.algorithm)
+			}
+			params := L.Get(3).(*lua.LTable)
+			for name, want := range tt.params {
+				if got := params.RawGetString(name); got != lua.LNumber(want) {
+					t.Errorf("is_password_hash(%q) %s = %v, want %d", tt.hash, name, got, want)
+				}
+			}
+		}
+		L.SetTop(0)
+	}
+}
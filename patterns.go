@@ -0,0 +1,61 @@
+package validation
+
+import (
+	"fmt"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// namedPatterns are vetted regexes (RE2 syntax) for common formats. They
+// check the shape of a value only; checksums and calendar dates are not
+// verified.
+var namedPatterns = map[string]string{
+	"uuid":         `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`,
+	"slug":         `^[a-z0-9]+(?:-[a-z0-9]+)*$`,
+	"hex":          `^[0-9a-fA-F]+$`,
+	"hex_color":    `^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`,
+	"alpha":        `^[a-zA-Z]+$`,
+	"alphanumeric": `^[a-zA-Z0-9]+$`,
+	"numeric":      `^[0-9]+$`,
+	"integer":      `^[+-]?[0-9]+$`,
+	"decimal":      `^[+-]?(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+)$`,
+	"isbn10":       `^[0-9]{9}[0-9X]$`,
+	"isbn13":       `^97[89][0-9]{10}$`,
+	"isbn":         `^(?:[0-9]{9}[0-9X]|97[89][0-9]{10})$`,
+	"e164":         `^\+[1-9][0-9]{1,14}$`,
+	"ipv4":         `^(?:(?:25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])\.){3}(?:25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])$`,
+	"mac":          `^(?:[0-9A-Fa-f]{2}[:-]){5}[0-9A-Fa-f]{2}$`,
+	"base64":       `^(?:[A-Za-z0-9+/]{4})*(?:[A-Za-z0-9+/]{2}==|[A-Za-z0-9+/]{3}=)?$`,
+	"semver":       `^(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)(?:-((?:0|[1-9][0-9]*|[0-9]*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9][0-9]*|[0-9]*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`,
+	"date":         `^[0-9]{4}-(?:0[1-9]|1[0-2])-(?:0[1-9]|[12][0-9]|3[01])$`,
+	"time":         `^(?:[01][0-9]|2[0-3]):[0-5][0-9](?::[0-5][0-9])?$`,
+	"us_zip":       `^[0-9]{5}(?:-[0-9]{4})?$`,
+}
+
+// patternsTable builds the validation.patterns table. Each module gets its
+// own copy, so scripts cannot change the patterns used by matches_pattern.
+func patternsTable(L *lua.LState, _ *moduleState) *lua.LTable {
+	tbl := L.CreateTable(0, len(namedPatterns))
+	for name, pattern := range namedPatterns {
+		tbl.RawSetString(name, lua.LString(pattern))
+	}
+	return tbl
+}
+
+// matchesPattern checks a string against a named pattern from validation.patterns
+// Usage: validation.matches_pattern(str, name) -> boolean
+func matchesPattern(L *lua.LState) int {
+	str := L.CheckString(1)
+	name := L.CheckString(2)
+
+	pattern, ok := namedPatterns[name]
+	if !ok {
+		L.ArgError(2, fmt.Sprintf("unknown pattern %q", name))
+	}
+	re, err := patternCache.compile(pattern)
+	if err != nil {
+		L.RaiseError("pattern %s: %v", name, err)
+	}
+	L.Push(lua.LBool(re.MatchString(str)))
+	return 1
+}
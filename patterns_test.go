@@ -0,0 +1,90 @@
+package validation
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestNamedPatternsCompile(t *testing.T) {
+	for name, pattern := range namedPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			t.Errorf("Pattern %s does not compile: %v", name, err)
+		}
+	}
+}
+
+func TestMatchesPattern(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		str      string
+		name     string
+		expected bool
+	}{
+		{"0190a5b4-1c2d-7e3f-8a9b-0c1d2e3f4a5b", "uuid", true},
+		{"0190a5b4-1c2d-7e3f-8a9b", "uuid", false},
+		{"hello-world-2", "slug", true},
+		{"Hello_World", "slug", false},
+		{"#1a2B3c", "hex_color", true},
+		{"+14155552671", "e164", true},
+		{"0014155552671", "e164", false},
+		{"9780306406157", "isbn", true},
+		{"1.2.3-rc.1+build.5", "semver", true},
+		{"1.02.3", "semver", false},
+		{"256.1.1.1", "ipv4", false},
+		{"2024-02-30", "date", true},
+	}
+
+	for _, test := range tests {
+		L.SetGlobal("str", lua.LString(test.str))
+		L.SetGlobal("name", lua.LString(test.name))
+		err := L.DoString(`return require("validation").matches_pattern(str, name)`)
+		if err != nil {
+			t.Fatalf("MatchesPattern test failed: %v", err)
+		}
+		if got := lua.LVAsBool(L.Get(-1)); got != test.expected {
+			t.Errorf("matches_pattern(%q, %q): expected %v, got %v", test.str, test.name, test.expected, got)
+		}
+		L.Pop(1)
+	}
+}
+
+func TestPatternsTable(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local slug = validation.patterns.slug
+		validation.patterns.slug = ".*"
+		return slug, validation.validate_regex("my-post", slug), validation.matches_pattern("Not A Slug", "slug")
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("Patterns test failed: %v", err)
+	}
+
+	if got := L.Get(-3).String(); got != namedPatterns["slug"] {
+		t.Errorf("Expected slug pattern, got %q", got)
+	}
+	if ok := L.Get(-2).(lua.LBool); !bool(ok) {
+		t.Error("Expected pattern from table to work with validate_regex")
+	}
+	if ok := L.Get(-1).(lua.LBool); bool(ok) {
+		t.Error("Expected matches_pattern to ignore changes to the table")
+	}
+
+	err = L.DoString(`require("validation").matches_pattern("x", "nope")`)
+	if err == nil || !strings.Contains(err.Error(), `unknown pattern "nope"`) {
+		t.Errorf("Expected unknown pattern error, got %v", err)
+	}
+}
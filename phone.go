@@ -0,0 +1,212 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// E.164 limits: at most 15 digits including the country code. The shortest
+// national numbers in use have four digits.
+const (
+	maxE164Digits      = 15
+	minNationalDigits  = 4
+	maxCountryCodeSize = 3
+)
+
+// countryCodes holds the country calling codes assigned by the ITU,
+// including shared codes such as +1 and non-geographic ones such as +800.
+// Calling codes are prefix-free, so at most one matches a number.
+var countryCodes = buildCountryCodes(
+	"1 7 20 27 30 31 32 33 34 36 39 40 41 43 44 45 46 47 48 49",
+	"51 52 53 54 55 56 57 58 60 61 62 63 64 65 66 81 82 84 86 90 91 92 93 94 95 98",
+	"211 212 213 216 218 220-229 230-239 240-249 250-258 260-269 290 291 297 298 299",
+	"350-359 370-383 385-387 389 420 421 423 500-509 590-599 670 672-683 685-692",
+	"800 808 850 852 853 855 856 870 878 880-883 886 888",
+	"960-968 970-977 979 992-996 998",
+)
+
+// buildCountryCodes expands lists of codes and inclusive ranges such as
+// "220-229".
+func buildCountryCodes(lists ...string) map[string]bool {
+	codes := map[string]bool{}
+	for _, list := range lists {
+		for _, entry := range strings.Fields(list) {
+			first, last, isRange := strings.Cut(entry, "-")
+			if !isRange {
+				codes[entry] = true
+				continue
+			}
+			from, _ := strconv.Atoi(first)
+			to, _ := strconv.Atoi(last)
+			for code := from; code <= to; code++ {
+				codes[strconv.Itoa(code)] = true
+			}
+		}
+	}
+	return codes
+}
+
+// splitE164 splits a number in E.164 form, "+" followed by digits only, into
+// its country calling code and national number.
+func splitE164(s string) (code, national string, ok bool) {
+	digits, found := strings.CutPrefix(s, "+")
+	if !found || !isDigits(digits) || len(digits) > maxE164Digits {
+		return "", "", false
+	}
+	for size := 1; size <= maxCountryCodeSize && size < len(digits); size++ {
+		if countryCodes[digits[:size]] {
+			code, national = digits[:size], digits[size:]
+			return code, national, len(national) >= minNationalDigits
+		}
+	}
+	return "", "", false
+}
+
+// IsPhoneE164 reports whether s is a phone number in E.164 form: a "+", an
+// assigned country calling code and a national number, at most 15 digits in
+// total and without spaces or punctuation.
+func IsPhoneE164(s string) bool {
+	_, _, ok := splitE164(s)
+	return ok
+}
+
+// isPhoneE164 checks if a string is a phone number in E.164 form
+// Usage: validation.is_phone_e164(str) -> boolean
+func isPhoneE164(L *lua.LState) int {
+	L.Push(lua.LBool(IsPhoneE164(L.CheckString(1))))
+	return 1
+}
+
+// phoneRegion is the numbering plan metadata of a region: its country calling
+// code, the trunk prefix dialled before national numbers, and the shape of
+// its national significant numbers.
+type phoneRegion struct {
+	code    string
+	trunk   string
+	pattern *regexp.Regexp
+}
+
+func newPhoneRegion(code, trunk, pattern string) *phoneRegion {
+	return &phoneRegion{code: code, trunk: trunk, pattern: regexp.MustCompile(anchor(pattern))}
+}
+
+// phoneRegions holds numbering plans of major regions, keyed by ISO 3166-1
+// alpha-2 code. Patterns cover fixed and mobile numbers without checking
+// individual area codes.
+var phoneRegions = map[string]*phoneRegion{
+	"AE": newPhoneRegion("971", "0", `5[024568]\d{7}|[2-4679]\d{7}`),
+	"AU": newPhoneRegion("61", "0", `[2-478]\d{8}`),
+	"AZ": newPhoneRegion("994", "0", `[1-9]\d{8}`),
+	"BR": newPhoneRegion("55", "0", `[1-9]{2}9?\d{8}`),
+	"CA": newPhoneRegion("1", "1", `[2-9]\d{2}[2-9]\d{6}`),
+	"CH": newPhoneRegion("41", "0", `[1-9]\d{8}`),
+	"CN": newPhoneRegion("86", "0", `1[3-9]\d{9}|[2-9]\d{8,10}`),
+	"DE": newPhoneRegion("49", "0", `[1-9]\d{5,12}`),
+	"EG": newPhoneRegion("20", "0", `1[0-25]\d{8}|[2-9]\d{7,8}`),
+	"ES": newPhoneRegion("34", "", `[5-9]\d{8}`),
+	"FR": newPhoneRegion("33", "0", `[1-9]\d{8}`),
+	"GB": newPhoneRegion("44", "0", `[1-9]\d{8,9}`),
+	"IN": newPhoneRegion("91", "0", `[1-9]\d{9}`),
+	"IT": newPhoneRegion("39", "", `0\d{5,10}|3\d{8,9}`),
+	"JP": newPhoneRegion("81", "0", `[1-9]\d{8,9}`),
+	"KR": newPhoneRegion("82", "0", `[1-9]\d{7,9}`),
+	"KZ": newPhoneRegion("7", "8", `[67]\d{9}`),
+	"MX": newPhoneRegion("52", "", `[1-9]\d{9}`),
+	"NG": newPhoneRegion("234", "0", `[1-9]\d{7,9}`),
+	"NL": newPhoneRegion("31", "0", `[1-9]\d{8}`),
+	"PL": newPhoneRegion("48", "", `[1-9]\d{8}`),
+	"RU": newPhoneRegion("7", "8", `[3489]\d{9}`),
+	"SA": newPhoneRegion("966", "0", `5\d{8}|1\d{7,8}`),
+	"SE": newPhoneRegion("46", "0", `[1-9]\d{6,8}`),
+	"SG": newPhoneRegion("65", "", `[3689]\d{7}`),
+	"TR": newPhoneRegion("90", "0", `[2-58]\d{9}`),
+	"US": newPhoneRegion("1", "1", `[2-9]\d{2}[2-9]\d{6}`),
+	"ZA": newPhoneRegion("27", "0", `[1-8]\d{8}`),
+}
+
+// phoneCodeRegions maps calling codes to the numbering plans above. Regions
+// sharing a code, such as RU and KZ under +7, are all listed; a number with
+// that code is valid if it fits any of their plans.
+var phoneCodeRegions = func() map[string][]*phoneRegion {
+	byCode := map[string][]*phoneRegion{}
+	for _, r := range phoneRegions {
+		byCode[r.code] = append(byCode[r.code], r)
+	}
+	return byCode
+}()
+
+// phoneSeparators are the characters removed from a phone number before it
+// is checked.
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "", "/", "")
+
+// PhoneToE164 validates a phone number written in international form or, for
+// a supported region given as an ISO 3166-1 alpha-2 code, in national form
+// such as "050 123 4567" in "AE". It returns the number in E.164 form.
+// Spaces, hyphens, dots, slashes and parentheses are ignored. With an empty
+// region, only international numbers are accepted; numbers whose calling
+// code belongs to supported regions are then checked against their
+// numbering plans, any of which may match when the code is shared.
+func PhoneToE164(s, region string) (string, bool) {
+	plan, known := phoneRegions[strings.ToUpper(region)]
+	if region != "" && !known {
+		return "", false
+	}
+
+	s = phoneSeparators.Replace(s)
+	var national string
+	if strings.HasPrefix(s, "+") {
+		code, rest, ok := splitE164(s)
+		if !ok || plan != nil && code != plan.code {
+			return "", false
+		}
+		if plan == nil {
+			plans := phoneCodeRegions[code]
+			if len(plans) == 0 {
+				return s, true
+			}
+			for _, p := range plans {
+				if p.pattern.MatchString(rest) {
+					return s, true
+				}
+			}
+			return "", false
+		}
+		national = rest
+	} else {
+		if plan == nil || !isDigits(s) {
+			return "", false
+		}
+		national = s
+		if !plan.pattern.MatchString(national) && plan.trunk != "" {
+			national = strings.TrimPrefix(s, plan.trunk)
+		}
+	}
+
+	if !plan.pattern.MatchString(national) {
+		return "", false
+	}
+	return "+" + plan.code + national, true
+}
+
+// validatePhone validates a phone number in international or national form
+// Usage: validation.validate_phone(str, region?) -> boolean, e164?
+func validatePhone(L *lua.LState) int {
+	str := L.CheckString(1)
+	region := L.OptString(2, "")
+	if _, ok := phoneRegions[strings.ToUpper(region)]; region != "" && !ok {
+		L.ArgError(2, fmt.Sprintf("unsupported region %q", region))
+	}
+
+	e164, ok := PhoneToE164(str, region)
+	if !ok {
+		L.Push(lua.LBool(false))
+		return 1
+	}
+	L.Push(lua.LBool(true))
+	L.Push(lua.LString(e164))
+	return 2
+}
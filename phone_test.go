@@ -0,0 +1,113 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsPhoneE164(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		number   string
+		expected bool
+	}{
+		{"+14155552671", true},
+		{"+442071838750", true},
+		{"+971501234567", true},
+		{"+6834002", true},
+		{"+80012345678", true},
+		{"14155552671", false},
+		{"+1 415 555 2671", false},
+		{"+1-415-555-2671", false},
+		{"+0123456789", false},
+		{"+2591234567", false},
+		{"+384123456", false},
+		{"+1234567890123456", false},
+		{"+44123", false},
+		{"+", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			err := L.DoString(`return require("validation").is_phone_e164("` + tt.number + `")`)
+			if err != nil {
+				t.Fatalf("is_phone_e164 failed: %v", err)
+			}
+			result := L.Get(-1).(lua.LBool)
+			L.Pop(1)
+			if bool(result) != tt.expected {
+				t.Errorf("Expected %v for %s, got %v", tt.expected, tt.number, result)
+			}
+		})
+	}
+}
+
+func TestValidatePhone(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		number   string
+		region   string
+		expected string
+	}{
+		{"0501234567", "AE", "+971501234567"},
+		{"050 123 4567", "ae", "+971501234567"},
+		{"+971 50 123 4567", "AE", "+971501234567"},
+		{"0511234567", "AE", ""},
+		{"(415) 555-2671", "US", "+14155552671"},
+		{"1-415-555-2671", "US", "+14155552671"},
+		{"(415) 155-2671", "US", ""},
+		{"020 7183 8750", "GB", "+442071838750"},
+		{"+44 20 7183 8750", "US", ""},
+		{"8 912 345 67 89", "RU", "+79123456789"},
+		{"8 701 123 45 67", "KZ", "+77011234567"},
+		{"+7 701 123 45 67", "RU", ""},
+		{"+77011234567", "", "+77011234567"},
+		{"+79123456789", "", "+79123456789"},
+		{"+71123456789", "", ""},
+		{"612 345 678", "ES", "+34612345678"},
+		{"050 123 4567", "", ""},
+		{"+971501234567", "", "+971501234567"},
+		{"+971111", "", ""},
+		{"+2301234567", "", "+2301234567"},
+		{"050-CALL-NOW", "AE", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number+" "+tt.region, func(t *testing.T) {
+			err := L.DoString(`
+				local ok, e164 = require("validation").validate_phone("` + tt.number + `", "` + tt.region + `")
+				return ok, e164
+			`)
+			if err != nil {
+				t.Fatalf("validate_phone failed: %v", err)
+			}
+			ok := bool(L.Get(-2).(lua.LBool))
+			e164 := L.Get(-1)
+			L.Pop(2)
+			if tt.expected == "" {
+				if ok {
+					t.Errorf("Expected %s in %q to be invalid", tt.number, tt.region)
+				}
+				return
+			}
+			if !ok || e164.String() != tt.expected {
+				t.Errorf("Expected %s for %s in %q, got %v, %v", tt.expected, tt.number, tt.region, ok, e164)
+			}
+		})
+	}
+
+	err := L.DoString(`require("validation").validate_phone("0501234567", "XX")`)
+	if err == nil || !strings.Contains(err.Error(), `unsupported region "XX"`) {
+		t.Errorf("Expected unsupported region error, got %v", err)
+	}
+}
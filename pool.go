@@ -0,0 +1,109 @@
+package validation
+
+import (
+	"fmt"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// poolScriptCacheSize bounds the number of compiled scripts kept by a Pool.
+const poolScriptCacheSize = 256
+
+// Pool is a fixed set of Lua states with the validation module preloaded,
+// shared by concurrent callers of Validate.
+type Pool struct {
+	states  chan *lua.LState
+	scripts *compileCache[*lua.FunctionProto]
+	err     error
+}
+
+// NewPool creates a pool of n Lua states (at least one) whose validation
+// module is configured by opts. If the module cannot be loaded, for example
+// because opts.Only names an unknown group, Validate reports the error.
+func NewPool(n int, opts Options) *Pool {
+	if n < 1 {
+		n = 1
+	}
+	p := &Pool{
+		states: make(chan *lua.LState, n),
+		scripts: newCompileCache(poolScriptCacheSize, func(script string) (*lua.FunctionProto, error) {
+			return compileSource(script, "<validation>")
+		}),
+	}
+	for i := 0; i < n; i++ {
+		L := lua.NewState()
+		L.PreloadModule("validation", NewLoader(opts))
+		if err := loadPooledModule(L); err != nil && p.err == nil {
+			p.err = err
+		}
+		p.states <- L
+	}
+	return p
+}
+
+// loadPooledModule loads the validation module into L and records its
+// entries so resetState can undo changes scripts make to it.
+func loadPooledModule(L *lua.LState) error {
+	if err := L.CallByParam(lua.P{Fn: L.GetGlobal("require"), NRet: 1, Protect: true}, lua.LString("validation")); err != nil {
+		return err
+	}
+	mod, ok := L.Get(-1).(*lua.LTable)
+	L.Pop(1)
+	if !ok {
+		return fmt.Errorf("validation: module is not a table")
+	}
+	st := stateOf(L)
+	st.module, st.snapshot = mod, takeSnapshot(mod)
+	return nil
+}
+
+// Validate runs a validation script with payload, converted to a Lua value,
+// as its argument (...) on a free state, waiting for one if all are busy.
+// The script returns whether the payload is valid and, if not, a table of
+// field errors or a message, which is returned under "body":
+//
+//	local payload = ...
+//	return require("validation").validate_fields(payload, {email = "required|email"})
+//
+// Validate returns nil errors for valid payloads. Recently used scripts are
+// compiled once and cached by source.
+//
+// Each call is isolated from the others: globals set by the script are
+// discarded, and the validation module is restored afterwards, so changes
+// made with set_locale, register, register_messages or openapi.load, and
+// writes to the module table and its subtables, do not carry over. Changes to the standard
+// library tables, such as string, are not undone.
+func (p *Pool) Validate(script string, payload any) (map[string]string, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	proto, err := p.scripts.compile(script)
+	if err != nil {
+		return nil, err
+	}
+
+	L := <-p.states
+	defer func() {
+		resetState(L)
+		p.states <- L
+	}()
+
+	// Globals written by the script go to a table of its own that falls
+	// back to the shared globals for reads.
+	env := L.NewTable()
+	meta := L.NewTable()
+	meta.RawSetString("__index", L.Get(lua.GlobalsIndex))
+	L.SetMetatable(env, meta)
+
+	fn := L.NewFunctionFromProto(proto)
+	fn.Env = env
+	return runValidation(L, fn, toLua(L, payload))
+}
+
+// Close closes every state of the pool. It waits for states in use to be
+// returned and must not be called concurrently with Validate.
+func (p *Pool) Close() {
+	for i := 0; i < cap(p.states); i++ {
+		(<-p.states).Close()
+	}
+}
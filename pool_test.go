@@ -0,0 +1,112 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestPoolValidate(t *testing.T) {
+	pool := NewPool(4, Options{})
+	defer pool.Close()
+
+	script := `
+		local payload = ...
+		return require("validation").validate_fields(payload, {
+			email = "required|email",
+			age = "integer|min:18",
+		})
+	`
+
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			payload := map[string]any{"email": fmt.Sprintf("user%d@example.com", i), "age": 18 + i}
+			errs, err := pool.Validate(script, payload)
+			if err != nil || errs != nil {
+				t.Errorf("Payload %d: expected valid, got %v, %v", i, errs, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	errs, err := pool.Validate(script, map[string]any{"age": 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs["email"] != "email is required" || errs["age"] != "age must be at least 18" {
+		t.Errorf("Unexpected errors: %v", errs)
+	}
+}
+
+func TestPoolValidateErrors(t *testing.T) {
+	pool := NewPool(1, Options{Only: []string{"core"}})
+	defer pool.Close()
+
+	if _, err := pool.Validate(`return (`, nil); err == nil {
+		t.Error("Expected syntax error")
+	}
+	if _, err := pool.Validate(`return require("validation").validate_fields({}, {})`, nil); err == nil {
+		t.Error("Expected error for function outside the allowed groups")
+	}
+
+	errs, err := pool.Validate(`return require("validation").is_present(...), "payload is required"`, "")
+	if err != nil || errs["body"] != "payload is required" {
+		t.Errorf("Expected message for body, got %v, %v", errs, err)
+	}
+
+	broken := NewPool(1, Options{Only: []string{"strings"}})
+	defer broken.Close()
+	if _, err := broken.Validate(`return true`, nil); err == nil || !strings.Contains(err.Error(), `unknown group or validator "strings"`) {
+		t.Errorf("Expected load error, got %v", err)
+	}
+}
+
+func TestPoolValidateIsolation(t *testing.T) {
+	pool := NewPool(1, Options{})
+	defer pool.Close()
+
+	_, err := pool.Validate(`
+		local validation = require("validation")
+		validation.set_locale("de")
+		validation.register("is_sku", function(v) return true end)
+		validation.validate_email = nil
+		validation.patterns.slug = ".*"
+		leaked = true
+		return true
+	`, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs, err := pool.Validate(`
+		local validation = require("validation")
+		if leaked ~= nil then return false, "global leaked" end
+		if validation.get_locale() ~= "en" then return false, "locale leaked" end
+		if validation.validate_email == nil then return false, "module table change leaked" end
+		if validation.patterns.slug == ".*" then return false, "patterns change leaked" end
+		local ok = pcall(validation.parse_rules("is_sku").compile, validation.parse_rules("is_sku"))
+		if ok then return false, "registered validator leaked" end
+		return true
+	`, nil)
+	if err != nil || errs != nil {
+		t.Errorf("Expected a fresh module, got %v, %v", errs, err)
+	}
+}
+
+func TestPoolScriptCacheBounded(t *testing.T) {
+	pool := NewPool(1, Options{})
+	defer pool.Close()
+
+	for i := 0; i < poolScriptCacheSize+10; i++ {
+		if _, err := pool.Validate(fmt.Sprintf("return %d > 0", i+1), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := pool.scripts.order.Len(); n != poolScriptCacheSize {
+		t.Errorf("Expected %d cached scripts, got %d", poolScriptCacheSize, n)
+	}
+}
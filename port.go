@@ -0,0 +1,61 @@
+package validation
+
+import (
+	"strconv"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// maxPort is the highest TCP/UDP port number.
+const maxPort = 65535
+
+// firstUnprivilegedPort is the lowest port that does not need elevated
+// privileges to bind on most systems.
+const firstUnprivilegedPort = 1024
+
+// IsPort reports whether s is a decimal port number from 1 to 65535.
+func IsPort(s string) bool {
+	port, ok := parsePort(lua.LString(s))
+	return ok && port >= 1
+}
+
+// parsePort reads a port from an integer number or a string of digits.
+func parsePort(value lua.LValue) (int, bool) {
+	switch v := value.(type) {
+	case lua.LNumber:
+		n := float64(v)
+		if n != float64(int(n)) || n < 0 || n > maxPort {
+			return 0, false
+		}
+		return int(n), true
+	case lua.LString:
+		if !isDigits(string(v)) || len(v) > 5 {
+			return 0, false
+		}
+		n, err := strconv.Atoi(string(v))
+		if err != nil || n > maxPort {
+			return 0, false
+		}
+		return n, true
+	}
+	return 0, false
+}
+
+// isPort validates a TCP/UDP port given as a number or numeric string
+// Usage: validation.is_port(value, {allow_zero=false, unprivileged=false}) -> boolean
+func isPort(L *lua.LState) int {
+	value := L.CheckAny(1)
+	opts := L.OptTable(2, L.NewTable())
+
+	min := 1
+	if lua.LVAsBool(opts.RawGetString("allow_zero")) {
+		min = 0
+	}
+	if lua.LVAsBool(opts.RawGetString("unprivileged")) {
+		min = firstUnprivilegedPort
+	}
+
+	port, ok := parsePort(value)
+	L.Push(lua.LBool(ok && port >= min))
+	return 1
+}
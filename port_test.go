@@ -0,0 +1,45 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsPort(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		args     string
+		expected bool
+	}{
+		{`8080`, true},
+		{`"443"`, true},
+		{`65535`, true},
+		{`65536`, false},
+		{`0`, false},
+		{`0, {allow_zero = true}`, true},
+		{`"0", {allow_zero = true}`, true},
+		{`80.5`, false},
+		{`-1`, false},
+		{`"+80"`, false},
+		{`" 80"`, false},
+		{`"http"`, false},
+		{`true`, false},
+		{`80, {unprivileged = true}`, false},
+		{`1024, {unprivileged = true}`, true},
+	}
+
+	for _, test := range tests {
+		if err := L.DoString(`return require("validation").is_port(` + test.args + `)`); err != nil {
+			t.Fatalf("is_port(%s) failed: %v", test.args, err)
+		}
+		if got := lua.LVAsBool(L.Get(-1)); got != test.expected {
+			t.Errorf("is_port(%s): expected %v, got %v", test.args, test.expected, got)
+		}
+		L.Pop(1)
+	}
+}
@@ -0,0 +1,109 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// postalCodePatterns holds the postal code formats of countries, keyed by
+// ISO 3166-1 alpha-2 code. Codes are upper-cased before matching.
+var postalCodePatterns = map[string]string{
+	"AT": `[1-9]\d{3}`,
+	"AU": `\d{4}`,
+	"AZ": `AZ ?\d{4}`,
+	"BE": `[1-9]\d{3}`,
+	"BR": `\d{5}-?\d{3}`,
+	"CA": `[ABCEGHJ-NPRSTVXY]\d[ABCEGHJ-NPRSTV-Z] ?\d[ABCEGHJ-NPRSTV-Z]\d`,
+	"CH": `[1-9]\d{3}`,
+	"CN": `\d{6}`,
+	"DE": `\d{5}`,
+	"DK": `\d{4}`,
+	"ES": `(?:0[1-9]|[1-4]\d|5[0-2])\d{3}`,
+	"FI": `\d{5}`,
+	"FR": `\d{5}`,
+	"GB": `GIR ?0AA|[A-PR-UWYZ](?:\d{1,2}|[A-HK-Y]\d{1,2}|\d[A-HJKPSTUW]|[A-HK-Y]\d[ABEHMNPRVWXY]) ?\d[ABD-HJLNP-UW-Z]{2}`,
+	"IE": `(?:[AC-FHKNPRTV-Y]\d{2}|D6W) ?[0-9AC-FHKNPRTV-Y]{4}`,
+	"IN": `[1-9]\d{5}`,
+	"IT": `\d{5}`,
+	"JP": `\d{3}-?\d{4}`,
+	"KR": `\d{5}`,
+	"MX": `\d{5}`,
+	"NL": `[1-9]\d{3} ?[A-Z]{2}`,
+	"NO": `\d{4}`,
+	"PL": `\d{2}-\d{3}`,
+	"PT": `\d{4}-\d{3}`,
+	"RU": `\d{6}`,
+	"SE": `\d{3} ?\d{2}`,
+	"SG": `\d{6}`,
+	"TR": `\d{5}`,
+	"US": `\d{5}(?:-\d{4})?`,
+	"ZA": `\d{4}`,
+}
+
+var (
+	postalCodesMu sync.RWMutex
+	postalCodes   = func() map[string]*regexp.Regexp {
+		compiled := map[string]*regexp.Regexp{}
+		for country, pattern := range postalCodePatterns {
+			compiled[country] = regexp.MustCompile(anchor(pattern))
+		}
+		return compiled
+	}()
+)
+
+// anchor wraps a pattern so that it must match the whole string.
+func anchor(pattern string) string {
+	return "^(?:" + pattern + ")$"
+}
+
+// RegisterPostalCode adds or replaces the postal code format of a country,
+// given as an ISO 3166-1 alpha-2 code, for is_postal_code in every Lua
+// state. The pattern uses Go regexp syntax, must match the whole code and
+// is applied to the upper-cased input.
+func RegisterPostalCode(country, pattern string) error {
+	if len(country) != 2 {
+		return fmt.Errorf("country %q must be an ISO 3166-1 alpha-2 code", country)
+	}
+	re, err := regexp.Compile(anchor(pattern))
+	if err != nil {
+		return fmt.Errorf("country %q: %w", country, err)
+	}
+
+	postalCodesMu.Lock()
+	defer postalCodesMu.Unlock()
+	postalCodes[strings.ToUpper(country)] = re
+	return nil
+}
+
+// postalCodePattern returns the format registered for a country.
+func postalCodePattern(country string) (*regexp.Regexp, bool) {
+	postalCodesMu.RLock()
+	defer postalCodesMu.RUnlock()
+	re, ok := postalCodes[strings.ToUpper(country)]
+	return re, ok
+}
+
+// IsPostalCode reports whether s is a postal code in the format of country,
+// given as an ISO 3166-1 alpha-2 code. Countries without a registered format
+// fail.
+func IsPostalCode(s, country string) bool {
+	re, ok := postalCodePattern(country)
+	return ok && re.MatchString(strings.ToUpper(s))
+}
+
+// isPostalCode checks if a string is a postal code of a country
+// Usage: validation.is_postal_code(str, country) -> boolean
+func isPostalCode(L *lua.LState) int {
+	str := L.CheckString(1)
+	country := L.CheckString(2)
+	re, ok := postalCodePattern(country)
+	if !ok {
+		L.ArgError(2, fmt.Sprintf("unsupported postal code country %q", country))
+	}
+	L.Push(lua.LBool(re.MatchString(strings.ToUpper(str))))
+	return 1
+}
@@ -0,0 +1,71 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsPostalCode(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		args     string
+		expected bool
+	}{
+		{`"94105", "US"`, true},
+		{`"94105-1234", "us"`, true},
+		{`"9410", "US"`, false},
+		{`"94105-12", "US"`, false},
+		{`"SW1A 1AA", "GB"`, true},
+		{`"ec1a1bb", "GB"`, true},
+		{`"M1 1AE", "GB"`, true},
+		{`"QA1 1AA", "GB"`, false},
+		{`"K1A 0B1", "CA"`, true},
+		{`"D1A 0B1", "CA"`, false},
+		{`"10115", "DE"`, true},
+		{`"1011", "DE"`, false},
+		{`"100-0001", "JP"`, true},
+		{`"1000001", "JP"`, true},
+		{`"1012 AB", "NL"`, true},
+		{`"0123 AB", "NL"`, false},
+		{`"AZ1000", "AZ"`, true},
+		{`"D6W 1234", "IE"`, true},
+		{`"53000", "ES"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			err := L.DoString(`return require("validation").is_postal_code(` + tt.args + `)`)
+			if err != nil {
+				t.Fatalf("is_postal_code failed: %v", err)
+			}
+			result := L.Get(-1).(lua.LBool)
+			L.Pop(1)
+			if bool(result) != tt.expected {
+				t.Errorf("Expected %v for %s, got %v", tt.expected, tt.args, result)
+			}
+		})
+	}
+
+	err := L.DoString(`require("validation").is_postal_code("12345", "AE")`)
+	if err == nil || !strings.Contains(err.Error(), `unsupported postal code country "AE"`) {
+		t.Errorf("Expected unsupported country error, got %v", err)
+	}
+}
+
+func TestRegisterPostalCode(t *testing.T) {
+	if err := RegisterPostalCode("XC", `XC-\d{3}`); err != nil {
+		t.Fatalf("RegisterPostalCode failed: %v", err)
+	}
+	if err := RegisterPostalCode("XD", `(`); err == nil {
+		t.Error("Expected an error for an invalid pattern")
+	}
+	if !IsPostalCode("xc-123", "xc") || IsPostalCode("XC-1234", "XC") {
+		t.Error("Expected the registered format to match whole codes")
+	}
+}
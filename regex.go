@@ -0,0 +1,206 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// regexCacheSize bounds the number of compiled patterns kept by
+// patternCache.
+const regexCacheSize = 256
+
+// patternCache keeps recently used compiled patterns so validators called
+// repeatedly with the same pattern compile it only once. Compiled regexps
+// are safe for concurrent use, so the cache is shared by all Lua states.
+var patternCache = newRegexCache(regexCacheSize)
+
+func newRegexCache(size int) *compileCache[*regexp.Regexp] {
+	return newCompileCache(size, regexp.Compile)
+}
+
+// RegexLimits bounds the work regex and Lua pattern validators do for
+// untrusted scripts. Zero values disable a limit.
+type RegexLimits struct {
+	// MaxPatternLength is the longest pattern, in bytes, that is compiled.
+	MaxPatternLength int
+
+	// MaxSubjectLength is the longest string, in bytes, that is matched.
+	MaxSubjectLength int
+
+	// Timeout bounds how long a validator waits for a match. It does not
+	// bound CPU use: matches cannot be interrupted, so an abandoned match
+	// keeps running in the background until it completes. Use
+	// MaxSubjectLength to bound the work of each match.
+	Timeout time.Duration
+}
+
+// regexLimitsOf returns the limits of the module loaded into L. Schemas
+// compiled without a Lua state are not limited.
+func regexLimitsOf(L *lua.LState) RegexLimits {
+	if L == nil {
+		return RegexLimits{}
+	}
+	return stateOf(L).options.RegexLimits
+}
+
+// compile compiles a pattern within the limits.
+func (l RegexLimits) compile(pattern string) (*regexp.Regexp, error) {
+	if err := l.checkPattern(pattern); err != nil {
+		return nil, err
+	}
+	return patternCache.compile(pattern)
+}
+
+// checkPattern applies the pattern length limit.
+func (l RegexLimits) checkPattern(pattern string) error {
+	if l.MaxPatternLength > 0 && len(pattern) > l.MaxPatternLength {
+		return fmt.Errorf("pattern exceeds the maximum length of %d bytes", l.MaxPatternLength)
+	}
+	return nil
+}
+
+// match reports whether str matches re within the limits.
+func (l RegexLimits) match(re *regexp.Regexp, str string) (bool, error) {
+	return l.run(str, func() (bool, error) {
+		return re.MatchString(str), nil
+	})
+}
+
+// run applies the subject length limit and timeout to a match of str.
+func (l RegexLimits) run(str string, match func() (bool, error)) (bool, error) {
+	if l.MaxSubjectLength > 0 && len(str) > l.MaxSubjectLength {
+		return false, fmt.Errorf("string exceeds the maximum length of %d bytes", l.MaxSubjectLength)
+	}
+	if l.Timeout <= 0 {
+		return match()
+	}
+
+	type result struct {
+		matched bool
+		err     error
+	}
+	done := make(chan result, 1)
+	go func() {
+		matched, err := match()
+		done <- result{matched, err}
+	}()
+	timer := time.NewTimer(l.Timeout)
+	defer timer.Stop()
+	select {
+	case r := <-done:
+		return r.matched, r.err
+	case <-timer.C:
+		return false, fmt.Errorf("match timed out after %v", l.Timeout)
+	}
+}
+
+// compilePatterns compiles every pattern in an array table of pattern strings.
+// The returned error identifies the 1-based position of the failing pattern.
+func compilePatterns(L *lua.LState, n int) ([]*regexp.Regexp, error) {
+	limits := stateOf(L).options.RegexLimits
+	tbl := L.CheckTable(n)
+	patterns := make([]*regexp.Regexp, 0, tbl.Len())
+	for i := 1; i <= tbl.Len(); i++ {
+		pattern, ok := tbl.RawGetInt(i).(lua.LString)
+		if !ok {
+			L.ArgError(n, fmt.Sprintf("pattern %d must be a string", i))
+		}
+		re, err := limits.compile(string(pattern))
+		if err != nil {
+			return nil, fmt.Errorf("pattern %d: %v", i, err)
+		}
+		patterns = append(patterns, re)
+	}
+	return patterns, nil
+}
+
+// matchesAll checks if a string matches every pattern in a table
+// Usage: validation.matches_all(str, patterns) -> boolean, error?
+func matchesAll(L *lua.LState) int {
+	return matchPatterns(L, true)
+}
+
+// matchesAny checks if a string matches at least one pattern in a table
+// Usage: validation.matches_any(str, patterns) -> boolean, error?
+func matchesAny(L *lua.LState) int {
+	return matchPatterns(L, false)
+}
+
+// matchPatterns implements matches_all (all) and matches_any (!all).
+func matchPatterns(L *lua.LState, all bool) int {
+	str := L.CheckString(1)
+
+	patterns, err := compilePatterns(L, 2)
+	if err != nil {
+		L.Push(lua.LBool(false))
+		L.Push(lua.LString(err.Error()))
+		return 2
+	}
+
+	limits := stateOf(L).options.RegexLimits
+	for _, re := range patterns {
+		matched, err := limits.match(re, str)
+		if err != nil {
+			L.Push(lua.LBool(false))
+			L.Push(lua.LString(err.Error()))
+			return 2
+		}
+		if matched != all {
+			L.Push(lua.LBool(matched))
+			return 1
+		}
+	}
+	L.Push(lua.LBool(all))
+	return 1
+}
+
+// match returns the captures of the first match of a regex pattern: the
+// whole match at index 0, groups at 1..n and named groups also by name.
+// Groups that did not participate in the match are nil.
+// Usage: validation.match(str, pattern) -> table|nil, error?
+func match(L *lua.LState) int {
+	str := L.CheckString(1)
+	pattern := L.CheckString(2)
+
+	limits := stateOf(L).options.RegexLimits
+	re, err := limits.compile(pattern)
+	if err != nil {
+		L.Push(lua.LNil)
+		L.Push(lua.LString(err.Error()))
+		return 2
+	}
+
+	var indexes []int
+	_, err = limits.run(str, func() (bool, error) {
+		indexes = re.FindStringSubmatchIndex(str)
+		return indexes != nil, nil
+	})
+	if err != nil {
+		L.Push(lua.LNil)
+		L.Push(lua.LString(err.Error()))
+		return 2
+	}
+	if indexes == nil {
+		L.Push(lua.LNil)
+		return 1
+	}
+
+	captures := L.NewTable()
+	names := re.SubexpNames()
+	for i := 0; i < len(indexes)/2; i++ {
+		start, end := indexes[2*i], indexes[2*i+1]
+		if start < 0 {
+			continue
+		}
+		value := lua.LString(str[start:end])
+		captures.RawSetInt(i, value)
+		if names[i] != "" {
+			captures.RawSetString(names[i], value)
+		}
+	}
+	L.Push(captures)
+	return 1
+}
@@ -0,0 +1,240 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestMatchesAll(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local patterns = {"[0-9]", "[A-Z]"}
+		return validation.matches_all("Passw0rd", patterns), validation.matches_all("password", patterns)
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("MatchesAll test failed: %v", err)
+	}
+
+	result1 := L.Get(-2).(lua.LBool)
+	result2 := L.Get(-1).(lua.LBool)
+
+	if !bool(result1) {
+		t.Error("Expected true for 'Passw0rd' matching digit and uppercase patterns")
+	}
+	if bool(result2) {
+		t.Error("Expected false for 'password' matching digit and uppercase patterns")
+	}
+}
+
+func TestMatchesAny(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local patterns = {"^[0-9]+$", "^[a-z]+$"}
+		return validation.matches_any("abc", patterns), validation.matches_any("ABC", patterns)
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("MatchesAny test failed: %v", err)
+	}
+
+	result1 := L.Get(-2).(lua.LBool)
+	result2 := L.Get(-1).(lua.LBool)
+
+	if !bool(result1) {
+		t.Error("Expected true for 'abc' matching a lowercase pattern")
+	}
+	if bool(result2) {
+		t.Error("Expected false for 'ABC' matching neither pattern")
+	}
+}
+
+func TestMatchesAllInvalidPattern(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		return validation.matches_all("test", {"^t", "[invalid"})
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("MatchesAll invalid pattern test failed: %v", err)
+	}
+
+	result := L.Get(-2).(lua.LBool)
+	errVal := L.Get(-1)
+
+	if bool(result) {
+		t.Error("Expected false for invalid pattern")
+	}
+	if !strings.HasPrefix(errVal.String(), "pattern 2:") {
+		t.Errorf("Expected error identifying pattern 2, got %v", errVal)
+	}
+}
+
+func TestRegexCache(t *testing.T) {
+	cache := newRegexCache(2)
+
+	first, err := cache.compile("^a+$")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := cache.compile("^a+$"); again != first {
+		t.Error("Expected cached regexp to be reused")
+	}
+
+	cache.compile("^b+$")
+	cache.compile("^a+$")
+	cache.compile("^c+$")
+	if _, ok := cache.entries["^b+$"]; ok {
+		t.Error("Expected least recently used pattern to be evicted")
+	}
+	if _, ok := cache.entries["^a+$"]; !ok {
+		t.Error("Expected recently used pattern to be kept")
+	}
+
+	if _, err := cache.compile("("); err == nil {
+		t.Error("Expected error for invalid pattern")
+	}
+	if cache.order.Len() != 2 {
+		t.Errorf("Expected 2 cached patterns, got %d", cache.order.Len())
+	}
+}
+
+func TestRegexLimits(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", NewLoader(Options{RegexLimits: RegexLimits{
+		MaxPatternLength: 16,
+		MaxSubjectLength: 8,
+	}}))
+
+	script := `
+		local validation = require("validation")
+		local _, pattern_err = validation.validate_regex("abc", string.rep("a", 17))
+		local _, subject_err = validation.matches_all("abcdefghi", {"^a"})
+		return validation.validate_regex("abc", "^a"), pattern_err, subject_err
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("RegexLimits test failed: %v", err)
+	}
+
+	if ok := L.Get(-3).(lua.LBool); !bool(ok) {
+		t.Error("Expected short pattern and subject to match")
+	}
+	expected := []string{
+		"pattern exceeds the maximum length of 16 bytes",
+		"string exceeds the maximum length of 8 bytes",
+	}
+	for i, message := range expected {
+		if got := L.Get(i - len(expected)).String(); got != message {
+			t.Errorf("Expected %q, got %q", message, got)
+		}
+	}
+}
+
+func TestRegexTimeout(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", NewLoader(Options{RegexLimits: RegexLimits{Timeout: time.Microsecond}}))
+	L.SetGlobal("long", lua.LString(strings.Repeat("ab", 1<<20)))
+
+	err := L.DoString(`return require("validation").validate_regex(long, "^(a|b)*c$")`)
+	if err != nil {
+		t.Fatalf("RegexTimeout test failed: %v", err)
+	}
+
+	if got := L.Get(-1).String(); got != "match timed out after 1µs" {
+		t.Errorf("Expected timeout error, got %q", got)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local m = validation.match("v1.24.5", "^v(?P<major>\\d+)\\.(\\d+)\\.(\\d+)(-\\w+)?$")
+		local none = validation.match("latest", "^v\\d+$")
+		local _, err = validation.match("v1", "(")
+		return m[0], m.major, m[1], m[2], m[3], m[4] == nil, none == nil, err
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("Match test failed: %v", err)
+	}
+
+	expected := []string{"v1.24.5", "1", "1", "24", "5", "true", "true"}
+	for i, want := range expected {
+		if got := L.Get(i - 8).String(); got != want {
+			t.Errorf("Result %d: expected %q, got %q", i+1, want, got)
+		}
+	}
+	if got := L.Get(-1).String(); !strings.Contains(got, "missing closing )") {
+		t.Errorf("Expected invalid pattern error, got %q", got)
+	}
+}
+
+func TestRegexLimitsSchema(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", NewLoader(Options{RegexLimits: RegexLimits{
+		MaxPatternLength: 3,
+		MaxSubjectLength: 8,
+	}}))
+
+	script := `
+		local validation = require("validation")
+		local _, schema_err = pcall(validation.schema, {x = {type = "string", pattern = "^abcdef$"}})
+		local _, rule_err = pcall(function()
+			return validation.rule():string():matches("^abcdef$"):compile("x")
+		end)
+		local _, rules_err = pcall(validation.schema, {x = validation.parse_rules("string|regex:^abcdef$")})
+		local s = validation.schema({x = {type = "string", pattern = "^a"}})
+		return schema_err, rule_err, rules_err, (s:validate({x = "abc"})), (s:validate({x = "abcdefghi"}))
+	`
+
+	if err := L.DoString(script); err != nil {
+		t.Fatalf("RegexLimitsSchema test failed: %v", err)
+	}
+
+	for i := -5; i <= -3; i++ {
+		if got := L.Get(i).String(); !strings.Contains(got, "pattern exceeds the maximum length of 3 bytes") {
+			t.Errorf("Expected pattern length error, got %q", got)
+		}
+	}
+	if ok := L.Get(-2); ok != lua.LTrue {
+		t.Error("Expected short string to match schema pattern")
+	}
+	if ok := L.Get(-1); ok != lua.LFalse {
+		t.Error("Expected string over the subject limit to fail schema pattern")
+	}
+}
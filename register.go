@@ -0,0 +1,95 @@
+package validation
+
+import (
+	"fmt"
+	"sync"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// register adds a validator written in Lua to the module. The validator is
+// exposed on the module table, can be dispatched by validate_all and used by
+// name in schemas and rules. It receives the value and returns a boolean and
+// optionally a message template for the failure. The module table is bound
+// as the first upvalue.
+// Usage: validation.register(name, fn, message?)
+func register(L *lua.LState) int {
+	mod := L.CheckTable(lua.UpvalueIndex(1))
+	name := L.CheckString(1)
+	fn := L.CheckFunction(2)
+	message := L.OptString(3, "")
+
+	st := stateOf(L)
+	if _, custom := st.validators[name]; !custom && mod.RawGetString(name) != lua.LNil {
+		L.ArgError(1, fmt.Sprintf("cannot replace built-in %q", name))
+	}
+
+	st.validators[name] = fn
+	if message != "" {
+		st.validatorMessages[name] = message
+	} else {
+		delete(st.validatorMessages, name)
+	}
+	mod.RawSetString(name, fn)
+	return 0
+}
+
+var (
+	goValidatorsMu sync.RWMutex
+	goValidators   = map[string]lua.LGFunction{}
+)
+
+// RegisterGoValidator adds a validator implemented in Go to every module
+// loaded afterwards. Like validators registered from Lua, it is exposed as
+// validation.<name>, can be dispatched by validate_all and used by name with
+// the custom schema option, where it is called with the field value. The
+// function should push a boolean and optionally a message template.
+func RegisterGoValidator(name string, fn lua.LGFunction) error {
+	if name == "" {
+		return fmt.Errorf("validator name must not be empty")
+	}
+	if fn == nil {
+		return fmt.Errorf("validator %q: function must not be nil", name)
+	}
+	_, builtin := exports[name]
+	_, closure := moduleClosures[name]
+	_, table := moduleTables[name]
+	if builtin || closure || table {
+		return fmt.Errorf("validator %q: cannot replace built-in", name)
+	}
+
+	goValidatorsMu.Lock()
+	defer goValidatorsMu.Unlock()
+	goValidators[name] = fn
+	return nil
+}
+
+// RegisterStringValidator is a typed wrapper around RegisterGoValidator for
+// the common case of checking a single string. Non-string values fail.
+func RegisterStringValidator(name string, fn func(string) bool) error {
+	if fn == nil {
+		return fmt.Errorf("validator %q: function must not be nil", name)
+	}
+	return RegisterGoValidator(name, func(L *lua.LState) int {
+		str, ok := L.Get(1).(lua.LString)
+		L.Push(lua.LBool(ok && fn(string(str))))
+		return 1
+	})
+}
+
+// goValidator returns the Go validator registered under name.
+func goValidator(name string) (lua.LGFunction, bool) {
+	goValidatorsMu.RLock()
+	defer goValidatorsMu.RUnlock()
+	fn, ok := goValidators[name]
+	return fn, ok
+}
+
+// loadGoValidators adds every registered Go validator to the module table.
+func loadGoValidators(L *lua.LState, mod *lua.LTable) {
+	goValidatorsMu.RLock()
+	defer goValidatorsMu.RUnlock()
+	for name, fn := range goValidators {
+		mod.RawSetString(name, L.NewFunction(fn))
+	}
+}
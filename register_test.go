@@ -0,0 +1,235 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestRegister(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		validation.register("is_sku", function(v)
+			return type(v) == "string" and v:match("^SKU%-%d+$") ~= nil
+		end, "{field} must be a valid SKU")
+
+		local s = validation.schema({
+			sku = {type="string", required=true, custom="is_sku"},
+			alt = validation.rule():string():custom("is_sku"),
+		})
+		local _, errors = s:validate({sku="ABC", alt="SKU-1"})
+		local allOk, allErrors = validation.validate_all({
+			{fn="is_sku", args={"SKU-42"}},
+			{fn="is_sku", args={"nope"}, message="bad sku"},
+		})
+		return validation.is_sku("SKU-7"), errors.sku, errors.alt, allErrors[1]
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("Register test failed: %v", err)
+	}
+
+	direct := L.Get(-4).(lua.LBool)
+	skuErr := L.Get(-3).String()
+	altErr := L.Get(-2)
+	allErr := L.Get(-1).String()
+
+	if !bool(direct) {
+		t.Error("Expected registered validator to be callable on the module")
+	}
+	if skuErr != "sku must be a valid SKU" {
+		t.Errorf("Unexpected schema error: %q", skuErr)
+	}
+	if altErr != lua.LNil {
+		t.Errorf("Expected no error for valid alt, got %v", altErr)
+	}
+	if allErr != "bad sku" {
+		t.Errorf("Unexpected validate_all error: %q", allErr)
+	}
+}
+
+func TestRegisterDynamicMessage(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		validation.register("is_even", function(v)
+			if v % 2 ~= 0 then
+				return false, "{field} must be even, got " .. v
+			end
+			return true
+		end)
+		validation.register("is_positive", function(v) return v > 0 end)
+
+		local s = validation.schema({
+			count = {type="number", custom={"is_positive", "is_even"}},
+		})
+		local _, odd = s:validate({count=3})
+		local _, negative = s:validate({count=-2})
+		return odd.count, negative.count
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("Register dynamic message test failed: %v", err)
+	}
+
+	odd := L.Get(-2).String()
+	negative := L.Get(-1).String()
+
+	if odd != "count must be even, got 3" {
+		t.Errorf("Unexpected dynamic message: %q", odd)
+	}
+	if negative != "count is invalid" {
+		t.Errorf("Unexpected default message: %q", negative)
+	}
+}
+
+func TestRegisterErrors(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		name     string
+		script   string
+		expected string
+	}{
+		{"replace built-in", `validation.register("is_string", function() return true end)`, `cannot replace built-in "is_string"`},
+		{"unknown custom", `validation.schema({sku={custom="is_sku"}})`, `unknown validator "is_sku"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := L.DoString(`local validation = require("validation")` + "\n" + tt.script)
+			if err == nil {
+				t.Fatal("Expected error")
+			}
+			if !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestRegisterGoValidator(t *testing.T) {
+	taken := map[string]bool{"taken@example.com": true}
+	err := RegisterGoValidator("is_unique_email", func(L *lua.LState) int {
+		email := L.CheckString(1)
+		if taken[email] {
+			L.Push(lua.LFalse)
+			L.Push(lua.LString("{field} is already registered"))
+			return 2
+		}
+		L.Push(lua.LTrue)
+		return 1
+	})
+	if err != nil {
+		t.Fatalf("RegisterGoValidator failed: %v", err)
+	}
+
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local s = validation.schema({
+			email = {type="string", format="email", custom="is_unique_email"},
+		})
+		local ok = s:validate({email="new@example.com"})
+		local _, errors = s:validate({email="taken@example.com"})
+		return validation.is_unique_email("new@example.com"), ok, errors.email
+	`
+
+	err = L.DoString(script)
+	if err != nil {
+		t.Fatalf("RegisterGoValidator test failed: %v", err)
+	}
+
+	direct := L.Get(-3).(lua.LBool)
+	ok := L.Get(-2).(lua.LBool)
+	message := L.Get(-1).String()
+
+	if !bool(direct) {
+		t.Error("Expected Go validator to be callable on the module")
+	}
+	if !bool(ok) {
+		t.Error("Expected true for unused email")
+	}
+	if message != "email is already registered" {
+		t.Errorf("Unexpected error: %q", message)
+	}
+}
+
+func TestRegisterStringValidator(t *testing.T) {
+	err := RegisterStringValidator("is_feature_flag", func(s string) bool {
+		return s == "beta" || s == "dark_mode"
+	})
+	if err != nil {
+		t.Fatalf("RegisterStringValidator failed: %v", err)
+	}
+
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local ok, errors = validation.validate_all({
+			{fn="is_feature_flag", args={"beta"}},
+			{fn="is_feature_flag", args={42}, message="not a flag"},
+		})
+		return ok, errors[1]
+	`
+
+	err = L.DoString(script)
+	if err != nil {
+		t.Fatalf("RegisterStringValidator test failed: %v", err)
+	}
+
+	ok := L.Get(-2).(lua.LBool)
+	message := L.Get(-1).String()
+
+	if bool(ok) {
+		t.Error("Expected false when a non-string is checked")
+	}
+	if message != "not a flag" {
+		t.Errorf("Unexpected error: %q", message)
+	}
+}
+
+func TestRegisterGoValidatorErrors(t *testing.T) {
+	noop := func(L *lua.LState) int { return 0 }
+
+	if err := RegisterGoValidator("is_string", noop); err == nil {
+		t.Error("Expected error when replacing a built-in validator")
+	}
+	if err := RegisterGoValidator("validate_all", noop); err == nil {
+		t.Error("Expected error when replacing validate_all")
+	}
+	for _, name := range []string{"patterns", "openapi"} {
+		if err := RegisterGoValidator(name, noop); err == nil {
+			t.Errorf("Expected error when replacing the %s table", name)
+		}
+	}
+	if err := RegisterGoValidator("", noop); err == nil {
+		t.Error("Expected error for empty name")
+	}
+	if err := RegisterGoValidator("is_nothing", nil); err == nil {
+		t.Error("Expected error for nil function")
+	}
+}
@@ -0,0 +1,194 @@
+package validation
+
+import (
+	lua "github.com/yuin/gopher-lua"
+)
+
+const ruleTypeName = "validation.rule"
+
+// ruleBuilder accumulates a field definition through chained method calls.
+// Every call returns a new builder, so partially built rules can be shared.
+type ruleBuilder struct {
+	def *lua.LTable
+}
+
+// registerRuleType installs the metatable shared by rule builder userdata.
+func registerRuleType(L *lua.LState) {
+	mt := L.NewTypeMetatable(ruleTypeName)
+	L.SetField(mt, "__index", L.SetFuncs(L.NewTable(), ruleMethods))
+}
+
+var ruleMethods = map[string]lua.LGFunction{
+	"string":      ruleType("string"),
+	"number":      ruleType("number"),
+	"integer":     ruleType("integer"),
+	"boolean":     ruleType("boolean"),
+	"table":       ruleType("table"),
+	"array":       ruleType("array"),
+	"any":         ruleType("any"),
+	"required":    ruleRequired,
+	"utf8":        ruleUTF8,
+	"min":         ruleNumber("min"),
+	"max":         ruleNumber("max"),
+	"gt":          ruleNumber("gt"),
+	"lt":          ruleNumber("lt"),
+	"matches":     ruleString("pattern"),
+	"lua_pattern": ruleString("lua_pattern"),
+	"format":      ruleString("format"),
+	"email":       ruleFormat("email"),
+	"url":         ruleFormat("url"),
+	"one_of":      ruleTable("one_of"),
+	"fields":      ruleTable("fields"),
+	"items":       ruleItems,
+	"message":     ruleString("message"),
+	"messages":    ruleTable("messages"),
+	"custom":      ruleString("custom"),
+	"compile":     ruleCompile,
+	"validate":    ruleValidate,
+}
+
+// newRule starts an empty rule builder
+// Usage: validation.rule():string():min(3):max(64) -> rule
+func newRule(L *lua.LState) int {
+	pushRule(L, L.NewTable())
+	return 1
+}
+
+func pushRule(L *lua.LState, def *lua.LTable) {
+	ud := L.NewUserData()
+	ud.Value = &ruleBuilder{def: def}
+	L.SetMetatable(ud, L.GetTypeMetatable(ruleTypeName))
+	L.Push(ud)
+}
+
+func checkRule(L *lua.LState, n int) *ruleBuilder {
+	ud := L.CheckUserData(n)
+	if b, ok := ud.Value.(*ruleBuilder); ok {
+		return b
+	}
+	L.ArgError(n, "rule expected")
+	return nil
+}
+
+// with returns a copy of the builder's definition with key set to value.
+func (b *ruleBuilder) with(L *lua.LState, key string, value lua.LValue) *lua.LTable {
+	def := L.NewTable()
+	b.def.ForEach(func(k, v lua.LValue) {
+		def.RawSet(k, v)
+	})
+	def.RawSetString(key, value)
+	return def
+}
+
+func ruleType(typ string) lua.LGFunction {
+	return func(L *lua.LState) int {
+		b := checkRule(L, 1)
+		pushRule(L, b.with(L, "type", lua.LString(typ)))
+		return 1
+	}
+}
+
+func ruleFormat(format string) lua.LGFunction {
+	return func(L *lua.LState) int {
+		b := checkRule(L, 1)
+		pushRule(L, b.with(L, "format", lua.LString(format)))
+		return 1
+	}
+}
+
+func ruleNumber(key string) lua.LGFunction {
+	return func(L *lua.LState) int {
+		b := checkRule(L, 1)
+		pushRule(L, b.with(L, key, L.CheckNumber(2)))
+		return 1
+	}
+}
+
+func ruleString(key string) lua.LGFunction {
+	return func(L *lua.LState) int {
+		b := checkRule(L, 1)
+		pushRule(L, b.with(L, key, lua.LString(L.CheckString(2))))
+		return 1
+	}
+}
+
+func ruleTable(key string) lua.LGFunction {
+	return func(L *lua.LState) int {
+		b := checkRule(L, 1)
+		pushRule(L, b.with(L, key, L.CheckTable(2)))
+		return 1
+	}
+}
+
+// ruleRequired marks the value as required
+// Usage: rule:required() -> rule
+func ruleRequired(L *lua.LState) int {
+	b := checkRule(L, 1)
+	pushRule(L, b.with(L, "required", lua.LTrue))
+	return 1
+}
+
+// ruleUTF8 requires string values to be valid UTF-8
+// Usage: rule:utf8() -> rule
+func ruleUTF8(L *lua.LState) int {
+	b := checkRule(L, 1)
+	pushRule(L, b.with(L, "utf8", lua.LTrue))
+	return 1
+}
+
+// ruleItems sets the rule or definition applied to every array item
+// Usage: rule:items(item_rule) -> rule
+func ruleItems(L *lua.LState) int {
+	b := checkRule(L, 1)
+	pushRule(L, b.with(L, "items", L.CheckAny(2)))
+	return 1
+}
+
+// ruleCompile compiles the rule into a validator function. The optional name
+// is used as the field name in error messages.
+// Usage: rule:compile(name?) -> function(value) -> boolean, message?
+func ruleCompile(L *lua.LState) int {
+	b := checkRule(L, 1)
+	name := L.OptString(2, "value")
+
+	f, err := compileField(L, b.def)
+	if err != nil {
+		L.ArgError(1, err.Error())
+	}
+
+	ud := L.NewUserData()
+	ud.Value = f
+	L.Push(L.NewClosure(compiledRule, ud, lua.LString(name)))
+	return 1
+}
+
+// ruleValidate compiles the rule and checks a single value with it
+// Usage: rule:validate(value) -> boolean, message?
+func ruleValidate(L *lua.LState) int {
+	b := checkRule(L, 1)
+	value := L.Get(2)
+
+	f, err := compileField(L, b.def)
+	if err != nil {
+		L.ArgError(1, err.Error())
+	}
+	return pushRuleResult(L, f.validate(L, "value", value, nil))
+}
+
+// compiledRule is the function returned by rule:compile. The compiled field
+// and the field name are bound as upvalues.
+func compiledRule(L *lua.LState) int {
+	f := L.CheckUserData(lua.UpvalueIndex(1)).Value.(*field)
+	name := L.CheckString(lua.UpvalueIndex(2))
+	return pushRuleResult(L, f.validate(L, name, L.Get(1), nil))
+}
+
+func pushRuleResult(L *lua.LState, errs []fieldError) int {
+	if len(errs) == 0 {
+		L.Push(lua.LTrue)
+		return 1
+	}
+	L.Push(lua.LFalse)
+	L.Push(lua.LString(errs[0].message(L)))
+	return 2
+}
@@ -0,0 +1,125 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestRuleCompile(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected bool
+		message  string
+	}{
+		{"valid username", `"ada_lovelace"`, true, ""},
+		{"too short", `"ad"`, false, "username must be at least 3 characters"},
+		{"too long", `"abcdefghijklmnopq"`, false, "username must be at most 16 characters"},
+		{"invalid characters", `"ada lovelace"`, false, "username must match the pattern ^\\w+$"},
+		{"wrong type", "42", false, "username must be of type string"},
+		{"missing", "nil", false, "username is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script := `
+				local validation = require("validation")
+				local check = validation.rule():string():required():min(3):max(16):matches("^\\w+$"):compile("username")
+				return check(` + tt.value + `)
+			`
+
+			err := L.DoString(script)
+			if err != nil {
+				t.Fatalf("Rule compile test failed: %v", err)
+			}
+
+			if tt.expected {
+				result := L.Get(-1).(lua.LBool)
+				if !bool(result) {
+					t.Errorf("Expected true for %s", tt.value)
+				}
+				return
+			}
+
+			result := L.Get(-2).(lua.LBool)
+			message := L.Get(-1).String()
+			if bool(result) {
+				t.Errorf("Expected false for %s", tt.value)
+			}
+			if message != tt.message {
+				t.Errorf("Expected message %q, got %q", tt.message, message)
+			}
+		})
+	}
+}
+
+func TestRuleReuse(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local base = validation.rule():string()
+		local short = base:max(3)
+		return base:validate("longer"), (short:validate("longer"))
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("Rule reuse test failed: %v", err)
+	}
+
+	base := L.Get(-2).(lua.LBool)
+	short := L.Get(-1).(lua.LBool)
+
+	if !bool(base) {
+		t.Error("Expected base rule to be unaffected by derived rule")
+	}
+	if bool(short) {
+		t.Error("Expected derived rule to apply max")
+	}
+}
+
+func TestRuleInSchema(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local s = validation.schema({
+			email = validation.rule():string():required():email(),
+			scores = validation.rule():array():items(validation.rule():number():min(0):max(100)),
+		})
+		local ok, errors = s:validate({email="not-an-email", scores={50, 101}})
+		return ok, errors.email, errors["scores[2]"]
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("Rule in schema test failed: %v", err)
+	}
+
+	ok := L.Get(-3).(lua.LBool)
+	email := L.Get(-2).String()
+	score := L.Get(-1).String()
+
+	if bool(ok) {
+		t.Error("Expected false for invalid table")
+	}
+	if email != "email must be a valid email" {
+		t.Errorf("Unexpected email error: %q", email)
+	}
+	if score != "scores[2] must be at most 100" {
+		t.Errorf("Unexpected score error: %q", score)
+	}
+}
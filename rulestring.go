@@ -0,0 +1,134 @@
+package validation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// ruleTypes maps rule string keywords to field types.
+var ruleTypes = map[string]string{
+	"string":  "string",
+	"numeric": "number",
+	"number":  "number",
+	"integer": "integer",
+	"boolean": "boolean",
+	"array":   "array",
+	"table":   "table",
+}
+
+// parseRuleString converts a rule string such as "required|email|max:255"
+// into a field definition table. Names that are not built-in keywords are
+// treated as custom validators. Without an explicit type, min, max and
+// between bound the length of a string.
+func parseRuleString(L *lua.LState, rules string) (*lua.LTable, error) {
+	def := L.NewTable()
+	custom := L.NewTable()
+
+	for _, token := range strings.Split(rules, "|") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+		name, arg, hasArg := strings.Cut(token, ":")
+
+		switch {
+		case name == "required":
+			def.RawSetString("required", lua.LTrue)
+		case name == "utf8":
+			def.RawSetString("utf8", lua.LTrue)
+		case name == "nullable" || name == "sometimes":
+			// Optional values are already skipped when nil.
+		case ruleTypes[name] != "":
+			def.RawSetString("type", lua.LString(ruleTypes[name]))
+		case formats[name] != nil:
+			def.RawSetString("format", lua.LString(name))
+		case name == "min" || name == "max":
+			n, err := ruleNumberArg(token, arg, hasArg)
+			if err != nil {
+				return nil, err
+			}
+			def.RawSetString(name, n)
+		case name == "between":
+			lo, hi, ok := strings.Cut(arg, ",")
+			if !ok {
+				return nil, fmt.Errorf("rule %q: expected between:min,max", token)
+			}
+			min, err := ruleNumberArg(token, lo, true)
+			if err != nil {
+				return nil, err
+			}
+			max, err := ruleNumberArg(token, hi, true)
+			if err != nil {
+				return nil, err
+			}
+			def.RawSetString("min", min)
+			def.RawSetString("max", max)
+		case name == "in":
+			if !hasArg || arg == "" {
+				return nil, fmt.Errorf("rule %q: expected in:a,b,...", token)
+			}
+			def.RawSetString("one_of", tagValues(L, strings.Split(arg, ",")))
+		case name == "regex":
+			if !hasArg || arg == "" {
+				return nil, fmt.Errorf("rule %q: expected regex:pattern", token)
+			}
+			def.RawSetString("pattern", lua.LString(arg))
+		case hasArg:
+			return nil, fmt.Errorf("unknown rule %q", name)
+		default:
+			custom.Append(lua.LString(name))
+		}
+	}
+
+	if custom.Len() > 0 {
+		def.RawSetString("custom", custom)
+	}
+	if def.RawGetString("type") == lua.LNil {
+		if def.RawGetString("format") != lua.LNil || def.RawGetString("min") != lua.LNil ||
+			def.RawGetString("max") != lua.LNil || def.RawGetString("pattern") != lua.LNil {
+			def.RawSetString("type", lua.LString("string"))
+		}
+	}
+	return def, nil
+}
+
+func ruleNumberArg(token, arg string, hasArg bool) (lua.LNumber, error) {
+	n, err := strconv.ParseFloat(strings.TrimSpace(arg), 64)
+	if !hasArg || err != nil {
+		return 0, fmt.Errorf("rule %q: expected a number", token)
+	}
+	return lua.LNumber(n), nil
+}
+
+// parseRules parses a rule string into a rule that can be compiled, extended
+// or used in a schema
+// Usage: validation.parse_rules("required|email|max:255") -> rule
+func parseRules(L *lua.LState) int {
+	def, err := parseRuleString(L, L.CheckString(1))
+	if err != nil {
+		L.ArgError(1, err.Error())
+	}
+	pushRule(L, def)
+	return 1
+}
+
+// validateFields validates a table against a map of field names to rule
+// strings, rules or field definitions
+// Usage: validation.validate_fields(tbl, {email="required|email|max:255", ...}) -> boolean, table
+func validateFields(L *lua.LState) int {
+	tbl := L.CheckTable(1)
+	rules := L.CheckTable(2)
+
+	s, err := compileSchema(L, rules)
+	if err != nil {
+		L.ArgError(2, err.Error())
+	}
+
+	errs := s.validate(L, tbl)
+	L.Push(lua.LBool(len(errs) == 0))
+	L.Push(fieldErrorsTable(L, errs))
+	return 2
+}
@@ -0,0 +1,150 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestValidateFields(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local rules = {
+			email = "required|email|max:255",
+			name = "required|string|between:2,50",
+			age = "numeric|min:18",
+			role = "in:admin,member",
+			code = "regex:^[A-Z]{3}$",
+		}
+		local ok = validation.validate_fields({email="ada@example.com", name="Ada", age=36, role="admin", code="ABC"}, rules)
+		local _, errors = validation.validate_fields({email="nope", name="A", age=12, role="owner", code="abc"}, rules)
+		return ok, errors.email, errors.name, errors.age, errors.role, errors.code
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("ValidateFields test failed: %v", err)
+	}
+
+	ok := L.Get(-6).(lua.LBool)
+	if !bool(ok) {
+		t.Error("Expected true for valid table")
+	}
+
+	expected := []string{
+		"email must be a valid email",
+		"name must be at least 2 characters",
+		"age must be at least 18",
+		"role must be one of admin, member",
+		"code must match the pattern ^[A-Z]{3}$",
+	}
+	for i, message := range expected {
+		if got := L.Get(i - len(expected)).String(); got != message {
+			t.Errorf("Expected %q, got %q", message, got)
+		}
+	}
+}
+
+func TestValidateFieldsNumericIn(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local rules = {n = "integer|in:1,2"}
+		local _, errors = validation.validate_fields({n = 3}, rules)
+		return (validation.validate_fields({n = 1}, rules)), (validation.validate_fields({n = "2"}, {n = "in:1,2"})), errors.n
+	`
+
+	if err := L.DoString(script); err != nil {
+		t.Fatalf("ValidateFieldsNumericIn test failed: %v", err)
+	}
+
+	if got := L.Get(-3); got != lua.LTrue {
+		t.Error("Expected number in the in: list to be accepted")
+	}
+	if got := L.Get(-2); got != lua.LTrue {
+		t.Error("Expected numeric string in the in: list to be accepted")
+	}
+	if got := L.Get(-1).String(); got != "n must be one of 1, 2" {
+		t.Errorf("Expected %q, got %q", "n must be one of 1, 2", got)
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		validation.register("is_sku", function(v) return v:sub(1, 4) == "SKU-" end)
+		local check = validation.parse_rules("required|is_sku|max:8"):compile("sku")
+		local _, missing = check(nil)
+		local _, custom = check("ABC-1")
+		local _, long = check("SKU-12345")
+		return check("SKU-1"), missing, custom, long
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("ParseRules test failed: %v", err)
+	}
+
+	ok := L.Get(-4).(lua.LBool)
+	missing := L.Get(-3).String()
+	custom := L.Get(-2).String()
+	long := L.Get(-1).String()
+
+	if !bool(ok) {
+		t.Error("Expected true for valid SKU")
+	}
+	if missing != "sku is required" {
+		t.Errorf("Unexpected required error: %q", missing)
+	}
+	if custom != "sku is invalid" {
+		t.Errorf("Unexpected custom error: %q", custom)
+	}
+	if long != "sku must be at most 8 characters" {
+		t.Errorf("Unexpected max error: %q", long)
+	}
+}
+
+func TestParseRulesErrors(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		name     string
+		rules    string
+		expected string
+	}{
+		{"bad number", "max:abc", `rule "max:abc": expected a number`},
+		{"bad between", "between:3", `rule "between:3": expected between:min,max`},
+		{"unknown rule with argument", "digits:4", `unknown rule "digits"`},
+		{"unknown validator", "required|is_sku", `unknown validator "is_sku"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := L.DoString(`require("validation").parse_rules("` + tt.rules + `"):compile()`)
+			if err == nil {
+				t.Fatal("Expected error for invalid rule string")
+			}
+			if !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
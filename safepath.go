@@ -0,0 +1,93 @@
+package validation
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// windowsDeviceNames lists the names Windows reserves for devices in every
+// directory, with or without an extension.
+var windowsDeviceNames = buildCodeSet(`CON PRN AUX NUL CONIN$ CONOUT$
+	COM0 COM1 COM2 COM3 COM4 COM5 COM6 COM7 COM8 COM9 COM¹ COM² COM³
+	LPT0 LPT1 LPT2 LPT3 LPT4 LPT5 LPT6 LPT7 LPT8 LPT9 LPT¹ LPT² LPT³`)
+
+// isWindowsDeviceName reports whether a path element names a Windows device,
+// such as "nul" or "COM1.txt".
+func isWindowsDeviceName(elem string) bool {
+	name, _, _ := strings.Cut(elem, ".")
+	return windowsDeviceNames[strings.ToUpper(strings.TrimRight(name, " "))]
+}
+
+// safePath checks a relative path supplied by a user, such as a storage key,
+// and returns it cleaned with forward slashes. Both "/" and "\" separate
+// elements. Without a base directory no element may be "..". With one, the
+// path is joined to it and must stay inside it, and the joined path is
+// returned. The check is lexical; symbolic links are not resolved.
+func safePath(s, baseDir string) (string, error) {
+	if s == "" {
+		return "", fmt.Errorf("empty path")
+	}
+	for _, r := range s {
+		if r == 0 {
+			return "", fmt.Errorf("path contains a NUL byte")
+		}
+		if r < 0x20 || r == 0x7f {
+			return "", fmt.Errorf("path contains a control character")
+		}
+	}
+
+	p := strings.ReplaceAll(s, `\`, "/")
+	if strings.HasPrefix(p, "/") || len(p) >= 2 && p[1] == ':' && isASCIILetter(p[0]) {
+		return "", fmt.Errorf("path is absolute")
+	}
+	for _, elem := range strings.Split(p, "/") {
+		if elem == ".." && baseDir == "" {
+			return "", fmt.Errorf("path contains a parent directory reference")
+		}
+		if isWindowsDeviceName(elem) {
+			return "", fmt.Errorf("path contains the reserved name %q", elem)
+		}
+	}
+
+	cleaned := path.Clean(p)
+	if cleaned == "." {
+		return "", fmt.Errorf("path does not name a file")
+	}
+	if baseDir == "" {
+		return cleaned, nil
+	}
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("path escapes the base directory")
+	}
+	return filepath.Join(baseDir, filepath.FromSlash(cleaned)), nil
+}
+
+// IsSafePath reports whether s is a relative path without parent directory
+// references, NUL bytes, control characters or Windows device names.
+func IsSafePath(s string) bool {
+	_, err := safePath(s, "")
+	return err == nil
+}
+
+// isSafePath checks if a user-supplied path is relative and stays inside a
+// base directory, returning the cleaned path
+// Usage: validation.is_safe_path(str, {base_dir}) -> boolean, path_or_error
+func isSafePath(L *lua.LState) int {
+	str := L.CheckString(1)
+	opts := L.OptTable(2, L.NewTable())
+	baseDir := lua.LVAsString(opts.RawGetString("base_dir"))
+
+	cleaned, err := safePath(str, baseDir)
+	if err != nil {
+		L.Push(lua.LFalse)
+		L.Push(lua.LString(err.Error()))
+		return 2
+	}
+	L.Push(lua.LTrue)
+	L.Push(lua.LString(cleaned))
+	return 2
+}
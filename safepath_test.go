@@ -0,0 +1,63 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsSafePath(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		input    string
+		opts     string
+		expected bool
+		result   string
+	}{
+		{"avatars/42.png", `{}`, true, "avatars/42.png"},
+		{"./a//b/./c.txt", `{}`, true, "a/b/c.txt"},
+		{`reports\2024\q1.pdf`, `{}`, true, "reports/2024/q1.pdf"},
+		{"..hidden/file", `{}`, true, "..hidden/file"},
+		{"", `{}`, false, "empty path"},
+		{".", `{}`, false, "path does not name a file"},
+		{"../etc/passwd", `{}`, false, "path contains a parent directory reference"},
+		{`a\..\..\b`, `{}`, false, "path contains a parent directory reference"},
+		{"/etc/passwd", `{}`, false, "path is absolute"},
+		{`\\server\share\file`, `{}`, false, "path is absolute"},
+		{`C:\Windows\win.ini`, `{}`, false, "path is absolute"},
+		{"c:file", `{}`, false, "path is absolute"},
+		{"file\x00.png", `{}`, false, "path contains a NUL byte"},
+		{"file\n.png", `{}`, false, "path contains a control character"},
+		{"logs/CON", `{}`, false, `path contains the reserved name "CON"`},
+		{"nul.txt", `{}`, false, `path contains the reserved name "nul.txt"`},
+		{"com1 .log", `{}`, false, `path contains the reserved name "com1 .log"`},
+		{"lpt¹", `{}`, false, `path contains the reserved name "lpt¹"`},
+		{"console.log", `{}`, true, "console.log"},
+		{"a/b.png", `{base_dir = "/srv/uploads"}`, true, "/srv/uploads/a/b.png"},
+		{"a/../b.png", `{base_dir = "/srv/uploads"}`, true, "/srv/uploads/b.png"},
+		{"a/../../b.png", `{base_dir = "/srv/uploads"}`, false, "path escapes the base directory"},
+		{"..", `{base_dir = "/srv/uploads"}`, false, "path escapes the base directory"},
+		{"a/..", `{base_dir = "/srv/uploads"}`, false, "path does not name a file"},
+		{"/srv/uploads/a.png", `{base_dir = "/srv/uploads"}`, false, "path is absolute"},
+	}
+
+	for _, tt := range tests {
+		L.SetGlobal("input", lua.LString(tt.input))
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.is_safe_path(input, ` + tt.opts + `)
+		`); err != nil {
+			t.Fatalf("is_safe_path(%q): %v", tt.input, err)
+		}
+
+		ok, result := L.Get(-2), L.Get(-1)
+		L.Pop(2)
+		if ok != lua.LBool(tt.expected) || result.String() != tt.result {
+			t.Errorf("is_safe_path(%q, %s) = %v, %q, want %v, %q", tt.input, tt.opts, ok, result, tt.expected, tt.result)
+		}
+	}
+}
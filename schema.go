@@ -0,0 +1,740 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+const schemaTypeName = "validation.schema"
+
+// schema is a compiled table schema: a set of named fields, each with the
+// rules its value must satisfy.
+type schema struct {
+	fields map[string]*field
+	names  []string
+}
+
+// field describes the expected value of one schema field. Tables may carry a
+// nested schema for their fields and arrays a field describing every item.
+type field struct {
+	typ       string
+	required  bool
+	omitEmpty bool
+	rules     []rule
+	fields    *schema
+	items     *field
+	message   string
+	messages  map[string]string
+}
+
+// rule is a single named check. The name selects the message template and
+// params fill in its placeholders. Rules backed by a validator registered
+// from Lua carry the function in fn instead of a check. Sized rules on fields
+// of type any pick the length or items variant of the name from the value.
+// arg keeps the option value the rule was compiled from.
+type rule struct {
+	name   string
+	params map[string]string
+	check  func(value lua.LValue) bool
+	fn     *lua.LFunction
+	sized  bool
+	arg    lua.LValue
+}
+
+// nameFor returns the rule name used to report a failure for value.
+func (r rule) nameFor(value lua.LValue) string {
+	if !r.sized {
+		return r.name
+	}
+	switch value.(type) {
+	case lua.LString:
+		return r.name + "_length"
+	case *lua.LTable:
+		return r.name + "_items"
+	}
+	return r.name
+}
+
+// run applies the rule to value. Lua validators may return a message as a
+// second result, which is returned as the template for the error.
+func (r rule) run(L *lua.LState, value lua.LValue) (bool, string) {
+	if r.fn == nil {
+		return r.check(value), ""
+	}
+
+	if err := L.CallByParam(lua.P{Fn: r.fn, NRet: 2, Protect: true}, value); err != nil {
+		L.RaiseError("validator %s: %v", r.name, err)
+	}
+	passed, message := lua.LVAsBool(L.Get(-2)), L.Get(-1)
+	L.Pop(2)
+	if str, ok := message.(lua.LString); ok && !passed {
+		return false, string(str)
+	}
+	return passed, ""
+}
+
+// fieldError is a failed rule for the field at path. The message is rendered
+// from params when the error is reported, so it follows the active locale
+// unless the field overrides the template.
+type fieldError struct {
+	path     string
+	rule     string
+	params   map[string]string
+	template string
+}
+
+// schemaTypes maps the type names accepted in field definitions to their checks.
+var schemaTypes = map[string]func(value lua.LValue) bool{
+	"string": func(value lua.LValue) bool {
+		_, ok := value.(lua.LString)
+		return ok
+	},
+	"number": func(value lua.LValue) bool {
+		_, ok := value.(lua.LNumber)
+		return ok
+	},
+	"integer": func(value lua.LValue) bool {
+		n, ok := value.(lua.LNumber)
+		return ok && float64(n) == math.Trunc(float64(n))
+	},
+	"boolean": func(value lua.LValue) bool {
+		_, ok := value.(lua.LBool)
+		return ok
+	},
+	"table": func(value lua.LValue) bool {
+		_, ok := value.(*lua.LTable)
+		return ok
+	},
+	"any": func(value lua.LValue) bool {
+		return value != lua.LNil
+	},
+	"array": func(value lua.LValue) bool {
+		tbl, ok := value.(*lua.LTable)
+		return ok && isArray(tbl)
+	},
+}
+
+// isArray reports whether a table is a sequence with keys 1..n and nothing else.
+func isArray(tbl *lua.LTable) bool {
+	count := 0
+	tbl.ForEach(func(_, _ lua.LValue) {
+		count++
+	})
+	return count == tbl.Len()
+}
+
+// formats maps the names accepted by the format option to string checks.
+var formats = map[string]func(string) bool{
+	"email":               IsEmail,
+	"idn_email":           IsIDNEmail,
+	"url":                 IsURL,
+	"domain":              IsDomain,
+	"idn_domain":          IsIDNDomain,
+	"hostname":            IsHostname,
+	"idn_hostname":        IsIDNHostname,
+	"uuid":                func(s string) bool { return IsUUID(s, 0) },
+	"ulid":                IsULID,
+	"object_id":           IsObjectID,
+	"ip":                  IsIP,
+	"ipv4":                IsIPv4,
+	"ipv6":                IsIPv6,
+	"cidr":                IsCIDR,
+	"mac":                 IsMAC,
+	"port":                IsPort,
+	"e164":                IsPhoneE164,
+	"credit_card":         IsCreditCard,
+	"iban":                IsIBAN,
+	"bic":                 IsBIC,
+	"isbn":                func(s string) bool { return IsISBN(s, 0) },
+	"isbn10":              func(s string) bool { return IsISBN(s, 10) },
+	"isbn13":              func(s string) bool { return IsISBN(s, 13) },
+	"ean":                 IsEAN,
+	"upc":                 IsUPC,
+	"country_code":        func(s string) bool { return IsCountryCode(s, false) },
+	"country_code_alpha3": func(s string) bool { return IsCountryCode(s, true) },
+	"currency_code":       func(s string) bool { return IsCurrencyCode(s, false) },
+	"language_tag":        IsLanguageTag,
+	"date":                func(s string) bool { return IsDate(s, "") },
+	"datetime":            func(s string) bool { return IsDatetime(s, true) },
+	"duration":            func(s string) bool { return IsDuration(s, false) },
+	"timezone":            IsTimezone,
+	"latitude":            IsLatitude,
+	"longitude":           IsLongitude,
+	"coordinates":         IsCoordinates,
+	"geohash":             IsGeohash,
+	"hex_color":           IsHexColor,
+	"rgb":                 IsRGB,
+	"hsl":                 IsHSL,
+	"base64":              IsBase64,
+	"base64url":           IsBase64URL,
+	"data_uri":            IsDataURI,
+	"mime_type":           IsMIMEType,
+	"safe_path":           IsSafePath,
+	"jwt":                 IsJWT,
+	"md5":                 func(s string) bool { return IsHash(s, "md5") },
+	"sha1":                func(s string) bool { return IsHash(s, "sha1") },
+	"sha256":              func(s string) bool { return IsHash(s, "sha256") },
+	"sha384":              func(s string) bool { return IsHash(s, "sha384") },
+	"sha512":              func(s string) bool { return IsHash(s, "sha512") },
+	"semver":              IsSemver,
+	"cron":                IsCron,
+	"ascii":               IsASCII,
+	"printable":           IsPrintable,
+	"printable_ascii":     isPrintableASCII,
+	"alpha":               func(s string) bool { return IsAlpha(s, false) },
+	"alpha_unicode":       func(s string) bool { return IsAlpha(s, true) },
+	"alphanum":            func(s string) bool { return IsAlphanumeric(s, false) },
+	"alphanum_unicode":    func(s string) bool { return IsAlphanumeric(s, true) },
+	"numeric_string":      func(s string) bool { return IsNumericString(s, false) },
+	"lowercase":           IsLowercase,
+	"uppercase":           IsUppercase,
+	"snake_case":          func(s string) bool { return IsCaseStyle(s, "snake_case") },
+	"constant_case":       func(s string) bool { return IsCaseStyle(s, "constant_case") },
+	"kebab_case":          func(s string) bool { return IsCaseStyle(s, "kebab_case") },
+	"camel_case":          func(s string) bool { return IsCaseStyle(s, "camel_case") },
+	"pascal_case":         func(s string) bool { return IsCaseStyle(s, "pascal_case") },
+	"slug":                func(s string) bool { return IsSlug(s, false) },
+	"slug_unicode":        func(s string) bool { return IsSlug(s, true) },
+	"username":            IsUsername,
+	"json":                IsJSON,
+	"yaml":                IsYAML,
+	"xml":                 IsXML,
+}
+
+// fieldOptions lists the keys accepted in a field definition table.
+var fieldOptions = map[string]bool{
+	"type": true, "required": true, "min": true, "max": true, "gt": true, "lt": true,
+	"pattern": true, "lua_pattern": true, "one_of": true, "format": true,
+	"fields": true, "items": true, "message": true, "messages": true,
+	"custom": true, "utf8": true, "omit_empty": true,
+}
+
+// compileSchema compiles a Lua table of field definitions. Validators
+// registered from Lua are resolved against the module state of L.
+func compileSchema(L *lua.LState, def *lua.LTable) (*schema, error) {
+	s := &schema{fields: map[string]*field{}}
+
+	var err error
+	def.ForEach(func(key, value lua.LValue) {
+		if err != nil {
+			return
+		}
+		name, ok := key.(lua.LString)
+		if !ok {
+			err = fmt.Errorf("field names must be strings, got %s", key.Type())
+			return
+		}
+		f, fieldErr := compileField(L, value)
+		if fieldErr != nil {
+			err = fmt.Errorf("field %q: %v", string(name), fieldErr)
+			return
+		}
+		s.fields[string(name)] = f
+		s.names = append(s.names, string(name))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(s.names)
+	return s, nil
+}
+
+// compileField compiles a single field definition table, rule builder or
+// rule string.
+func compileField(L *lua.LState, def lua.LValue) (*field, error) {
+	switch d := def.(type) {
+	case *lua.LUserData:
+		if b, ok := d.Value.(*ruleBuilder); ok {
+			def = b.def
+		}
+	case lua.LString:
+		tbl, err := parseRuleString(L, string(d))
+		if err != nil {
+			return nil, err
+		}
+		def = tbl
+	}
+	tbl, ok := def.(*lua.LTable)
+	if !ok {
+		return nil, fmt.Errorf("definition must be a table, rule or rule string, got %s", def.Type())
+	}
+
+	var unknown []string
+	tbl.ForEach(func(key, _ lua.LValue) {
+		if !fieldOptions[key.String()] {
+			unknown = append(unknown, key.String())
+		}
+	})
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown option %q", unknown[0])
+	}
+
+	f := &field{
+		required:  lua.LVAsBool(tbl.RawGetString("required")),
+		omitEmpty: lua.LVAsBool(tbl.RawGetString("omit_empty")),
+	}
+
+	if message, ok := tbl.RawGetString("message").(lua.LString); ok {
+		f.message = string(message)
+	}
+	if messages, ok := tbl.RawGetString("messages").(*lua.LTable); ok {
+		f.messages = map[string]string{}
+		var err error
+		messages.ForEach(func(key, value lua.LValue) {
+			template, ok := value.(lua.LString)
+			if !ok && err == nil {
+				err = fmt.Errorf("message for %s must be a string", key.String())
+			}
+			f.messages[key.String()] = string(template)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	typ, _ := tbl.RawGetString("type").(lua.LString)
+	fields, hasFields := tbl.RawGetString("fields").(*lua.LTable)
+	items := tbl.RawGetString("items")
+	switch {
+	case hasFields && typ == "":
+		typ = "table"
+	case items != lua.LNil && typ == "":
+		typ = "array"
+	}
+	if hasFields && typ != "table" {
+		return nil, fmt.Errorf("fields requires type table")
+	}
+	if items != lua.LNil && typ != "array" {
+		return nil, fmt.Errorf("items requires type array")
+	}
+
+	if typ != "" {
+		check, known := schemaTypes[string(typ)]
+		if !known {
+			return nil, fmt.Errorf("unknown type %q", string(typ))
+		}
+		f.typ = string(typ)
+		f.rules = append(f.rules, rule{
+			name:   "type",
+			params: map[string]string{"type": f.typ},
+			check:  check,
+		})
+	}
+
+	if hasFields {
+		nested, err := compileSchema(L, fields)
+		if err != nil {
+			return nil, err
+		}
+		f.fields = nested
+	}
+	if items != lua.LNil {
+		item, err := compileField(L, items)
+		if err != nil {
+			return nil, fmt.Errorf("items: %v", err)
+		}
+		f.items = item
+	}
+
+	if lua.LVAsBool(tbl.RawGetString("utf8")) {
+		f.rules = append(f.rules, rule{
+			name: "utf8",
+			check: func(value lua.LValue) bool {
+				str, ok := value.(lua.LString)
+				return ok && IsUTF8(string(str))
+			},
+		})
+	}
+
+	for _, bound := range []string{"min", "max", "gt", "lt"} {
+		n, ok := tbl.RawGetString(bound).(lua.LNumber)
+		if !ok {
+			continue
+		}
+		r, err := boundRule(f.typ, bound, n)
+		if err != nil {
+			return nil, err
+		}
+		f.rules = append(f.rules, r)
+	}
+
+	if pattern, ok := tbl.RawGetString("pattern").(lua.LString); ok {
+		limits := regexLimitsOf(L)
+		re, err := limits.compile(string(pattern))
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern: %v", err)
+		}
+		f.rules = append(f.rules, rule{
+			name:   "pattern",
+			params: map[string]string{"pattern": string(pattern)},
+			arg:    pattern,
+			check: func(value lua.LValue) bool {
+				str, ok := value.(lua.LString)
+				if !ok {
+					return false
+				}
+				matched, err := limits.match(re, string(str))
+				return err == nil && matched
+			},
+		})
+	}
+
+	if pattern, ok := tbl.RawGetString("lua_pattern").(lua.LString); ok {
+		limits := regexLimitsOf(L)
+		if err := limits.checkPattern(string(pattern)); err != nil {
+			return nil, fmt.Errorf("invalid Lua pattern: %v", err)
+		}
+		if err := checkLuaPattern(string(pattern)); err != nil {
+			return nil, fmt.Errorf("invalid Lua pattern: %v", err)
+		}
+		f.rules = append(f.rules, rule{
+			name:   "lua_pattern",
+			params: map[string]string{"pattern": string(pattern)},
+			check: func(value lua.LValue) bool {
+				str, ok := value.(lua.LString)
+				if !ok {
+					return false
+				}
+				matched, err := limits.run(string(str), func() (bool, error) {
+					return matchLuaPattern(string(pattern), string(str))
+				})
+				return err == nil && matched
+			},
+		})
+	}
+
+	if values, ok := tbl.RawGetString("one_of").(*lua.LTable); ok {
+		var allowed []lua.LValue
+		var names []string
+		for i := 1; i <= values.Len(); i++ {
+			allowed = append(allowed, values.RawGetInt(i))
+			if name := values.RawGetInt(i).String(); !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+		f.rules = append(f.rules, rule{
+			name:   "one_of",
+			params: map[string]string{"values": strings.Join(names, ", ")},
+			arg:    values,
+			check: func(value lua.LValue) bool {
+				for _, candidate := range allowed {
+					if value == candidate {
+						return true
+					}
+				}
+				return false
+			},
+		})
+	}
+
+	if format, ok := tbl.RawGetString("format").(lua.LString); ok {
+		check, known := formats[string(format)]
+		if !known {
+			return nil, fmt.Errorf("unknown format %q", string(format))
+		}
+		f.rules = append(f.rules, rule{
+			name:   "format",
+			params: map[string]string{"format": string(format)},
+			arg:    format,
+			check: func(value lua.LValue) bool {
+				str, ok := value.(lua.LString)
+				return ok && check(string(str))
+			},
+		})
+	}
+
+	switch custom := tbl.RawGetString("custom").(type) {
+	case lua.LString:
+		r, err := customRule(L, string(custom))
+		if err != nil {
+			return nil, err
+		}
+		f.rules = append(f.rules, r)
+	case *lua.LTable:
+		for i := 1; i <= custom.Len(); i++ {
+			r, err := customRule(L, custom.RawGetInt(i).String())
+			if err != nil {
+				return nil, err
+			}
+			f.rules = append(f.rules, r)
+		}
+	}
+
+	return f, nil
+}
+
+// customRule builds a rule for a validator registered with validation.register
+// or RegisterGoValidator.
+func customRule(L *lua.LState, name string) (rule, error) {
+	if L != nil {
+		st := stateOf(L)
+		if fn, ok := st.validators[name]; ok {
+			return rule{name: name, fn: fn}, nil
+		}
+		if fn, ok := goValidator(name); ok && (st.enabled == nil || st.enabled(customGroup)) {
+			return rule{name: name, fn: L.NewFunction(fn)}, nil
+		}
+	}
+	return rule{}, fmt.Errorf("unknown validator %q", name)
+}
+
+// boundRule builds an inclusive (min, max) or exclusive (gt, lt) bound.
+// Strings are bounded by their length in characters, arrays by their item
+// count and numbers by their value; fields of type any apply whichever fits
+// the value at hand. The field must declare one of these types.
+func boundRule(typ, bound string, limit lua.LNumber) (rule, error) {
+	params := map[string]string{bound: limit.String()}
+	within := func(n float64) bool {
+		switch bound {
+		case "min":
+			return n >= float64(limit)
+		case "max":
+			return n <= float64(limit)
+		case "gt":
+			return n > float64(limit)
+		}
+		return n < float64(limit)
+	}
+
+	switch typ {
+	case "string":
+		return rule{
+			name:   bound + "_length",
+			params: params,
+			arg:    limit,
+			check: func(value lua.LValue) bool {
+				return within(float64(utf8.RuneCountInString(lua.LVAsString(value))))
+			},
+		}, nil
+	case "array":
+		return rule{
+			name:   bound + "_items",
+			params: params,
+			arg:    limit,
+			check: func(value lua.LValue) bool {
+				return within(float64(value.(*lua.LTable).Len()))
+			},
+		}, nil
+	case "number", "integer":
+		return rule{
+			name:   bound,
+			params: params,
+			arg:    limit,
+			check: func(value lua.LValue) bool {
+				return within(float64(lua.LVAsNumber(value)))
+			},
+		}, nil
+	case "any":
+		return rule{
+			name:   bound,
+			params: params,
+			arg:    limit,
+			sized:  true,
+			check: func(value lua.LValue) bool {
+				size, ok := valueSize(value)
+				return ok && within(size)
+			},
+		}, nil
+	}
+	return rule{}, fmt.Errorf("%s requires type string, number, integer, array or any", bound)
+}
+
+// valueSize returns the value of a number, the length in characters of a
+// string or the length of a table.
+func valueSize(value lua.LValue) (float64, bool) {
+	switch v := value.(type) {
+	case lua.LNumber:
+		return float64(v), true
+	case lua.LString:
+		return float64(utf8.RuneCountInString(string(v))), true
+	case *lua.LTable:
+		return float64(v.Len()), true
+	}
+	return 0, false
+}
+
+// validate checks a table against the schema and returns the failures in
+// field name order.
+func (s *schema) validate(L *lua.LState, tbl *lua.LTable) []fieldError {
+	return s.validateFields(L, "", tbl, nil)
+}
+
+// validateFields checks every field of tbl, prefixing error paths with prefix.
+func (s *schema) validateFields(L *lua.LState, prefix string, tbl *lua.LTable, errs []fieldError) []fieldError {
+	for _, name := range s.names {
+		errs = s.fields[name].validate(L, prefix+name, tbl.RawGetString(name), errs)
+	}
+	return errs
+}
+
+// validate checks a single value, stopping at the first failing rule, then
+// descends into nested fields and array items. Missing optional values are
+// not checked further.
+func (f *field) validate(L *lua.LState, path string, value lua.LValue, errs []fieldError) []fieldError {
+	if value == lua.LNil || isBlankString(value) {
+		if f.required {
+			return append(errs, f.newError(path, "required", nil))
+		}
+		if value == lua.LNil {
+			return errs
+		}
+	}
+	if f.omitEmpty && isEmptyValue(value) {
+		return errs
+	}
+
+	for _, r := range f.rules {
+		if ok, template := r.run(L, value); !ok {
+			e := f.newError(path, r.nameFor(value), r.params)
+			if template != "" {
+				e.template = template
+			}
+			return append(errs, e)
+		}
+	}
+
+	if f.fields != nil {
+		errs = f.fields.validateFields(L, path+".", value.(*lua.LTable), errs)
+	}
+	if f.items != nil {
+		tbl := value.(*lua.LTable)
+		for i := 1; i <= tbl.Len(); i++ {
+			errs = f.items.validate(L, fmt.Sprintf("%s[%d]", path, i), tbl.RawGetInt(i), errs)
+		}
+	}
+	return errs
+}
+
+// isEmptyValue reports whether value is the zero value of its type: an
+// empty string, 0, false or a table without entries.
+func isEmptyValue(value lua.LValue) bool {
+	switch v := value.(type) {
+	case lua.LString:
+		return v == ""
+	case lua.LNumber:
+		return v == 0
+	case lua.LBool:
+		return !bool(v)
+	case *lua.LTable:
+		key, _ := v.Next(lua.LNil)
+		return key == lua.LNil
+	}
+	return false
+}
+
+func isBlankString(value lua.LValue) bool {
+	str, ok := value.(lua.LString)
+	return ok && strings.TrimSpace(string(str)) == ""
+}
+
+// newError builds the error for a failed rule, picking up a custom template
+// registered on the field under the rule name ("min_length") or the option
+// that produced it ("min").
+func (f *field) newError(path, ruleName string, params map[string]string) fieldError {
+	values := map[string]string{"field": path}
+	for name, value := range params {
+		values[name] = value
+	}
+
+	template, ok := f.messages[ruleName]
+	if !ok {
+		template, ok = f.messages[ruleOption(ruleName)]
+	}
+	if !ok {
+		template = f.message
+	}
+	return fieldError{path: path, rule: ruleName, params: values, template: template}
+}
+
+// ruleOption returns the field option a rule name was derived from.
+func ruleOption(ruleName string) string {
+	for _, suffix := range []string{"_length", "_items"} {
+		if strings.HasSuffix(ruleName, suffix) {
+			return strings.TrimSuffix(ruleName, suffix)
+		}
+	}
+	return ruleName
+}
+
+// message renders the error, using the field's custom template if it has one
+// and the active locale of L otherwise.
+func (e fieldError) message(L *lua.LState) string {
+	return e.render(stateOf(L))
+}
+
+// render renders the error with the custom template or the messages of st.
+func (e fieldError) render(st *moduleState) string {
+	if e.template != "" {
+		return formatMessage(e.template, e.params)
+	}
+	return st.message(e.rule, e.params)
+}
+
+// registerSchemaType installs the metatable shared by schema userdata.
+func registerSchemaType(L *lua.LState) {
+	mt := L.NewTypeMetatable(schemaTypeName)
+	L.SetField(mt, "__index", L.SetFuncs(L.NewTable(), schemaMethods))
+}
+
+var schemaMethods = map[string]lua.LGFunction{
+	"validate":       schemaValidate,
+	"to_json_schema": schemaToJSONSchema,
+}
+
+// newSchema compiles a table of field definitions into a reusable schema
+// Usage: validation.schema({name={type="string", required=true, min=3}, ...}) -> schema
+func newSchema(L *lua.LState) int {
+	def := L.CheckTable(1)
+
+	s, err := compileSchema(L, def)
+	if err != nil {
+		L.ArgError(1, err.Error())
+	}
+	pushSchema(L, s)
+	return 1
+}
+
+func pushSchema(L *lua.LState, s *schema) {
+	ud := L.NewUserData()
+	ud.Value = s
+	L.SetMetatable(ud, L.GetTypeMetatable(schemaTypeName))
+	L.Push(ud)
+}
+
+func checkSchema(L *lua.LState, n int) *schema {
+	ud := L.CheckUserData(n)
+	if s, ok := ud.Value.(*schema); ok {
+		return s
+	}
+	L.ArgError(n, "schema expected")
+	return nil
+}
+
+// schemaValidate validates a table against the schema
+// Usage: schema:validate(tbl) -> boolean, table
+func schemaValidate(L *lua.LState) int {
+	s := checkSchema(L, 1)
+	tbl := L.CheckTable(2)
+
+	errs := s.validate(L, tbl)
+	L.Push(lua.LBool(len(errs) == 0))
+	L.Push(fieldErrorsTable(L, errs))
+	return 2
+}
+
+// fieldErrorsTable converts field errors to a table mapping paths to messages.
+func fieldErrorsTable(L *lua.LState, errs []fieldError) *lua.LTable {
+	tbl := L.NewTable()
+	for _, e := range errs {
+		tbl.RawSetString(e.path, lua.LString(e.message(L)))
+	}
+	return tbl
+}
@@ -0,0 +1,229 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestSchemaValidate(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local user = validation.schema({
+			name = {type="string", required=true, min=3, max=64},
+			email = {type="string", required=true, format="email"},
+			age = {type="integer", min=18, max=120},
+			role = {type="string", one_of={"admin", "member"}},
+			nickname = {type="string", pattern="^[a-z]+$"},
+		})
+		return user:validate({name="Ada", email="ada@example.com", age=36, role="admin"})
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("Schema validate test failed: %v", err)
+	}
+
+	ok := L.Get(-2).(lua.LBool)
+	errors := L.Get(-1).(*lua.LTable)
+
+	if !bool(ok) {
+		t.Error("Expected true for valid table")
+	}
+	if errors.Len() != 0 || errors.RawGetString("name") != lua.LNil {
+		t.Error("Expected no errors for valid table")
+	}
+}
+
+func TestSchemaValidateErrors(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local user = validation.schema({
+			name = {type="string", required=true, min=3},
+			email = {type="string", required=true, format="email"},
+			age = {type="integer", min=18},
+			role = {type="string", one_of={"admin", "member"}},
+			nickname = {type="string", pattern="^[a-z]+$"},
+			bio = {type="string"},
+		})
+		return user:validate({name="Al", age=12.5, role="owner", nickname="Bad1", bio="  "})
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("Schema validate errors test failed: %v", err)
+	}
+
+	ok := L.Get(-2).(lua.LBool)
+	errors := L.Get(-1).(*lua.LTable)
+
+	if bool(ok) {
+		t.Error("Expected false for invalid table")
+	}
+
+	expected := map[string]string{
+		"name":     "name must be at least 3 characters",
+		"email":    "email is required",
+		"age":      "age must be of type integer",
+		"role":     "role must be one of admin, member",
+		"nickname": "nickname must match the pattern ^[a-z]+$",
+		"bio":      "",
+	}
+	for field, message := range expected {
+		got := errors.RawGetString(field)
+		if message == "" {
+			if got != lua.LNil {
+				t.Errorf("Expected no error for %s, got %v", field, got)
+			}
+			continue
+		}
+		if got.String() != message {
+			t.Errorf("Expected %q for %s, got %v", message, field, got)
+		}
+	}
+}
+
+func TestSchemaInvalidDefinition(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		name     string
+		def      string
+		expected string
+	}{
+		{"unknown type", `{name={type="text"}}`, `field "name": unknown type "text"`},
+		{"unknown option", `{name={type="string", minimum=3}}`, `field "name": unknown option "minimum"`},
+		{"min without type", `{name={min=3}}`, `field "name": min requires type`},
+		{"invalid pattern", `{name={type="string", pattern="[x"}}`, `field "name": invalid pattern`},
+		{"unknown format", `{name={type="string", format="zip"}}`, `field "name": unknown format "zip"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script := `
+				local validation = require("validation")
+				return validation.schema(` + tt.def + `)
+			`
+
+			err := L.DoString(script)
+			if err == nil {
+				t.Fatal("Expected error for invalid schema definition")
+			}
+			if !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestSchemaNested(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local order = validation.schema({
+			user = {type="table", required=true, fields={
+				name = {type="string", required=true},
+				address = {fields={
+					zip = {type="string", required=true, pattern="^[0-9]{5}$"},
+				}},
+			}},
+			items = {type="array", min=1, items={fields={
+				sku = {type="string", required=true},
+				price = {type="number", required=true, min=0},
+			}}},
+			tags = {items={type="string"}},
+		})
+		return order:validate({
+			user = {name="Ada", address={zip="1234"}},
+			items = {
+				{sku="A-1", price=10},
+				{sku="A-2", price=5},
+				{sku="A-3", price=-1},
+			},
+			tags = {"new", 42},
+		})
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("Schema nested test failed: %v", err)
+	}
+
+	ok := L.Get(-2).(lua.LBool)
+	errors := L.Get(-1).(*lua.LTable)
+
+	if bool(ok) {
+		t.Error("Expected false for invalid nested table")
+	}
+
+	expected := map[string]string{
+		"user.address.zip": "user.address.zip must match the pattern ^[0-9]{5}$",
+		"items[3].price":   "items[3].price must be at least 0",
+		"tags[2]":          "tags[2] must be of type string",
+	}
+	count := 0
+	errors.ForEach(func(key, value lua.LValue) {
+		count++
+		if expected[key.String()] != value.String() {
+			t.Errorf("Unexpected error %s = %v", key, value)
+		}
+	})
+	if count != len(expected) {
+		t.Errorf("Expected %d errors, got %d", len(expected), count)
+	}
+}
+
+func TestSchemaArrayBounds(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local s = validation.schema({
+			items = {type="array", min=1, max=2},
+		})
+		local _, empty = s:validate({items={}})
+		local _, notArray = s:validate({items={a=1}})
+		local ok = s:validate({items={1, 2}})
+		return empty.items, notArray.items, ok
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("Schema array bounds test failed: %v", err)
+	}
+
+	empty := L.Get(-3).String()
+	notArray := L.Get(-2).String()
+	ok := L.Get(-1).(lua.LBool)
+
+	if empty != "items must have at least 1 items" {
+		t.Errorf("Unexpected error for empty array: %q", empty)
+	}
+	if notArray != "items must be of type array" {
+		t.Errorf("Unexpected error for map: %q", notArray)
+	}
+	if !bool(ok) {
+		t.Error("Expected true for array with 2 items")
+	}
+}
@@ -0,0 +1,47 @@
+package validation
+
+import (
+	"fmt"
+	"sync"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+var (
+	sharedSchemasMu sync.RWMutex
+	sharedSchemas   = map[string]*schema{}
+)
+
+// RegisterSchema shares a schema compiled with CompileSchema with every Lua
+// state under name, where scripts load it with validation.load_schema(name).
+// Compiled schemas are immutable, so one copy serves all states; messages are
+// still rendered in the locale of the state that validates. Registering a
+// name again replaces the schema for later loads.
+func RegisterSchema(name string, s Schema) error {
+	if name == "" {
+		return fmt.Errorf("schema name must not be empty")
+	}
+	if s.s == nil {
+		return fmt.Errorf("schema %q: schema must be compiled with CompileSchema", name)
+	}
+
+	sharedSchemasMu.Lock()
+	defer sharedSchemasMu.Unlock()
+	sharedSchemas[name] = s.s
+	return nil
+}
+
+// loadSchema returns a schema registered from Go
+// Usage: validation.load_schema(name) -> schema
+func loadSchema(L *lua.LState) int {
+	name := L.CheckString(1)
+
+	sharedSchemasMu.RLock()
+	s, ok := sharedSchemas[name]
+	sharedSchemasMu.RUnlock()
+	if !ok {
+		L.ArgError(1, fmt.Sprintf("unknown schema %q", name))
+	}
+	pushSchema(L, s)
+	return 1
+}
@@ -0,0 +1,69 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestLoadSchema(t *testing.T) {
+	s, err := CompileSchema(map[string]any{
+		"email": "required|email",
+		"name":  "required|string|min:2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterSchema("test_user", s); err != nil {
+		t.Fatal(err)
+	}
+
+	script := `
+		local validation = require("validation")
+		local user = validation.load_schema("test_user")
+		local ok = user:validate({email="ada@example.com", name="Ada"})
+		local _, errors = user:validate({name="A"})
+		return ok, errors.email, errors.name
+	`
+
+	for _, locale := range []string{"en", "de"} {
+		L := lua.NewState()
+		L.PreloadModule("validation", Loader)
+		L.DoString(`require("validation").register_messages("de", {required = "{field} ist erforderlich"})`)
+		L.DoString(`require("validation").set_locale("` + locale + `")`)
+
+		if err := L.DoString(script); err != nil {
+			t.Fatalf("LoadSchema test failed: %v", err)
+		}
+		if ok := L.Get(-3).(lua.LBool); !bool(ok) {
+			t.Error("Expected true for valid table")
+		}
+		required := map[string]string{"en": "email is required", "de": "email ist erforderlich"}[locale]
+		if got := L.Get(-2).String(); got != required {
+			t.Errorf("Expected %q, got %q", required, got)
+		}
+		if got := L.Get(-1).String(); got != "name must be at least 2 characters" {
+			t.Errorf("Expected min length message, got %q", got)
+		}
+		L.Close()
+	}
+}
+
+func TestLoadSchemaErrors(t *testing.T) {
+	if err := RegisterSchema("", Schema{}); err == nil {
+		t.Error("Expected error for empty name")
+	}
+	if err := RegisterSchema("empty", Schema{}); err == nil {
+		t.Error("Expected error for uncompiled schema")
+	}
+
+	L := lua.NewState()
+	defer L.Close()
+	L.PreloadModule("validation", Loader)
+
+	err := L.DoString(`require("validation").load_schema("missing")`)
+	if err == nil || !strings.Contains(err.Error(), `unknown schema "missing"`) {
+		t.Errorf("Expected unknown schema error, got %v", err)
+	}
+}
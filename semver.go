@@ -0,0 +1,531 @@
+package validation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// semver is a parsed semantic version as defined by SemVer 2.0.0.
+// Build metadata is kept for completeness but ignored for precedence.
+type semver struct {
+	major, minor, patch uint64
+	prerelease          []string
+	build               []string
+}
+
+// parseSemver parses a version string using the full SemVer 2.0.0 grammar.
+func parseSemver(s string) (semver, error) {
+	var v semver
+
+	rest := s
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		build := rest[i+1:]
+		rest = rest[:i]
+		ids, err := splitIdentifiers(build, false)
+		if err != nil {
+			return v, fmt.Errorf("invalid build metadata in %q: %v", s, err)
+		}
+		v.build = ids
+	}
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		pre := rest[i+1:]
+		rest = rest[:i]
+		ids, err := splitIdentifiers(pre, true)
+		if err != nil {
+			return v, fmt.Errorf("invalid prerelease in %q: %v", s, err)
+		}
+		v.prerelease = ids
+	}
+
+	parts := strings.Split(rest, ".")
+	if len(parts) != 3 {
+		return v, fmt.Errorf("invalid semantic version %q: expected MAJOR.MINOR.PATCH", s)
+	}
+	nums := make([]uint64, 3)
+	for i, part := range parts {
+		if !isNumericIdentifier(part) {
+			return v, fmt.Errorf("invalid semantic version %q: %q is not a valid number", s, part)
+		}
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return v, fmt.Errorf("invalid semantic version %q: %v", s, err)
+		}
+		nums[i] = n
+	}
+	v.major, v.minor, v.patch = nums[0], nums[1], nums[2]
+	return v, nil
+}
+
+// splitIdentifiers splits dot-separated prerelease or build identifiers.
+// Prerelease identifiers additionally forbid leading zeros in numeric parts.
+func splitIdentifiers(s string, prerelease bool) ([]string, error) {
+	ids := strings.Split(s, ".")
+	for _, id := range ids {
+		if id == "" {
+			return nil, fmt.Errorf("empty identifier")
+		}
+		for _, r := range id {
+			if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '-') {
+				return nil, fmt.Errorf("invalid character %q in identifier %q", r, id)
+			}
+		}
+		if prerelease && isDigits(id) && !isNumericIdentifier(id) {
+			return nil, fmt.Errorf("numeric identifier %q has a leading zero", id)
+		}
+	}
+	return ids, nil
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isNumericIdentifier reports whether s is digits without a leading zero.
+func isNumericIdentifier(s string) bool {
+	return isDigits(s) && (s == "0" || s[0] != '0')
+}
+
+// compare returns -1, 0 or 1 depending on the precedence of v and o.
+func (v semver) compare(o semver) int {
+	if c := compareUint(v.major, o.major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.minor, o.minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.patch, o.patch); c != 0 {
+		return c
+	}
+
+	// A version without prerelease has higher precedence than one with it.
+	switch {
+	case len(v.prerelease) == 0 && len(o.prerelease) == 0:
+		return 0
+	case len(v.prerelease) == 0:
+		return 1
+	case len(o.prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.prerelease) && i < len(o.prerelease); i++ {
+		a, b := v.prerelease[i], o.prerelease[i]
+		aNum, bNum := isDigits(a), isDigits(b)
+		switch {
+		case aNum && bNum:
+			an, _ := strconv.ParseUint(a, 10, 64)
+			bn, _ := strconv.ParseUint(b, 10, 64)
+			if c := compareUint(an, bn); c != 0 {
+				return c
+			}
+		case aNum:
+			return -1
+		case bNum:
+			return 1
+		default:
+			if c := strings.Compare(a, b); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareUint(uint64(len(v.prerelease)), uint64(len(o.prerelease)))
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// IsSemver reports whether s is a semantic version under the full SemVer
+// 2.0.0 grammar, such as "1.4.2", "1.0.0-rc.1" or "2.0.0+build.5".
+func IsSemver(s string) bool {
+	_, err := parseSemver(s)
+	return err == nil
+}
+
+// isSemver checks if a string is a valid semantic version
+// Usage: validation.is_semver(str) -> boolean
+func isSemver(L *lua.LState) int {
+	str := L.CheckString(1)
+	L.Push(lua.LBool(IsSemver(str)))
+	return 1
+}
+
+// semversSorted checks if an array of version strings is in semver precedence order.
+// On failure it returns the index of the first offending entry; invalid versions
+// additionally return an error message.
+// Usage: validation.semvers_sorted(values, {descending=false}) -> boolean, index?, error?
+func semversSorted(L *lua.LState) int {
+	values := L.CheckTable(1)
+	opts := L.OptTable(2, L.NewTable())
+	descending := lua.LVAsBool(opts.RawGetString("descending"))
+
+	var prev semver
+	for i := 1; i <= values.Len(); i++ {
+		str, ok := values.RawGetInt(i).(lua.LString)
+		if !ok {
+			L.Push(lua.LBool(false))
+			L.Push(lua.LNumber(i))
+			L.Push(lua.LString(fmt.Sprintf("value %d is not a string", i)))
+			return 3
+		}
+		v, err := parseSemver(string(str))
+		if err != nil {
+			L.Push(lua.LBool(false))
+			L.Push(lua.LNumber(i))
+			L.Push(lua.LString(err.Error()))
+			return 3
+		}
+		if i > 1 {
+			c := prev.compare(v)
+			if (!descending && c > 0) || (descending && c < 0) {
+				L.Push(lua.LBool(false))
+				L.Push(lua.LNumber(i))
+				return 2
+			}
+		}
+		prev = v
+	}
+
+	L.Push(lua.LBool(true))
+	return 1
+}
+
+// semverComparator is a single comparison such as ">=1.2.0".
+type semverComparator struct {
+	op string // one of "=", "<", "<=", ">", ">="
+	v  semver
+}
+
+func (c semverComparator) matches(v semver) bool {
+	cmp := v.compare(c.v)
+	switch c.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return cmp == 0
+}
+
+// semverConstraint is a list of alternative comparator sets. A version
+// satisfies it when it matches every comparator of at least one set.
+type semverConstraint [][]semverComparator
+
+// partialSemver is a version in a constraint where trailing components may
+// be left out or written as x, X or *. n counts the numeric components.
+type partialSemver struct {
+	major, minor, patch uint64
+	prerelease          []string
+	n                   int
+}
+
+var (
+	// anyVersion matches every release.
+	anyVersion = semverComparator{op: ">=", v: semver{}}
+	// noVersion matches nothing: 0.0.0-0 is the lowest version there is.
+	noVersion = semverComparator{op: "<", v: semver{prerelease: []string{"0"}}}
+)
+
+// parseSemverConstraint parses a constraint in the npm range syntax:
+// comparators (=, <, <=, >, >=) joined by spaces or commas, alternatives
+// joined by ||, caret (^1.4) and tilde (~1.4.2) ranges, x-ranges (1.x) and
+// hyphen ranges (1.2 - 1.4).
+func parseSemverConstraint(s string) (semverConstraint, error) {
+	var c semverConstraint
+	for _, alt := range strings.Split(s, "||") {
+		set, err := parseComparatorSet(alt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid constraint %q: %v", s, err)
+		}
+		c = append(c, set)
+	}
+	return c, nil
+}
+
+func parseComparatorSet(s string) ([]semverComparator, error) {
+	// Join operators written apart from their version, as in ">= 1.2".
+	tokens := strings.Fields(strings.ReplaceAll(s, ",", " "))
+	var terms []string
+	for i := 0; i < len(tokens); i++ {
+		term := tokens[i]
+		if strings.Trim(term, "<>=~^") == "" && i+1 < len(tokens) {
+			term += tokens[i+1]
+			i++
+		}
+		terms = append(terms, term)
+	}
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("empty comparator set")
+	}
+
+	var set []semverComparator
+	for i := 0; i < len(terms); i++ {
+		if i+2 < len(terms) && terms[i+1] == "-" {
+			cmps, err := parseHyphenRange(terms[i], terms[i+2])
+			if err != nil {
+				return nil, err
+			}
+			set = append(set, cmps...)
+			i += 2
+			continue
+		}
+		cmps, err := parseComparatorTerm(terms[i])
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, cmps...)
+	}
+	return set, nil
+}
+
+func parseComparatorTerm(term string) ([]semverComparator, error) {
+	op := term[:len(term)-len(strings.TrimLeft(term, "<>=~^"))]
+	p, err := parsePartialSemver(term[len(op):])
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case "", "=", "==":
+		if p.n == 3 {
+			return []semverComparator{{op: "=", v: p.lower()}}, nil
+		}
+		return p.upTo(p.n), nil
+	case "^":
+		switch {
+		case p.n == 0:
+			return p.upTo(0), nil
+		case p.major > 0 || p.n == 1:
+			return p.upTo(1), nil
+		case p.minor > 0 || p.n == 2:
+			return p.upTo(2), nil
+		}
+		return p.upTo(3), nil
+	case "~":
+		return p.upTo(min(p.n, 2)), nil
+	case ">":
+		switch p.n {
+		case 0:
+			return []semverComparator{noVersion}, nil
+		case 3:
+			return []semverComparator{{op: ">", v: p.lower()}}, nil
+		}
+		v := p.next(p.n)
+		v.prerelease = nil
+		return []semverComparator{{op: ">=", v: v}}, nil
+	case ">=":
+		return []semverComparator{{op: ">=", v: p.lower()}}, nil
+	case "<":
+		if p.n == 0 {
+			return []semverComparator{noVersion}, nil
+		}
+		v := p.lower()
+		if p.n < 3 {
+			v.prerelease = []string{"0"}
+		}
+		return []semverComparator{{op: "<", v: v}}, nil
+	case "<=":
+		switch p.n {
+		case 0:
+			return []semverComparator{anyVersion}, nil
+		case 3:
+			return []semverComparator{{op: "<=", v: p.lower()}}, nil
+		}
+		return []semverComparator{{op: "<", v: p.next(p.n)}}, nil
+	}
+	return nil, fmt.Errorf("unknown operator %q", op)
+}
+
+// parseHyphenRange expands "a - b" into an inclusive range. A partial upper
+// bound includes every version it covers, so "1.2 - 1.4" allows 1.4.9.
+func parseHyphenRange(from, to string) ([]semverComparator, error) {
+	lo, err := parsePartialSemver(from)
+	if err != nil {
+		return nil, err
+	}
+	hi, err := parsePartialSemver(to)
+	if err != nil {
+		return nil, err
+	}
+	set := []semverComparator{{op: ">=", v: lo.lower()}}
+	switch hi.n {
+	case 0:
+	case 3:
+		set = append(set, semverComparator{op: "<=", v: hi.lower()})
+	default:
+		set = append(set, semverComparator{op: "<", v: hi.next(hi.n)})
+	}
+	return set, nil
+}
+
+func parsePartialSemver(s string) (partialSemver, error) {
+	var p partialSemver
+	if s == "" {
+		return p, fmt.Errorf("missing version")
+	}
+	if s == "*" || s == "x" || s == "X" {
+		return p, nil
+	}
+
+	core := s
+	if i := strings.IndexByte(core, '+'); i >= 0 {
+		if _, err := splitIdentifiers(core[i+1:], false); err != nil {
+			return p, fmt.Errorf("invalid build metadata in %q: %v", s, err)
+		}
+		core = core[:i]
+	}
+	if i := strings.IndexByte(core, '-'); i >= 0 {
+		ids, err := splitIdentifiers(core[i+1:], true)
+		if err != nil {
+			return p, fmt.Errorf("invalid prerelease in %q: %v", s, err)
+		}
+		p.prerelease = ids
+		core = core[:i]
+	}
+
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return p, fmt.Errorf("invalid version %q", s)
+	}
+	nums := []*uint64{&p.major, &p.minor, &p.patch}
+	for i, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			// Everything after a wildcard must be a wildcard too.
+			for _, rest := range parts[i+1:] {
+				if rest != "x" && rest != "X" && rest != "*" {
+					return p, fmt.Errorf("invalid version %q", s)
+				}
+			}
+			break
+		}
+		if !isNumericIdentifier(part) {
+			return p, fmt.Errorf("invalid version %q: %q is not a valid number", s, part)
+		}
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return p, fmt.Errorf("invalid version %q: %v", s, err)
+		}
+		*nums[i] = n
+		p.n++
+	}
+	if p.prerelease != nil && p.n < 3 {
+		return p, fmt.Errorf("invalid version %q: prerelease needs a full version", s)
+	}
+	return p, nil
+}
+
+// lower returns the lowest version p covers.
+func (p partialSemver) lower() semver {
+	return semver{major: p.major, minor: p.minor, patch: p.patch, prerelease: p.prerelease}
+}
+
+// next returns the lowest version above everything that matches the first
+// n components of p, as a -0 prerelease so that it excludes prereleases of
+// the next release too.
+func (p partialSemver) next(n int) semver {
+	v := semver{major: p.major, minor: p.minor, patch: p.patch, prerelease: []string{"0"}}
+	switch n {
+	case 1:
+		v.major, v.minor, v.patch = v.major+1, 0, 0
+	case 2:
+		v.minor, v.patch = v.minor+1, 0
+	default:
+		v.patch++
+	}
+	return v
+}
+
+// upTo returns the range from p up to, but excluding, the next version that
+// differs in the first n components. n of zero matches every version.
+func (p partialSemver) upTo(n int) []semverComparator {
+	if n == 0 {
+		return []semverComparator{anyVersion}
+	}
+	return []semverComparator{{op: ">=", v: p.lower()}, {op: "<", v: p.next(n)}}
+}
+
+// satisfiedBy reports whether v matches the constraint. As in npm, a
+// prerelease version only matches a comparator set that names a
+// prerelease of the same MAJOR.MINOR.PATCH, so "^1.4" does not admit
+// 1.5.0-beta while ">=1.5.0-alpha" does.
+func (c semverConstraint) satisfiedBy(v semver) bool {
+	for _, set := range c {
+		if comparatorSetMatches(set, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func comparatorSetMatches(set []semverComparator, v semver) bool {
+	for _, cmp := range set {
+		if !cmp.matches(v) {
+			return false
+		}
+	}
+	if len(v.prerelease) == 0 {
+		return true
+	}
+	for _, cmp := range set {
+		if len(cmp.v.prerelease) > 0 && cmp.v.major == v.major && cmp.v.minor == v.minor && cmp.v.patch == v.patch {
+			return true
+		}
+	}
+	return false
+}
+
+// SemverSatisfies reports whether version satisfies constraint, such as
+// ">=1.2.0 <2.0.0" or "^1.4". It returns an error if either is malformed.
+func SemverSatisfies(version, constraint string) (bool, error) {
+	c, err := parseSemverConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+	v, err := parseSemver(version)
+	if err != nil {
+		return false, err
+	}
+	return c.satisfiedBy(v), nil
+}
+
+// semverSatisfies checks if a version satisfies a constraint such as ">=1.2.0 <2.0.0" or "^1.4".
+// A malformed constraint raises an error; a malformed version returns false and a message.
+// Usage: validation.semver_satisfies(version, constraint) -> boolean, error?
+func semverSatisfies(L *lua.LState) int {
+	version := L.CheckString(1)
+	c, err := parseSemverConstraint(L.CheckString(2))
+	if err != nil {
+		L.ArgError(2, err.Error())
+		return 0
+	}
+
+	v, err := parseSemver(version)
+	if err != nil {
+		L.Push(lua.LBool(false))
+		L.Push(lua.LString(err.Error()))
+		return 2
+	}
+	L.Push(lua.LBool(c.satisfiedBy(v)))
+	return 1
+}
@@ -0,0 +1,237 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestSemversSorted(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local ascending = {"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-beta", "1.0.0", "1.2.0", "2.0.0+build.5"}
+		local descending = {"2.0.0", "1.10.0", "1.9.1", "1.9.1-rc.1"}
+		return validation.semvers_sorted(ascending), validation.semvers_sorted(descending, {descending=true})
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("SemversSorted test failed: %v", err)
+	}
+
+	result1 := L.Get(-2).(lua.LBool)
+	result2 := L.Get(-1).(lua.LBool)
+
+	if !bool(result1) {
+		t.Error("Expected true for ascending list")
+	}
+	if !bool(result2) {
+		t.Error("Expected true for descending list with descending=true")
+	}
+}
+
+func TestSemversSortedOutOfOrder(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local ok, index, err = validation.semvers_sorted({"1.0.0", "1.2.0", "1.1.0", "1.3.0"})
+		return ok, index, err
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("SemversSorted out of order test failed: %v", err)
+	}
+
+	ok := L.Get(-3).(lua.LBool)
+	index := L.Get(-2)
+	errVal := L.Get(-1)
+
+	if bool(ok) {
+		t.Error("Expected false for out-of-order list")
+	}
+	if index != lua.LNumber(3) {
+		t.Errorf("Expected index 3, got %v", index)
+	}
+	if errVal != lua.LNil {
+		t.Errorf("Expected no error for ordering failure, got %v", errVal)
+	}
+}
+
+func TestSemversSortedInvalidVersion(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local ok, index, err = validation.semvers_sorted({"1.0.0", "1.01.0", "0.1.0"})
+		return ok, index, err
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("SemversSorted invalid version test failed: %v", err)
+	}
+
+	ok := L.Get(-3).(lua.LBool)
+	index := L.Get(-2)
+	errVal := L.Get(-1)
+
+	if bool(ok) {
+		t.Error("Expected false for list with invalid version")
+	}
+	if index != lua.LNumber(2) {
+		t.Errorf("Expected index 2, got %v", index)
+	}
+	if errVal == lua.LNil {
+		t.Error("Expected error message for invalid version")
+	}
+}
+
+func TestIsSemver(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"0.0.0", true},
+		{"1.2.3", true},
+		{"10.20.30", true},
+		{"1.0.0-alpha", true},
+		{"1.0.0-alpha.1", true},
+		{"1.0.0-0.3.7", true},
+		{"1.0.0-x-y-z.--", true},
+		{"1.0.0+20130313144700", true},
+		{"1.0.0-beta+exp.sha.5114f85", true},
+		{"1.0.0+21AF26D3----117B344092BD", true},
+		{"1.0.0+001", true},
+		{"1.2", false},
+		{"1.2.3.4", false},
+		{"v1.2.3", false},
+		{"01.2.3", false},
+		{"1.2.3-01", false},
+		{"1.2.3-", false},
+		{"1.2.3+", false},
+		{"1.2.3-alpha..1", false},
+		{"1.2.3-alpha_1", false},
+		{" 1.2.3", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.is_semver("` + tt.input + `")
+		`); err != nil {
+			t.Fatalf("is_semver(%q): %v", tt.input, err)
+		}
+		if got := L.Get(-1) == lua.LTrue; got != tt.expected {
+			t.Errorf("is_semver(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+		L.Pop(1)
+	}
+}
+
+func TestSemverSatisfies(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		version, constraint string
+		expected            bool
+	}{
+		{"1.5.0", ">=1.2.0 <2.0.0", true},
+		{"2.0.0", ">=1.2.0 <2.0.0", false},
+		{"1.1.9", ">=1.2.0, <2.0.0", false},
+		{"1.5.0", ">= 1.2.0 < 2.0.0", true},
+		{"1.9.3", "^1.4", true},
+		{"1.3.0", "^1.4", false},
+		{"2.0.0", "^1.4", false},
+		{"0.2.9", "^0.2.3", true},
+		{"0.3.0", "^0.2.3", false},
+		{"0.0.4", "^0.0.3", false},
+		{"1.2.9", "~1.2.3", true},
+		{"1.3.0", "~1.2.3", false},
+		{"1.9.0", "~1", true},
+		{"1.2.3", "1.2.3", true},
+		{"1.2.3+build.7", "=1.2.3", true},
+		{"1.2.4", "1.2.3", false},
+		{"1.7.2", "1.x", true},
+		{"2.0.0", "1.*", false},
+		{"9.9.9", "*", true},
+		{"1.3.0", ">1.2", true},
+		{"1.2.9", ">1.2", false},
+		{"1.2.9", "<=1.2", true},
+		{"1.3.0", "<=1.2", false},
+		{"1.1.0", "<1.2", true},
+		{"1.4.9", "1.2 - 1.4", true},
+		{"1.5.0", "1.2 - 1.4", false},
+		{"2.3.4", "1.2.3 - 2.3.4", true},
+		{"3.1.0", "^1.4 || ^3.0", true},
+		{"2.1.0", "^1.4 || ^3.0", false},
+		{"1.5.0-beta", "^1.4", false},
+		{"2.0.0-rc.1", "<2.0.0", false},
+		{"1.5.0-beta", ">=1.5.0-alpha", true},
+		{"1.2.3-beta.4", "^1.2.3-beta.2", true},
+		{"1.3.0-beta", ">1.2", false},
+	}
+
+	for _, tt := range tests {
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.semver_satisfies("` + tt.version + `", "` + tt.constraint + `")
+		`); err != nil {
+			t.Fatalf("semver_satisfies(%q, %q): %v", tt.version, tt.constraint, err)
+		}
+		if got := L.Get(-1) == lua.LTrue; got != tt.expected {
+			t.Errorf("semver_satisfies(%q, %q) = %v, want %v", tt.version, tt.constraint, got, tt.expected)
+		}
+		L.Pop(1)
+	}
+}
+
+func TestSemverSatisfiesErrors(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	if err := L.DoString(`
+		local validation = require("validation")
+		local ok, err = validation.semver_satisfies("1.2", "^1.0")
+		return ok, err
+	`); err != nil {
+		t.Fatalf("semver_satisfies with invalid version failed: %v", err)
+	}
+	if L.Get(-2) != lua.LFalse || L.Get(-1).Type() != lua.LTString {
+		t.Errorf("expected false and an error message for an invalid version, got %v, %v", L.Get(-2), L.Get(-1))
+	}
+	L.Pop(2)
+
+	for _, constraint := range []string{"", ">=", "=>1.0.0", "1.x.3", "1.2-beta", "^1.4 ||"} {
+		err := L.DoString(`
+			local validation = require("validation")
+			return validation.semver_satisfies("1.0.0", "` + constraint + `")
+		`)
+		if err == nil {
+			t.Errorf("expected an error for constraint %q", constraint)
+		}
+	}
+}
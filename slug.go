@@ -0,0 +1,59 @@
+package validation
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// IsSlug reports whether s is a URL slug: words of lower-case letters and
+// digits joined by single hyphens. When allowUnicode is set, letters of any
+// script without upper-case forms are accepted, as are combining marks
+// following a letter or digit.
+func IsSlug(s string, allowUnicode bool) bool {
+	if s == "" || !IsUTF8(s) {
+		return false
+	}
+	prev := '-'
+	for _, r := range s {
+		switch {
+		case r == '-':
+			if prev == '-' {
+				return false
+			}
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+		case !allowUnicode || r < utf8.RuneSelf:
+			return false
+		case unicode.IsMark(r):
+			if prev == '-' {
+				return false
+			}
+		case unicode.IsLetter(r) && !unicode.IsUpper(r) && !unicode.IsTitle(r), unicode.IsDigit(r):
+		default:
+			return false
+		}
+		prev = r
+	}
+	return prev != '-'
+}
+
+// isSlug checks if a string is a URL slug of at most max_len characters
+// Usage: validation.is_slug(str, {max_len=nil, allow_unicode=false}) -> boolean
+func isSlug(L *lua.LState) int {
+	str := L.CheckString(1)
+	opts := L.OptTable(2, L.NewTable())
+
+	maxLen := 0
+	if v, ok := opts.RawGetString("max_len").(lua.LNumber); ok {
+		if maxLen = int(v); maxLen <= 0 {
+			L.ArgError(2, fmt.Sprintf("max_len must be positive, got %v", v))
+		}
+	}
+	allowUnicode := lua.LVAsBool(opts.RawGetString("allow_unicode"))
+
+	valid := IsSlug(str, allowUnicode) && (maxLen == 0 || utf8.RuneCountInString(str) <= maxLen)
+	L.Push(lua.LBool(valid))
+	return 1
+}
@@ -0,0 +1,85 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsSlug(t *testing.T) {
+	tests := []struct {
+		input        string
+		allowUnicode bool
+		expected     bool
+	}{
+		{"hello-world", false, true},
+		{"post-2024", false, true},
+		{"a", false, true},
+		{"404", false, true},
+		{"", false, false},
+		{"-hello", false, false},
+		{"hello-", false, false},
+		{"hello--world", false, false},
+		{"Hello-World", false, false},
+		{"hello_world", false, false},
+		{"hello world", false, false},
+		{"caf\u00e9", false, false},
+		{"caf\u00e9", true, true},
+		{"caf\u00e9-au-lait", true, true},
+		{"\u0301cafe", true, false},
+		{"hello-\u0301", true, false},
+		{"日本語-2024", true, true},
+		{"привет", true, true},
+		{"Привет", true, false},
+		{"hello_world", true, false},
+		{"hello world", true, false},
+		{"bad\xff", true, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsSlug(tt.input, tt.allowUnicode); got != tt.expected {
+			t.Errorf("IsSlug(%q, %v) = %v, want %v", tt.input, tt.allowUnicode, got, tt.expected)
+		}
+	}
+}
+
+func TestIsSlugLua(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		call     string
+		expected bool
+	}{
+		{`is_slug("my-first-post")`, true},
+		{`is_slug("My-First-Post")`, false},
+		{`is_slug("my-first-post", {max_len = 13})`, true},
+		{`is_slug("my-first-post", {max_len = 12})`, false},
+		{`is_slug("caf\195\169", {allow_unicode = true, max_len = 4})`, true},
+		{`is_slug("caf\195\169", {allow_unicode = true, max_len = 3})`, false},
+	}
+
+	for _, tt := range tests {
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.` + tt.call + `
+		`); err != nil {
+			t.Fatalf("%s: %v", tt.call, err)
+		}
+
+		result := L.Get(-1)
+		L.Pop(1)
+		if result != lua.LBool(tt.expected) {
+			t.Errorf("%s = %v, want %v", tt.call, result, tt.expected)
+		}
+	}
+
+	if err := L.DoString(`
+		local validation = require("validation")
+		validation.is_slug("abc", {max_len = 0})
+	`); err == nil {
+		t.Error("is_slug with max_len = 0 should raise an error")
+	}
+}
@@ -0,0 +1,129 @@
+package validation
+
+import (
+	lua "github.com/yuin/gopher-lua"
+)
+
+// stateKey is the registry key under which the module state is stored.
+const stateKey = "validation.state"
+
+// moduleState holds the per-LState settings of the module, such as the
+// loader options, the active locale, registered message catalogs and
+// validators registered from Lua together with their default messages, and
+// the operations of the loaded OpenAPI document.
+type moduleState struct {
+	options           Options
+	enabled           func(name string) bool
+	locale            string
+	catalogs          map[string]map[string]string
+	validators        map[string]*lua.LFunction
+	validatorMessages map[string]string
+	operations        map[string]*openAPIOperation
+
+	// documentOperations are the operations of Options.OpenAPI, restored
+	// by resetState.
+	documentOperations map[string]*openAPIOperation
+
+	// module is the loaded module table and snapshot its original entries,
+	// recorded for states reused by a Pool.
+	module   *lua.LTable
+	snapshot moduleSnapshot
+}
+
+func newModuleState() *moduleState {
+	return &moduleState{
+		locale:            defaultLocale,
+		catalogs:          map[string]map[string]string{},
+		validators:        map[string]*lua.LFunction{},
+		validatorMessages: map[string]string{},
+	}
+}
+
+// moduleSnapshot records the entries of a loaded module table and of the
+// tables it contains, so changes scripts make to them can be undone.
+type moduleSnapshot map[*lua.LTable]map[lua.LValue]lua.LValue
+
+func takeSnapshot(mod *lua.LTable) moduleSnapshot {
+	snapshot := moduleSnapshot{}
+	var record func(tbl *lua.LTable)
+	record = func(tbl *lua.LTable) {
+		entries := map[lua.LValue]lua.LValue{}
+		snapshot[tbl] = entries
+		tbl.ForEach(func(key, value lua.LValue) {
+			entries[key] = value
+			if sub, ok := value.(*lua.LTable); ok && snapshot[sub] == nil {
+				record(sub)
+			}
+		})
+	}
+	record(mod)
+	return snapshot
+}
+
+// restore sets the entries of every recorded table back to the snapshot.
+// Tables that were not changed are left alone.
+func (s moduleSnapshot) restore() {
+	for tbl, entries := range s {
+		n, changed := 0, false
+		tbl.ForEach(func(key, value lua.LValue) {
+			n++
+			if entries[key] != value {
+				changed = true
+			}
+		})
+		if !changed && n == len(entries) {
+			continue
+		}
+
+		var keys []lua.LValue
+		tbl.ForEach(func(key, _ lua.LValue) { keys = append(keys, key) })
+		for _, key := range keys {
+			tbl.RawSet(key, lua.LNil)
+		}
+		for key, value := range entries {
+			tbl.RawSet(key, value)
+		}
+	}
+}
+
+// resetState undoes the changes scripts made to the module loaded into L:
+// the locale, message catalogs, registered validators and OpenAPI document
+// are restored, and so is the module table if a snapshot of it was taken.
+func resetState(L *lua.LState) {
+	st := stateOf(L)
+	st.locale = defaultLocale
+	if len(st.catalogs) > 0 {
+		st.catalogs = map[string]map[string]string{}
+	}
+	if len(st.validators) > 0 {
+		st.validators = map[string]*lua.LFunction{}
+	}
+	if len(st.validatorMessages) > 0 {
+		st.validatorMessages = map[string]string{}
+	}
+	st.operations = st.documentOperations
+
+	if st.module != nil {
+		st.snapshot.restore()
+		registry := L.Get(lua.RegistryIndex).(*lua.LTable)
+		if loaded, ok := registry.RawGetString("_LOADED").(*lua.LTable); ok {
+			loaded.RawSetString("validation", st.module)
+		}
+	}
+}
+
+// stateOf returns the module state of L, creating it on first use.
+func stateOf(L *lua.LState) *moduleState {
+	registry := L.Get(lua.RegistryIndex).(*lua.LTable)
+	if ud, ok := registry.RawGetString(stateKey).(*lua.LUserData); ok {
+		if st, ok := ud.Value.(*moduleState); ok {
+			return st
+		}
+	}
+
+	st := newModuleState()
+	ud := L.NewUserData()
+	ud.Value = st
+	registry.RawSetString(stateKey, ud)
+	return st
+}
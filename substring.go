@@ -0,0 +1,51 @@
+package validation
+
+import (
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// substringArgs reads the substring argument at n, a string or an array of
+// alternatives, and whether the options table after it sets ignore_case.
+func substringArgs(L *lua.LState, n int) ([]string, bool) {
+	var subs []string
+	switch v := L.CheckAny(n).(type) {
+	case lua.LString:
+		subs = []string{string(v)}
+	case *lua.LTable:
+		for i := 1; i <= v.Len(); i++ {
+			subs = append(subs, lua.LVAsString(v.RawGetInt(i)))
+		}
+	default:
+		L.TypeError(n, lua.LTString)
+	}
+	ignoreCase := lua.LVAsBool(L.OptTable(n+1, L.NewTable()).RawGetString("ignore_case"))
+	return subs, ignoreCase
+}
+
+// substringFunc returns a Lua function reporting whether a string matches
+// any of the given substrings, or none of them if negate is set
+// Usage: validation.starts_with(str, prefix_or_prefixes, {ignore_case=false}) -> boolean
+func substringFunc(match func(s, sub string) bool, negate bool) lua.LGFunction {
+	return func(L *lua.LState) int {
+		str := L.CheckString(1)
+		subs, ignoreCase := substringArgs(L, 2)
+
+		if ignoreCase {
+			str = strings.ToLower(str)
+		}
+		found := false
+		for _, sub := range subs {
+			if ignoreCase {
+				sub = strings.ToLower(sub)
+			}
+			if match(str, sub) {
+				found = true
+				break
+			}
+		}
+		L.Push(lua.LBool(found != negate))
+		return 1
+	}
+}
@@ -0,0 +1,62 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestSubstringValidators(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		call     string
+		expected bool
+	}{
+		{`starts_with("https://example.com", "https://")`, true},
+		{`starts_with("http://example.com", "https://")`, false},
+		{`starts_with("http://example.com", {"https://", "http://"})`, true},
+		{`starts_with("HTTPS://example.com", "https://")`, false},
+		{`starts_with("HTTPS://example.com", "https://", {ignore_case = true})`, true},
+		{`starts_with("abc", "")`, true},
+		{`starts_with("abc", {})`, false},
+		{`ends_with("report.pdf", ".pdf")`, true},
+		{`ends_with("report.PDF", {".pdf", ".doc"}, {ignore_case = true})`, true},
+		{`ends_with("report.pdf.exe", ".pdf")`, false},
+		{`contains("hello world", "o w")`, true},
+		{`contains("hello world", "xyz")`, false},
+		{`contains("Hello World", "WORLD", {ignore_case = true})`, true},
+		{`not_starts_with("_private", "_")`, false},
+		{`not_starts_with("public", "_")`, true},
+		{`not_ends_with("backup.tmp", {".tmp", ".bak"})`, false},
+		{`not_ends_with("backup.tar", {".tmp", ".bak"})`, true},
+		{`not_contains("hello", {"<", ">"})`, true},
+		{`not_contains("<script>", {"<", ">"})`, false},
+		{`not_contains("DROP TABLE", "drop", {ignore_case = true})`, false},
+	}
+
+	for _, tt := range tests {
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.` + tt.call + `
+		`); err != nil {
+			t.Fatalf("%s: %v", tt.call, err)
+		}
+
+		result := L.Get(-1)
+		L.Pop(1)
+		if result != lua.LBool(tt.expected) {
+			t.Errorf("%s = %v, want %v", tt.call, result, tt.expected)
+		}
+	}
+
+	if err := L.DoString(`
+		local validation = require("validation")
+		validation.contains("abc", 1)
+	`); err == nil {
+		t.Error("contains with a number substring should raise an error")
+	}
+}
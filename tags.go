@@ -0,0 +1,220 @@
+package validation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// tagBounds maps go-playground/validator bound tags to field options.
+var tagBounds = map[string]string{
+	"min": "min",
+	"max": "max",
+	"gte": "min",
+	"lte": "max",
+	"gt":  "gt",
+	"lt":  "lt",
+}
+
+// tagFormats maps go-playground/validator format tags to format names.
+var tagFormats = map[string]string{
+	"email":              "email",
+	"url":                "url",
+	"uri":                "url",
+	"hostname":           "hostname",
+	"hostname_rfc1123":   "hostname",
+	"fqdn":               "domain",
+	"uuid":               "uuid",
+	"ip":                 "ip",
+	"ipv4":               "ipv4",
+	"ipv6":               "ipv6",
+	"cidr":               "cidr",
+	"mac":                "mac",
+	"port":               "port",
+	"e164":               "e164",
+	"credit_card":        "credit_card",
+	"bic":                "bic",
+	"isbn":               "isbn",
+	"isbn10":             "isbn10",
+	"isbn13":             "isbn13",
+	"iso3166_1_alpha2":   "country_code",
+	"iso3166_1_alpha3":   "country_code_alpha3",
+	"iso4217":            "currency_code",
+	"bcp47_language_tag": "language_tag",
+	"timezone":           "timezone",
+	"latitude":           "latitude",
+	"longitude":          "longitude",
+	"semver":             "semver",
+	"cron":               "cron",
+	"hexcolor":           "hex_color",
+	"base64":             "base64",
+	"base64url":          "base64url",
+	"datauri":            "data_uri",
+	"jwt":                "jwt",
+	"md5":                "md5",
+	"sha256":             "sha256",
+	"sha384":             "sha384",
+	"sha512":             "sha512",
+	"ascii":              "ascii",
+	"printascii":         "printable_ascii",
+	"alphaunicode":       "alpha_unicode",
+	"alphanumunicode":    "alphanum_unicode",
+	"number":             "numeric_string",
+	"lowercase":          "lowercase",
+	"uppercase":          "uppercase",
+	"json":               "json",
+}
+
+// tagPatterns maps go-playground/validator character class tags to patterns.
+var tagPatterns = map[string]string{
+	"alpha":    "^[a-zA-Z]+$",
+	"alphanum": "^[a-zA-Z0-9]+$",
+}
+
+// parseTag converts a go-playground/validator tag such as
+// "required,email,gte=1" into a field definition table. A wrapping
+// `validate:"..."` is accepted. Tags following "dive" describe array items.
+// As in go-playground/validator, "omitempty" skips the remaining rules for
+// zero values such as "" and 0.
+// Fields get type any unless a format implies a string, so bounds apply to
+// the length of strings, the size of arrays and the value of numbers just as
+// they do for the corresponding Go types.
+func parseTag(L *lua.LState, tag string) (*lua.LTable, error) {
+	tag = strings.TrimSpace(tag)
+	if rest, ok := strings.CutPrefix(tag, "validate:"); ok {
+		unquoted, err := strconv.Unquote(rest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid struct tag %q", tag)
+		}
+		tag = unquoted
+	}
+
+	tokens, items := strings.Split(tag, ","), []string(nil)
+	for i, token := range tokens {
+		if strings.TrimSpace(token) == "dive" {
+			tokens, items = tokens[:i], tokens[i+1:]
+			break
+		}
+	}
+
+	def := L.NewTable()
+	def.RawSetString("type", lua.LString("any"))
+	custom := L.NewTable()
+
+	for _, token := range tokens {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+		if token == "omitempty" {
+			def.RawSetString("omit_empty", lua.LTrue)
+			continue
+		}
+		name, arg, hasArg := strings.Cut(token, "=")
+
+		switch {
+		case name == "required":
+			def.RawSetString("required", lua.LTrue)
+		case tagBounds[name] != "":
+			n, err := ruleNumberArg(token, arg, hasArg)
+			if err != nil {
+				return nil, err
+			}
+			def.RawSetString(tagBounds[name], n)
+		case name == "len":
+			n, err := ruleNumberArg(token, arg, hasArg)
+			if err != nil {
+				return nil, err
+			}
+			def.RawSetString("min", n)
+			def.RawSetString("max", n)
+		case name == "eq" || name == "oneof":
+			if !hasArg || arg == "" {
+				return nil, fmt.Errorf("tag %q: expected %s=value", token, name)
+			}
+			values := strings.Fields(arg)
+			if name == "eq" {
+				values = []string{arg}
+			}
+			def.RawSetString("one_of", tagValues(L, values))
+		case name == "boolean":
+			def.RawSetString("type", lua.LString("boolean"))
+		case tagFormats[name] != "":
+			def.RawSetString("type", lua.LString("string"))
+			def.RawSetString("format", lua.LString(tagFormats[name]))
+		case tagPatterns[name] != "":
+			def.RawSetString("type", lua.LString("string"))
+			def.RawSetString("pattern", lua.LString(tagPatterns[name]))
+		case hasArg || strings.Contains(name, "|"):
+			return nil, fmt.Errorf("unsupported tag %q", token)
+		default:
+			custom.Append(lua.LString(name))
+		}
+	}
+
+	if custom.Len() > 0 {
+		def.RawSetString("custom", custom)
+	}
+	if items != nil {
+		itemDef, err := parseTag(L, strings.Join(items, ","))
+		if err != nil {
+			return nil, err
+		}
+		def.RawSetString("type", lua.LString("array"))
+		def.RawSetString("items", itemDef)
+	}
+	return def, nil
+}
+
+// tagValues converts oneof/eq tag and in: rule arguments to Lua values.
+// Numeric arguments match both numbers and numeric strings.
+func tagValues(L *lua.LState, values []string) *lua.LTable {
+	tbl := L.NewTable()
+	for _, value := range values {
+		tbl.Append(lua.LString(value))
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			tbl.Append(lua.LNumber(n))
+		}
+	}
+	return tbl
+}
+
+// parseTagRule parses a go-playground/validator tag into a rule
+// Usage: validation.parse_tag("required,email,gte=1") -> rule
+func parseTagRule(L *lua.LState) int {
+	def, err := parseTag(L, L.CheckString(1))
+	if err != nil {
+		L.ArgError(1, err.Error())
+	}
+	pushRule(L, def)
+	return 1
+}
+
+// schemaFromTags compiles a map of field names to go-playground/validator
+// tags into a schema
+// Usage: validation.schema_from_tags({email="required,email", age="gte=0,lte=130"}) -> schema
+func schemaFromTags(L *lua.LState) int {
+	tags := L.CheckTable(1)
+
+	defs := L.NewTable()
+	tags.ForEach(func(key, value lua.LValue) {
+		tag, ok := value.(lua.LString)
+		if !ok {
+			L.ArgError(1, fmt.Sprintf("tag for %s must be a string", key.String()))
+		}
+		def, err := parseTag(L, string(tag))
+		if err != nil {
+			L.ArgError(1, fmt.Sprintf("field %q: %v", key.String(), err))
+		}
+		defs.RawSet(key, def)
+	})
+
+	s, err := compileSchema(L, defs)
+	if err != nil {
+		L.ArgError(1, err.Error())
+	}
+	pushSchema(L, s)
+	return 1
+}
@@ -0,0 +1,119 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestSchemaFromTags(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local s = validation.schema_from_tags({
+			email = 'validate:"required,email"',
+			name = "required,gte=2,lte=50",
+			age = "omitempty,gte=0,lte=130",
+			role = "oneof=admin member",
+			tags = "max=3,dive,required,alphanum",
+		})
+		local ok = s:validate({email="ada@example.com", name="Ada", age=36, role="admin", tags={"a1", "b2"}})
+		local _, errors = s:validate({email="nope", name="A", age=131, role="owner", tags={"ok", "no!"}})
+		return ok, errors.email, errors.name, errors.age, errors.role, errors["tags[2]"]
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("SchemaFromTags test failed: %v", err)
+	}
+
+	ok := L.Get(-6).(lua.LBool)
+	if !bool(ok) {
+		t.Error("Expected true for valid table")
+	}
+
+	expected := []string{
+		"email must be a valid email",
+		"name must be at least 2 characters",
+		"age must be at most 130",
+		"role must be one of admin, member",
+		"tags[2] must match the pattern ^[a-zA-Z0-9]+$",
+	}
+	for i, message := range expected {
+		if got := L.Get(i - len(expected)).String(); got != message {
+			t.Errorf("Expected %q, got %q", message, got)
+		}
+	}
+}
+
+func TestSchemaFromTagsOmitEmpty(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local s = validation.schema_from_tags({e = "omitempty,email", n = "omitempty,gte=10", tags = "omitempty,min=2"})
+		local _, errors = s:validate({e = "nope", n = 5, tags = {"a"}})
+		return (s:validate({e = "", n = 0, tags = {}})), errors.e, errors.n, errors.tags
+	`
+
+	if err := L.DoString(script); err != nil {
+		t.Fatalf("SchemaFromTagsOmitEmpty test failed: %v", err)
+	}
+
+	if ok := L.Get(-4); ok != lua.LTrue {
+		t.Error("Expected empty values to skip omitempty rules")
+	}
+	for i := -3; i <= -1; i++ {
+		if L.Get(i) == lua.LNil {
+			t.Errorf("Expected an error for non-empty invalid value at %d", i)
+		}
+	}
+}
+
+func TestParseTag(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	script := `
+		local validation = require("validation")
+		local count = validation.parse_tag("gt=0,lt=10")
+		local exact = validation.parse_tag("len=3")
+		local flag = validation.parse_tag("eq=1")
+		return count:validate(5), count:validate(10), exact:validate("abc"), exact:validate({1, 2}),
+			flag:validate(1), flag:validate("1"), (flag:validate(2))
+	`
+
+	err := L.DoString(script)
+	if err != nil {
+		t.Fatalf("ParseTag test failed: %v", err)
+	}
+
+	expected := []bool{true, false, true, false, true, true, false}
+	for i, want := range expected {
+		if got := lua.LVAsBool(L.Get(i - len(expected))); got != want {
+			t.Errorf("Result %d: expected %v, got %v", i+1, want, got)
+		}
+	}
+}
+
+func TestParseTagUnsupported(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	err := L.DoString(`require("validation").parse_tag("required_if=Role admin")`)
+	if err == nil || !strings.Contains(err.Error(), `unsupported tag "required_if=Role admin"`) {
+		t.Errorf("Expected unsupported tag error, got %v", err)
+	}
+}
@@ -0,0 +1,29 @@
+package validation
+
+import (
+	"time"
+	// Embed the IANA time zone database so names validate the same way on
+	// hosts without zoneinfo files.
+	_ "time/tzdata"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// IsTimezone reports whether s names a zone in the IANA time zone database,
+// such as "Europe/Istanbul" or "UTC". The empty string and "Local", which
+// time.LoadLocation maps to UTC and the host zone, are rejected.
+func IsTimezone(s string) bool {
+	if s == "" || s == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(s)
+	return err == nil
+}
+
+// isTimezone checks if a string is an IANA time zone name
+// Usage: validation.is_timezone(str) -> boolean
+func isTimezone(L *lua.LState) int {
+	str := L.CheckString(1)
+	L.Push(lua.LBool(IsTimezone(str)))
+	return 1
+}
@@ -0,0 +1,42 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsTimezone(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"Europe/Istanbul", true},
+		{"America/Argentina/Buenos_Aires", true},
+		{"UTC", true},
+		{"Etc/GMT+3", true},
+		{"Mars/OlympusMons", false},
+		{"Europe/", false},
+		{"../etc/passwd", false},
+		{"Local", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if err := L.DoString(`
+			local validation = require("validation")
+			return validation.is_timezone("` + tt.input + `")
+		`); err != nil {
+			t.Fatalf("is_timezone(%q): %v", tt.input, err)
+		}
+		if got := L.Get(-1) == lua.LTrue; got != tt.expected {
+			t.Errorf("is_timezone(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+		L.Pop(1)
+	}
+}
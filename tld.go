@@ -0,0 +1,46 @@
+package validation
+
+import (
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+	"golang.org/x/net/publicsuffix"
+)
+
+// IsKnownTLD reports whether tld, without a leading dot, is a top-level
+// domain in the ICANN section of the public suffix list embedded in
+// golang.org/x/net/publicsuffix. Internationalized TLDs are given in their
+// punycode form, e.g. "xn--p1ai". Updating golang.org/x/net refreshes the list.
+func IsKnownTLD(tld string) bool {
+	tld = strings.ToLower(tld)
+	if tld == "" || strings.Contains(tld, ".") {
+		return false
+	}
+	suffix, icann := publicsuffix.PublicSuffix(tld)
+	return icann && suffix == tld
+}
+
+// isDomain validates a domain name, optionally requiring a known top-level domain
+// Usage: validation.is_domain(str, {check_tld=false, allow_trailing_dot=false, allow_underscore=false, allow_idn=false}) -> boolean
+func isDomain(L *lua.LState) int {
+	str := L.CheckString(1)
+	opts := L.OptTable(2, L.NewTable())
+
+	rules := domainRules().withOptions(opts)
+	if rules.check(str) != nil {
+		L.Push(lua.LBool(false))
+		return 1
+	}
+
+	if lua.LVAsBool(opts.RawGetString("check_tld")) {
+		host := strings.TrimSuffix(str, ".")
+		if rules.allowIDN {
+			host, _ = toASCIIHost(host)
+		}
+		tld := host[strings.LastIndexByte(host, '.')+1:]
+		L.Push(lua.LBool(IsKnownTLD(tld)))
+		return 1
+	}
+	L.Push(lua.LBool(true))
+	return 1
+}
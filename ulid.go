@@ -0,0 +1,40 @@
+package validation
+
+import (
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// crockfordAlphabet is the Crockford base32 alphabet used by ULIDs.
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// IsULID reports whether s is a ULID: 26 Crockford base32 characters (either
+// case) whose 48-bit timestamp does not overflow, so the first character is
+// at most 7.
+func IsULID(s string) bool {
+	if len(s) != 26 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(crockfordAlphabet, upperASCII(s[i])) < 0 {
+			return false
+		}
+	}
+	return s[0] <= '7'
+}
+
+func upperASCII(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+// isULID validates a ULID
+// Usage: validation.is_ulid(str) -> boolean
+func isULID(L *lua.LState) int {
+	str := L.CheckString(1)
+	L.Push(lua.LBool(IsULID(str)))
+	return 1
+}
@@ -0,0 +1,39 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsULID(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		str      string
+		expected bool
+	}{
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAV", true},
+		{"01arz3ndektsv4rrffq69g5fav", true},
+		{"7ZZZZZZZZZZZZZZZZZZZZZZZZZ", true},
+		{"8ZZZZZZZZZZZZZZZZZZZZZZZZZ", false},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FA", false},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAVX", false},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAU", false},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FIL", false},
+	}
+
+	for _, test := range tests {
+		L.SetGlobal("str", lua.LString(test.str))
+		if err := L.DoString(`return require("validation").is_ulid(str)`); err != nil {
+			t.Fatalf("is_ulid(%q) failed: %v", test.str, err)
+		}
+		if got := lua.LVAsBool(L.Get(-1)); got != test.expected {
+			t.Errorf("is_ulid(%q): expected %v, got %v", test.str, test.expected, got)
+		}
+		L.Pop(1)
+	}
+}
@@ -0,0 +1,139 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// UsernamePolicy lists the requirements checked by ValidateUsername.
+// Usernames consist of ASCII letters and digits plus the punctuation in
+// AllowedChars, which may appear only between letters or digits. Zero
+// lengths are not checked.
+type UsernamePolicy struct {
+	MinLength    int
+	MaxLength    int
+	AllowedChars string
+	Reserved     []string
+}
+
+// DefaultUsernamePolicy is the policy used by IsUsername and as the base of
+// the options passed to validation.is_username.
+var DefaultUsernamePolicy = UsernamePolicy{
+	MinLength:    3,
+	MaxLength:    32,
+	AllowedChars: "_.-",
+}
+
+// reservedUsernames lists names that are always rejected, compared without
+// regard to case: names implying authority, common system accounts and
+// mailboxes, and words likely to clash with application routes.
+var reservedUsernames = buildCodeSet(`
+	admin administrator root superuser sysadmin system sys daemon operator
+	staff moderator mod owner official support help helpdesk security
+	abuse postmaster hostmaster webmaster noreply no-reply mailer-daemon
+	info contact sales billing
+	api www ftp mail email smtp imap pop pop3 ssh dns cdn static assets
+	blog docs status dev test staging
+	null nil undefined none anonymous guest everyone
+	user users account accounts login logout signin signup register
+	settings profile dashboard me self new edit delete
+`)
+
+// ValidateUsername reports whether s meets policy and is not reserved,
+// describing the first problem found. Length is counted in characters.
+func ValidateUsername(s string, policy UsernamePolicy) error {
+	n := utf8.RuneCountInString(s)
+	if policy.MinLength > 0 && n < policy.MinLength {
+		return fmt.Errorf("must be at least %d characters", policy.MinLength)
+	}
+	if policy.MaxLength > 0 && n > policy.MaxLength {
+		return fmt.Errorf("must be at most %d characters", policy.MaxLength)
+	}
+	if s == "" {
+		return fmt.Errorf("must not be empty")
+	}
+
+	isAlnum := func(r rune) bool {
+		return r < utf8.RuneSelf && (isASCIILetter(byte(r)) || r >= '0' && r <= '9')
+	}
+	var prev rune
+	for i, r := range s {
+		switch {
+		case isAlnum(r):
+		case !strings.ContainsRune(policy.AllowedChars, r) || r == utf8.RuneError:
+			return fmt.Errorf("character %q is not allowed", r)
+		case i == 0:
+			return fmt.Errorf("must start with a letter or digit")
+		case i+utf8.RuneLen(r) == len(s):
+			return fmt.Errorf("must end with a letter or digit")
+		case !isAlnum(prev):
+			return fmt.Errorf("must not contain consecutive %q", string(prev)+string(r))
+		}
+		prev = r
+	}
+
+	name := strings.ToLower(s)
+	if reservedUsernames[name] {
+		return fmt.Errorf("%q is reserved", s)
+	}
+	for _, reserved := range policy.Reserved {
+		if strings.ToLower(reserved) == name {
+			return fmt.Errorf("%q is reserved", s)
+		}
+	}
+	return nil
+}
+
+// IsUsername reports whether s is a username under DefaultUsernamePolicy.
+func IsUsername(s string) bool {
+	return ValidateUsername(s, DefaultUsernamePolicy) == nil
+}
+
+// isUsername checks if a string is an acceptable, unreserved username
+// Usage: validation.is_username(str, {min=3, max=32, allowed_chars="_.-", reserved_names={}}) -> boolean, string?
+func isUsername(L *lua.LState) int {
+	str := L.CheckString(1)
+	opts := L.OptTable(2, L.NewTable())
+
+	policy := DefaultUsernamePolicy
+	length := func(key string, def int) int {
+		switch v := opts.RawGetString(key).(type) {
+		case *lua.LNilType:
+			return def
+		case lua.LNumber:
+			if v >= 1 {
+				return int(v)
+			}
+		}
+		L.ArgError(2, fmt.Sprintf("%s must be a positive number", key))
+		return 0
+	}
+	policy.MinLength = length("min", policy.MinLength)
+	policy.MaxLength = length("max", policy.MaxLength)
+	if policy.MinLength > policy.MaxLength {
+		L.ArgError(2, "min must not exceed max")
+	}
+	switch v := opts.RawGetString("allowed_chars").(type) {
+	case *lua.LNilType:
+	case lua.LString:
+		policy.AllowedChars = string(v)
+	default:
+		L.ArgError(2, "allowed_chars must be a string")
+	}
+	if reserved, ok := opts.RawGetString("reserved_names").(*lua.LTable); ok {
+		for i := 1; i <= reserved.Len(); i++ {
+			policy.Reserved = append(policy.Reserved, lua.LVAsString(reserved.RawGetInt(i)))
+		}
+	}
+
+	if err := ValidateUsername(str, policy); err != nil {
+		L.Push(lua.LFalse)
+		L.Push(lua.LString(err.Error()))
+		return 2
+	}
+	L.Push(lua.LTrue)
+	return 1
+}
@@ -0,0 +1,102 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestValidateUsername(t *testing.T) {
+	tests := []struct {
+		input   string
+		policy  UsernamePolicy
+		wantErr string
+	}{
+		{"alice", DefaultUsernamePolicy, ""},
+		{"john.doe", DefaultUsernamePolicy, ""},
+		{"user_42", DefaultUsernamePolicy, ""},
+		{"a-b", DefaultUsernamePolicy, ""},
+		{"jo", DefaultUsernamePolicy, "must be at least 3 characters"},
+		{"abcdefghijklmnopqrstuvwxyz0123456", DefaultUsernamePolicy, "must be at most 32 characters"},
+		{"_alice", DefaultUsernamePolicy, "must start with a letter or digit"},
+		{"alice.", DefaultUsernamePolicy, "must end with a letter or digit"},
+		{"john..doe", DefaultUsernamePolicy, `must not contain consecutive ".."`},
+		{"john._doe", DefaultUsernamePolicy, `must not contain consecutive "._"`},
+		{"john doe", DefaultUsernamePolicy, `character ' ' is not allowed`},
+		{"josé", DefaultUsernamePolicy, `character 'é' is not allowed`},
+		{"bad\xff", DefaultUsernamePolicy, `character '�' is not allowed`},
+		{"admin", DefaultUsernamePolicy, `"admin" is reserved`},
+		{"Root", DefaultUsernamePolicy, `"Root" is reserved`},
+		{"no-reply", DefaultUsernamePolicy, `"no-reply" is reserved`},
+		{"administrators", DefaultUsernamePolicy, ""},
+		{"john.doe", UsernamePolicy{AllowedChars: "_"}, `character '.' is not allowed`},
+		{"acme", UsernamePolicy{Reserved: []string{"ACME"}}, `"acme" is reserved`},
+		{"x", UsernamePolicy{}, ""},
+		{"", UsernamePolicy{}, "must not be empty"},
+	}
+
+	for _, tt := range tests {
+		err := ValidateUsername(tt.input, tt.policy)
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tt.wantErr {
+			t.Errorf("ValidateUsername(%q) = %q, want %q", tt.input, got, tt.wantErr)
+		}
+	}
+
+	if !IsUsername("octocat") || IsUsername("api") {
+		t.Error("IsUsername should accept octocat and reject api")
+	}
+}
+
+func TestIsUsernameLua(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		call     string
+		expected bool
+		err      string
+	}{
+		{`is_username("alice")`, true, ""},
+		{`is_username("admin")`, false, `"admin" is reserved`},
+		{`is_username("al", {min = 2})`, true, ""},
+		{`is_username("alice", {max = 4})`, false, "must be at most 4 characters"},
+		{`is_username("alice+bob", {allowed_chars = "+"})`, true, ""},
+		{`is_username("alice_bob", {allowed_chars = ""})`, false, `character '_' is not allowed`},
+		{`is_username("acme", {reserved_names = {"acme", "corp"}})`, false, `"acme" is reserved`},
+	}
+
+	for _, tt := range tests {
+		if err := L.DoString(`
+			local validation = require("validation")
+			local ok, err = validation.` + tt.call + `
+			return ok, err
+		`); err != nil {
+			t.Fatalf("%s: %v", tt.call, err)
+		}
+
+		ok, msg := L.Get(-2), L.Get(-1)
+		L.Pop(2)
+		if ok != lua.LBool(tt.expected) {
+			t.Errorf("%s = %v, want %v", tt.call, ok, tt.expected)
+		}
+		if tt.err != "" && msg.String() != tt.err {
+			t.Errorf("%s error = %q, want %q", tt.call, msg.String(), tt.err)
+		}
+	}
+
+	for _, call := range []string{
+		`is_username("alice", {min = 0})`,
+		`is_username("alice", {min = 10, max = 5})`,
+		`is_username("alice", {allowed_chars = 1})`,
+	} {
+		if err := L.DoString(`require("validation").` + call); err == nil {
+			t.Errorf("%s should raise an error", call)
+		}
+	}
+}
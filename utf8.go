@@ -0,0 +1,46 @@
+package validation
+
+import (
+	"unicode/utf8"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// invalidUTF8Offset returns the byte offset of the first invalid UTF-8
+// sequence in s, or -1 if s is valid.
+func invalidUTF8Offset(s string) int {
+	for i, r := range s {
+		if r == utf8.RuneError {
+			if _, size := utf8.DecodeRuneInString(s[i:]); size == 1 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// IsUTF8 reports whether s is valid UTF-8.
+func IsUTF8(s string) bool {
+	return utf8.ValidString(s)
+}
+
+// utf8Required reports whether the options table at argument n of a string
+// validator sets utf8, asking it to reject invalid UTF-8.
+func utf8Required(L *lua.LState, n int) bool {
+	return lua.LVAsBool(L.OptTable(n, L.NewTable()).RawGetString("utf8"))
+}
+
+// isUTF8 checks if a string is valid UTF-8 and returns the position of the
+// first invalid byte otherwise
+// Usage: validation.is_utf8(str) -> boolean, position?
+func isUTF8(L *lua.LState) int {
+	str := L.CheckString(1)
+
+	if i := invalidUTF8Offset(str); i >= 0 {
+		L.Push(lua.LFalse)
+		L.Push(lua.LNumber(i + 1))
+		return 2
+	}
+	L.Push(lua.LTrue)
+	return 1
+}
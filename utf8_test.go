@@ -0,0 +1,82 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsUTF8(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		input    string
+		expected bool
+		position int
+	}{
+		{"", true, 0},
+		{"hello", true, 0},
+		{"Günaydın 👋", true, 0},
+		{"\xef\xbf\xbd", true, 0},
+		{"caf\xe9", false, 4},
+		{"ok\xc3", false, 3},
+		{"\xed\xa0\x80", false, 1},
+		{"\xc0\xaf", false, 1},
+		{"a\xff\xfe", false, 2},
+	}
+
+	for _, tt := range tests {
+		L.SetGlobal("input", lua.LString(tt.input))
+		if err := L.DoString(`
+			local validation = require("validation")
+			local ok, position = validation.is_utf8(input)
+			return ok, position
+		`); err != nil {
+			t.Fatalf("is_utf8(%q): %v", tt.input, err)
+		}
+
+		ok, position := L.Get(-2), L.Get(-1)
+		L.Pop(2)
+		if ok != lua.LBool(tt.expected) {
+			t.Errorf("is_utf8(%q) = %v, want %v", tt.input, ok, tt.expected)
+		}
+		if !tt.expected && position != lua.LNumber(tt.position) {
+			t.Errorf("is_utf8(%q) position = %v, want %d", tt.input, position, tt.position)
+		}
+	}
+}
+
+func TestUTF8Option(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	L.SetGlobal("mojibake", lua.LString("caf\xe9"))
+	if err := L.DoString(`
+		local validation = require("validation")
+
+		assert(validation.min_length(mojibake, 2))
+		assert(not validation.min_length(mojibake, 2, {utf8 = true}))
+		assert(validation.min_length("café", 2, {utf8 = true}))
+		assert(not validation.max_length(mojibake, 10, {utf8 = true}))
+		assert(validation.max_length("café", 10, {utf8 = true}))
+
+		local ok, err = validation.validate_regex(mojibake, "^caf", {utf8 = true})
+		assert(not ok and err == "string is not valid UTF-8", err)
+		assert(validation.validate_regex("café", "^caf", {utf8 = true}))
+
+		local ok, errors = validation.validate_fields({name = mojibake, city = "İzmir"}, {
+			name = "required|utf8|max:20",
+			city = validation.rule():string():utf8(),
+		})
+		assert(not ok)
+		assert(errors.name == "name must be valid UTF-8", errors.name)
+		assert(errors.city == nil)
+	`); err != nil {
+		t.Fatalf("utf8 option: %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package validation
+
+import (
+	"fmt"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// IsUUID reports whether s is a UUID in canonical form
+// (xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx, either case). A version of 0
+// accepts any UUID, including the nil UUID; versions 1 to 8 also require
+// that version and the RFC 9562 variant.
+func IsUUID(s string, version int) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return false
+			}
+		default:
+			if !isHexDigit(s[i]) {
+				return false
+			}
+		}
+	}
+	if version == 0 {
+		return true
+	}
+	return hexValue(s[14]) == version && hexValue(s[19])&0xc == 0x8
+}
+
+// isNilUUID reports whether s is the nil UUID (all zeros).
+func isNilUUID(s string) bool {
+	return s == "00000000-0000-0000-0000-000000000000"
+}
+
+func isHexDigit(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+func hexValue(c byte) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0')
+	case c >= 'a' && c <= 'f':
+		return int(c-'a') + 10
+	case c >= 'A' && c <= 'F':
+		return int(c-'A') + 10
+	}
+	return -1
+}
+
+// isUUID validates a UUID, optionally of a specific version
+// Usage: validation.is_uuid(str, version|{version=7, allow_nil=true}) -> boolean
+func isUUID(L *lua.LState) int {
+	str := L.CheckString(1)
+
+	version, allowNil := 0, true
+	switch opts := L.Get(2).(type) {
+	case lua.LNumber:
+		version = int(opts)
+	case *lua.LTable:
+		if v, ok := opts.RawGetString("version").(lua.LNumber); ok {
+			version = int(v)
+		}
+		if v := opts.RawGetString("allow_nil"); v != lua.LNil {
+			allowNil = lua.LVAsBool(v)
+		}
+	case *lua.LNilType:
+	default:
+		L.ArgError(2, "version or options table expected")
+	}
+	if version < 0 || version > 8 {
+		L.ArgError(2, fmt.Sprintf("unsupported UUID version %d", version))
+	}
+
+	L.Push(lua.LBool(IsUUID(str, version) && (allowNil || !isNilUUID(str))))
+	return 1
+}
@@ -0,0 +1,55 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsUUID(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	const (
+		v4    = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+		v7    = "0190A5B4-1C2D-7E3F-8A9B-0C1D2E3F4A5B"
+		nilID = "00000000-0000-0000-0000-000000000000"
+	)
+
+	tests := []struct {
+		args     string
+		expected bool
+	}{
+		{`"` + v4 + `"`, true},
+		{`"` + v7 + `"`, true},
+		{`"` + v4 + `", 4`, true},
+		{`"` + v4 + `", 7`, false},
+		{`"` + v7 + `", {version = 7}`, true},
+		{`"0190a5b4-1c2d-7e3f-ca9b-0c1d2e3f4a5b", 7`, false},
+		{`"` + nilID + `"`, true},
+		{`"` + nilID + `", {allow_nil = false}`, false},
+		{`"` + nilID + `", 4`, false},
+		{`"f47ac10b58cc4372a5670e02b2c3d479"`, false},
+		{`"{f47ac10b-58cc-4372-a567-0e02b2c3d479}"`, false},
+		{`"f47ac10b-58cc-4372-a567-0e02b2c3d47g"`, false},
+	}
+
+	for _, test := range tests {
+		err := L.DoString(`return require("validation").is_uuid(` + test.args + `)`)
+		if err != nil {
+			t.Fatalf("is_uuid(%s) failed: %v", test.args, err)
+		}
+		if got := lua.LVAsBool(L.Get(-1)); got != test.expected {
+			t.Errorf("is_uuid(%s): expected %v, got %v", test.args, test.expected, got)
+		}
+		L.Pop(1)
+	}
+
+	err := L.DoString(`require("validation").is_uuid("` + v4 + `", 9)`)
+	if err == nil || !strings.Contains(err.Error(), "unsupported UUID version 9") {
+		t.Errorf("Expected unsupported version error, got %v", err)
+	}
+}
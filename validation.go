@@ -1,33 +1,198 @@
 package validation
 
 import (
-	"net/mail"
 	"net/url"
-	"regexp"
+	"strings"
 
 	lua "github.com/yuin/gopher-lua"
 )
 
-// Loader loads the validation module
+// Loader loads the validation module with every validator enabled
 func Loader(L *lua.LState) int {
-	mod := L.SetFuncs(L.NewTable(), exports)
-	L.Push(mod)
-	return 1
+	return NewLoader(Options{})(L)
+}
+
+// moduleClosures are functions that need the module table itself, which is
+// bound to them as the first upvalue.
+var moduleClosures = map[string]lua.LGFunction{
+	"validate_all": validateAll,
+	"register":     register,
+}
+
+// moduleTables build the tables exposed as fields of the module.
+var moduleTables = map[string]func(L *lua.LState, st *moduleState) *lua.LTable{
+	"openapi":  loadOpenAPI,
+	"patterns": patternsTable,
 }
 
 var exports = map[string]lua.LGFunction{
-	"is_empty":       isEmpty,
-	"is_string":      isString,
-	"is_number":      isNumber,
-	"is_table":       isTable,
-	"is_boolean":     isBoolean,
-	"is_nil":         isNil,
-	"validate_email": validateEmail,
-	"validate_url":   validateURL,
-	"validate_regex": validateRegex,
-	"min_length":     minLength,
-	"max_length":     maxLength,
-	"in_range":       inRange,
+	"is_empty":             isEmpty,
+	"is_blank":             isBlank,
+	"is_present":           isPresent,
+	"is_string":            isString,
+	"is_number":            isNumber,
+	"is_table":             isTable,
+	"is_boolean":           isBoolean,
+	"is_nil":               isNil,
+	"validate_email":       validateEmail,
+	"validate_email_mx":    validateEmailMX,
+	"is_disposable_email":  isDisposableEmail,
+	"normalize_email":      normalizeEmail,
+	"validate_url":         validateURL,
+	"validate_regex":       validateRegex,
+	"match":                match,
+	"matches_pattern":      matchesPattern,
+	"matches_all":          matchesAll,
+	"matches_any":          matchesAny,
+	"validate_lua_pattern": validateLuaPattern,
+	"min_length":           minLength,
+	"max_length":           maxLength,
+	"in_range":             inRange,
+	"equals":               equals,
+	"deep_equals":          deepEquals,
+
+	"validate_password": validatePassword,
+
+	"is_semver":        isSemver,
+	"semvers_sorted":   semversSorted,
+	"semver_satisfies": semverSatisfies,
+
+	"is_cron":                     isCron,
+	"cron_not_more_frequent_than": cronNotMoreFrequentThan,
+
+	"is_uuid":      isUUID,
+	"is_ulid":      isULID,
+	"is_object_id": isObjectID,
+
+	"is_ip":   isIP,
+	"is_ipv4": isIPv4,
+	"is_ipv6": isIPv6,
+	"is_cidr": isCIDR,
+	"is_mac":  isMAC,
+	"is_port": isPort,
+
+	"is_private_ip":   isPrivateIP,
+	"is_loopback_ip":  isLoopbackIP,
+	"is_multicast_ip": isMulticastIP,
+	"is_public_ip":    isPublicIP,
+
+	"is_phone_e164":  isPhoneE164,
+	"validate_phone": validatePhone,
+
+	"is_credit_card": isCreditCard,
+	"card_brand":     cardBrandOf,
+	"is_card_expiry": isCardExpiry,
+	"is_cvv":         isCVV,
+
+	"is_iban": isIBAN,
+	"is_bic":  isBIC,
+	"is_vat":  isVAT,
+
+	"is_national_id": isNationalID,
+	"is_postal_code": isPostalCode,
+
+	"is_country_code":  isCountryCode,
+	"is_currency_code": isCurrencyCode,
+	"is_language_tag":  isLanguageTag,
+	"is_timezone":      isTimezone,
+
+	"is_date":     isDate,
+	"is_datetime": isDatetime,
+
+	"date_before":  dateBefore,
+	"date_after":   dateAfter,
+	"date_between": dateBetween,
+	"min_age":      minAge,
+	"max_age":      maxAge,
+	"is_duration":  isDuration,
+
+	"is_weekday":      isWeekday,
+	"is_weekend":      isWeekend,
+	"is_business_day": isBusinessDay,
+
+	"is_latitude":    isLatitude,
+	"is_longitude":   isLongitude,
+	"is_coordinates": isCoordinates,
+	"is_geohash":     isGeohash,
+
+	"is_hex_color": isHexColor,
+	"is_rgb":       isRGB,
+	"is_hsl":       isHSL,
+
+	"is_base64":    isBase64,
+	"is_data_uri":  isDataURI,
+	"is_mime_type": isMIMEType,
+
+	"has_allowed_extension": hasAllowedExtension,
+	"is_safe_path":          isSafePath,
+	"detect_content_type":   detectContentType,
+	"is_jwt":                isJWT,
+	"verify_jwt":            verifyJWT,
+	"is_hash":               isHash,
+
+	"is_password_hash": isPasswordHash,
+
+	"starts_with":     substringFunc(strings.HasPrefix, false),
+	"ends_with":       substringFunc(strings.HasSuffix, false),
+	"contains":        substringFunc(strings.Contains, false),
+	"not_starts_with": substringFunc(strings.HasPrefix, true),
+	"not_ends_with":   substringFunc(strings.HasSuffix, true),
+	"not_contains":    substringFunc(strings.Contains, true),
+
+	"is_utf8":      isUTF8,
+	"is_ascii":     isASCII,
+	"is_printable": isPrintable,
+
+	"is_alpha":          charClassFunc(alphaClass),
+	"is_alphanumeric":   charClassFunc(alphanumericClass),
+	"is_numeric_string": charClassFunc(numericClass),
+
+	"is_lowercase":     isLowercase,
+	"is_uppercase":     isUppercase,
+	"is_snake_case":    caseStyleFunc("snake_case"),
+	"is_constant_case": caseStyleFunc("constant_case"),
+	"is_kebab_case":    caseStyleFunc("kebab_case"),
+	"is_camel_case":    caseStyleFunc("camel_case"),
+	"is_pascal_case":   caseStyleFunc("pascal_case"),
+	"is_slug":          isSlug,
+	"is_username":      isUsername,
+
+	"is_json": isJSON,
+	"is_yaml": isYAML,
+	"is_xml":  isXML,
+
+	"validate_csv": validateCSV,
+
+	"is_isbn": isISBN,
+	"is_ean":  isEAN,
+	"is_upc":  isUPC,
+
+	"validate_domain":        validateDomain,
+	"is_domain":              isDomain,
+	"validate_hostname":      validateHostname,
+	"is_hostname":            isHostname,
+	"is_available_subdomain": isAvailableSubdomain,
+	"domain_to_ascii":        domainToASCII,
+	"domain_to_unicode":      domainToUnicode,
+
+	"coerce_number":  coerceNumber,
+	"coerce_boolean": coerceBoolean,
+
+	"schema":      newSchema,
+	"rule":        newRule,
+	"load_schema": loadSchema,
+
+	"parse_rules":     parseRules,
+	"validate_fields": validateFields,
+
+	"parse_tag":        parseTagRule,
+	"schema_from_tags": schemaFromTags,
+
+	"schema_from_json": schemaFromJSON,
+
+	"set_locale":        setLocale,
+	"get_locale":        getLocale,
+	"register_messages": registerMessages,
 }
 
 // isEmpty checks if a value is nil, empty string, or empty table
@@ -58,6 +223,35 @@ func isEmpty(L *lua.LState) int {
 	return 1
 }
 
+// isBlank checks if a value is nil, an empty or whitespace-only string, or an empty table
+// Usage: validation.is_blank(value) -> boolean
+func isBlank(L *lua.LState) int {
+	value := L.CheckAny(1)
+	L.Push(lua.LBool(isBlankValue(value)))
+	return 1
+}
+
+// isPresent checks if a value is not blank
+// Usage: validation.is_present(value) -> boolean
+func isPresent(L *lua.LState) int {
+	value := L.CheckAny(1)
+	L.Push(lua.LBool(!isBlankValue(value)))
+	return 1
+}
+
+func isBlankValue(value lua.LValue) bool {
+	switch v := value.(type) {
+	case *lua.LNilType:
+		return true
+	case lua.LString:
+		return strings.TrimSpace(string(v)) == ""
+	case *lua.LTable:
+		key, _ := v.Next(lua.LNil)
+		return key == lua.LNil
+	}
+	return false
+}
+
 // isString checks if a value is a string
 // Usage: validation.is_string(value) -> boolean
 func isString(L *lua.LState) int {
@@ -102,55 +296,71 @@ func isNil(L *lua.LState) int {
 	return 1
 }
 
-// validateEmail validates an email address
-// Usage: validation.validate_email(email) -> boolean
-func validateEmail(L *lua.LState) int {
-	email := L.CheckString(1)
-	_, err := mail.ParseAddress(email)
-	L.Push(lua.LBool(err == nil))
-	return 1
-}
-
 // validateURL validates a URL
 // Usage: validation.validate_url(url) -> boolean
 func validateURL(L *lua.LState) int {
 	urlStr := L.CheckString(1)
-	_, err := url.ParseRequestURI(urlStr)
-	L.Push(lua.LBool(err == nil))
+	L.Push(lua.LBool(checkURL(urlStr)))
 	return 1
 }
 
+func checkURL(urlStr string) bool {
+	_, err := url.ParseRequestURI(urlStr)
+	return err == nil
+}
+
 // validateRegex validates a string against a regex pattern
-// Usage: validation.validate_regex(str, pattern) -> boolean, error?
+// Usage: validation.validate_regex(str, pattern, {utf8=false}) -> boolean, error?
 func validateRegex(L *lua.LState) int {
 	str := L.CheckString(1)
 	pattern := L.CheckString(2)
 
-	re, err := regexp.Compile(pattern)
+	if utf8Required(L, 3) && !IsUTF8(str) {
+		L.Push(lua.LBool(false))
+		L.Push(lua.LString("string is not valid UTF-8"))
+		return 2
+	}
+
+	limits := stateOf(L).options.RegexLimits
+	re, err := limits.compile(pattern)
 	if err != nil {
 		L.Push(lua.LBool(false))
 		L.Push(lua.LString(err.Error()))
 		return 2
 	}
 
-	L.Push(lua.LBool(re.MatchString(str)))
+	matched, err := limits.match(re, str)
+	if err != nil {
+		L.Push(lua.LBool(false))
+		L.Push(lua.LString(err.Error()))
+		return 2
+	}
+	L.Push(lua.LBool(matched))
 	return 1
 }
 
 // minLength checks if a string has minimum length
-// Usage: validation.min_length(str, min) -> boolean
+// Usage: validation.min_length(str, min, {utf8=false}) -> boolean
 func minLength(L *lua.LState) int {
 	str := L.CheckString(1)
 	min := L.CheckInt(2)
+	if utf8Required(L, 3) && !IsUTF8(str) {
+		L.Push(lua.LBool(false))
+		return 1
+	}
 	L.Push(lua.LBool(len(str) >= min))
 	return 1
 }
 
 // maxLength checks if a string has maximum length
-// Usage: validation.max_length(str, max) -> boolean
+// Usage: validation.max_length(str, max, {utf8=false}) -> boolean
 func maxLength(L *lua.LState) int {
 	str := L.CheckString(1)
 	max := L.CheckInt(2)
+	if utf8Required(L, 3) && !IsUTF8(str) {
+		L.Push(lua.LBool(false))
+		return 1
+	}
 	L.Push(lua.LBool(len(str) <= max))
 	return 1
 }
@@ -149,44 +149,6 @@ func TestIsEmpty(t *testing.T) {
 	}
 }
 
-func TestValidateEmail(t *testing.T) {
-	L := lua.NewState()
-	defer L.Close()
-
-	L.PreloadModule("validation", Loader)
-
-	tests := []struct {
-		name     string
-		email    string
-		expected bool
-	}{
-		{"valid email", "user@example.com", true},
-		{"valid email with subdomain", "user@mail.example.com", true},
-		{"invalid email", "not-an-email", false},
-		{"invalid email no domain", "user@", false},
-		{"invalid email no @", "userexample.com", false},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			script := `
-				local validation = require("validation")
-				return validation.validate_email("` + tt.email + `")
-			`
-
-			err := L.DoString(script)
-			if err != nil {
-				t.Fatalf("ValidateEmail test failed: %v", err)
-			}
-
-			result := L.Get(-1).(lua.LBool)
-			if bool(result) != tt.expected {
-				t.Errorf("Expected %v for %s, got %v", tt.expected, tt.email, result)
-			}
-		})
-	}
-}
-
 func TestValidateURL(t *testing.T) {
 	L := lua.NewState()
 	defer L.Close()
@@ -363,3 +325,47 @@ func TestInRange(t *testing.T) {
 		t.Error("Expected false for 0 in range [1, 10]")
 	}
 }
+
+func TestIsBlank(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected bool
+	}{
+		{"whitespace string", `"   "`, true},
+		{"empty string", `""`, true},
+		{"non-empty string", `"x"`, false},
+		{"nil", "nil", true},
+		{"empty table", "{}", true},
+		{"non-empty table", "{1}", false},
+		{"number", "0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script := `
+				local validation = require("validation")
+				return validation.is_blank(` + tt.value + `), validation.is_present(` + tt.value + `)
+			`
+
+			err := L.DoString(script)
+			if err != nil {
+				t.Fatalf("IsBlank test failed: %v", err)
+			}
+
+			blank := L.Get(-2).(lua.LBool)
+			present := L.Get(-1).(lua.LBool)
+			if bool(blank) != tt.expected {
+				t.Errorf("Expected is_blank %v for %s, got %v", tt.expected, tt.value, blank)
+			}
+			if bool(present) == tt.expected {
+				t.Errorf("Expected is_present %v for %s, got %v", !tt.expected, tt.value, present)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// vatRule describes the VAT numbers of a country: the shape of the number
+// after the country prefix and, where published, its check digit algorithm.
+type vatRule struct {
+	pattern *regexp.Regexp
+	check   func(number string) bool
+}
+
+func newVATRule(pattern string, check func(string) bool) vatRule {
+	return vatRule{pattern: regexp.MustCompile(anchor(pattern)), check: check}
+}
+
+// vatRules holds the VAT number rules of EU member states, Northern Ireland
+// (XI) and common non-EU countries, keyed by VAT prefix. Greece uses EL.
+var vatRules = map[string]vatRule{
+	"AT": newVATRule(`U\d{8}`, vatCheckAT),
+	"BE": newVATRule(`[01]\d{9}`, vatCheckBE),
+	"BG": newVATRule(`\d{9,10}`, nil),
+	"CH": newVATRule(`E\d{9}(?:MWST|TVA|IVA)?`, vatCheckCH),
+	"CY": newVATRule(`\d{8}[A-Z]`, nil),
+	"CZ": newVATRule(`\d{8,10}`, nil),
+	"DE": newVATRule(`\d{9}`, vatCheckDE),
+	"DK": newVATRule(`\d{8}`, weightedMod11([]int{2, 7, 6, 5, 4, 3, 2, 1})),
+	"EE": newVATRule(`\d{9}`, nil),
+	"EL": newVATRule(`\d{9}`, nil),
+	"ES": newVATRule(`[A-Z0-9]\d{7}[A-Z0-9]`, nil),
+	"FI": newVATRule(`\d{8}`, mod11CheckDigit([]int{7, 9, 10, 5, 8, 4, 2})),
+	"FR": newVATRule(`[A-HJ-NP-Z0-9]{2}\d{9}`, vatCheckFR),
+	"GB": newVATRule(`\d{9}|\d{12}|GD[0-4]\d{2}|HA[5-9]\d{2}`, nil),
+	"HR": newVATRule(`\d{11}`, nil),
+	"HU": newVATRule(`\d{8}`, nil),
+	"IE": newVATRule(`\d{7}[A-W][A-IW]?|\d[A-Z+*]\d{5}[A-W]`, nil),
+	"IT": newVATRule(`\d{11}`, luhnValid),
+	"LT": newVATRule(`\d{9}|\d{12}`, nil),
+	"LU": newVATRule(`\d{8}`, nil),
+	"LV": newVATRule(`\d{11}`, nil),
+	"MT": newVATRule(`\d{8}`, nil),
+	"NL": newVATRule(`\d{9}B\d{2}`, nil),
+	"NO": newVATRule(`\d{9}(?:MVA)?`, func(n string) bool { return mod11CheckDigit([]int{3, 2, 7, 6, 5, 4, 3, 2})(n[:9]) }),
+	"PL": newVATRule(`\d{10}`, vatCheckPL),
+	"PT": newVATRule(`\d{9}`, vatCheckPT),
+	"RO": newVATRule(`[1-9]\d{1,9}`, nil),
+	"SE": newVATRule(`\d{10}01`, func(n string) bool { return luhnValid(n[:10]) }),
+	"SI": newVATRule(`[1-9]\d{7}`, nil),
+	"SK": newVATRule(`[1-9]\d{9}`, nil),
+	"XI": newVATRule(`\d{9}|\d{12}|GD[0-4]\d{2}|HA[5-9]\d{2}`, nil),
+}
+
+// vatCountryPrefixes maps ISO 3166-1 codes to VAT prefixes where they differ.
+var vatCountryPrefixes = map[string]string{"GR": "EL"}
+
+// vatSeparators are removed from a VAT number before it is checked.
+var vatSeparators = strings.NewReplacer(" ", "", ".", "", "-", "")
+
+// weightedMod11 returns a check that the weighted digit sum is divisible
+// by 11.
+func weightedMod11(weights []int) func(string) bool {
+	return func(n string) bool {
+		return weightedSum(n, weights)%11 == 0
+	}
+}
+
+// mod11CheckDigit returns a check that the digit after the weighted ones is
+// 11 minus the weighted sum modulo 11, where 11 maps to 0. A remainder of 1
+// would need a check digit of 10, so such numbers are never issued.
+func mod11CheckDigit(weights []int) func(string) bool {
+	return func(n string) bool {
+		check := 11 - weightedSum(n, weights)%11
+		if check == 11 {
+			check = 0
+		}
+		return check == int(n[len(weights)]-'0')
+	}
+}
+
+// weightedSum multiplies the leading digits of n by weights and adds them up.
+func weightedSum(n string, weights []int) int {
+	sum := 0
+	for i, w := range weights {
+		sum += w * int(n[i]-'0')
+	}
+	return sum
+}
+
+// vatCheckAT verifies the check digit of an Austrian UID (ATU12345678).
+func vatCheckAT(n string) bool {
+	digits := n[1:]
+	sum := 0
+	for i := 0; i < 7; i++ {
+		d := int(digits[i] - '0')
+		if i%2 == 1 {
+			d *= 2
+			d = d/10 + d%10
+		}
+		sum += d
+	}
+	return (10-(sum+4)%10)%10 == int(digits[7]-'0')
+}
+
+// vatCheckBE verifies a Belgian enterprise number: the last two digits are
+// 97 minus the first eight modulo 97.
+func vatCheckBE(n string) bool {
+	return 97-mod97(n[:8]) == atoiDigits(n[8:])
+}
+
+// vatCheckCH verifies the check digit of a Swiss UID (CHE-123.456.789).
+func vatCheckCH(n string) bool {
+	return mod11CheckDigit([]int{5, 4, 3, 2, 7, 6, 5, 4})(n[1:10])
+}
+
+// vatCheckDE verifies a German USt-IdNr. with ISO 7064 MOD 11,10.
+func vatCheckDE(n string) bool {
+	product := 10
+	for i := 0; i < 8; i++ {
+		sum := (int(n[i]-'0') + product) % 10
+		if sum == 0 {
+			sum = 10
+		}
+		product = 2 * sum % 11
+	}
+	check := 11 - product
+	if check == 10 {
+		check = 0
+	}
+	return check == int(n[8]-'0')
+}
+
+// vatCheckFR verifies a numeric French key, 12 + 3 times the SIREN modulo 97,
+// all modulo 97. Alphabetic keys are not verified.
+func vatCheckFR(n string) bool {
+	if !isDigits(n[:2]) {
+		return true
+	}
+	return (12+3*mod97(n[2:]))%97 == atoiDigits(n[:2])
+}
+
+// vatCheckPL verifies a Polish NIP.
+func vatCheckPL(n string) bool {
+	return weightedSum(n, []int{6, 5, 7, 2, 3, 4, 5, 6, 7})%11 == int(n[9]-'0')
+}
+
+// vatCheckPT verifies a Portuguese NIF, where check digits of 10 become 0.
+func vatCheckPT(n string) bool {
+	check := 11 - weightedSum(n, []int{9, 8, 7, 6, 5, 4, 3, 2})%11
+	if check >= 10 {
+		check = 0
+	}
+	return check == int(n[8]-'0')
+}
+
+// atoiDigits converts a short string of ASCII digits to an int.
+func atoiDigits(s string) int {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		n = n*10 + int(s[i]-'0')
+	}
+	return n
+}
+
+// vatPrefix returns the VAT prefix for an ISO 3166-1 alpha-2 code or a VAT
+// prefix, and whether VAT rules are known for it.
+func vatPrefix(country string) (string, bool) {
+	country = strings.ToUpper(country)
+	if prefix, ok := vatCountryPrefixes[country]; ok {
+		country = prefix
+	}
+	_, ok := vatRules[country]
+	return country, ok
+}
+
+// IsVAT reports whether s is a VAT identification number. With a country,
+// given as an ISO 3166-1 alpha-2 code or VAT prefix, the number may omit the
+// prefix; without one, it must start with a known prefix. Spaces, dots and
+// hyphens are ignored. Check digits are verified for AT, BE, CH, DE, DK, FI,
+// FR, IT, NO, PL, PT and SE.
+func IsVAT(s, country string) bool {
+	number := strings.ToUpper(vatSeparators.Replace(s))
+	var prefix string
+	if country != "" {
+		var ok bool
+		if prefix, ok = vatPrefix(country); !ok {
+			return false
+		}
+		number = trimVATPrefix(number, prefix)
+	} else {
+		if len(number) < 2 {
+			return false
+		}
+		prefix, number = number[:2], number[2:]
+		if p, ok := vatCountryPrefixes[prefix]; ok {
+			prefix = p
+		}
+	}
+
+	rule, ok := vatRules[prefix]
+	if !ok || !rule.pattern.MatchString(number) {
+		return false
+	}
+	return rule.check == nil || rule.check(number)
+}
+
+// trimVATPrefix removes prefix from number, or the ISO code of a country
+// whose VAT prefix differs, such as GR for EL.
+func trimVATPrefix(number, prefix string) string {
+	if rest, ok := strings.CutPrefix(number, prefix); ok {
+		return rest
+	}
+	for iso, p := range vatCountryPrefixes {
+		if p == prefix {
+			if rest, ok := strings.CutPrefix(number, iso); ok {
+				return rest
+			}
+		}
+	}
+	return number
+}
+
+// isVAT checks if a string is a VAT identification number
+// Usage: validation.is_vat(str, country?) -> boolean
+func isVAT(L *lua.LState) int {
+	str := L.CheckString(1)
+	country := L.OptString(2, "")
+	if _, ok := vatPrefix(country); country != "" && !ok {
+		L.ArgError(2, fmt.Sprintf("unsupported VAT country %q", country))
+	}
+	L.Push(lua.LBool(IsVAT(str, country)))
+	return 1
+}
@@ -0,0 +1,74 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsVAT(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		args     string
+		expected bool
+	}{
+		{`"DE136695976"`, true},
+		{`"DE136695977"`, false},
+		{`"de 136 695 976"`, true},
+		{`"136695976", "DE"`, true},
+		{`"DE136695976", "de"`, true},
+		{`"DE136695976", "IT"`, false},
+		{`"ATU13585627"`, true},
+		{`"ATU13585628"`, false},
+		{`"BE0411905847"`, true},
+		{`"BE0411905848"`, false},
+		{`"FR40303265045"`, true},
+		{`"FR41303265045"`, false},
+		{`"IT00743110157"`, true},
+		{`"IT00743110158"`, false},
+		{`"PL5260250995"`, true},
+		{`"DK13585628"`, true},
+		{`"FI20774740"`, true},
+		{`"FI20774741"`, false},
+		{`"SE556188840401"`, true},
+		{`"SE556188840402"`, false},
+		{`"PT501964843"`, true},
+		{`"NO923609016MVA"`, true},
+		{`"CHE-116.281.710 MWST"`, true},
+		{`"CHE-116.281.711"`, false},
+		{`"NL004495445B01"`, true},
+		{`"NL004495445"`, false},
+		{`"GB980780684"`, true},
+		{`"094259216", "GR"`, true},
+		{`"GR094259216"`, true},
+		{`"GR094259216", "GR"`, true},
+		{`"EL094259216", "GR"`, true},
+		{`"GR094259216", "EL"`, true},
+		{`"XX123456789"`, false},
+		{`"D"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			err := L.DoString(`return require("validation").is_vat(` + tt.args + `)`)
+			if err != nil {
+				t.Fatalf("is_vat failed: %v", err)
+			}
+			result := L.Get(-1).(lua.LBool)
+			L.Pop(1)
+			if bool(result) != tt.expected {
+				t.Errorf("Expected %v for %s, got %v", tt.expected, tt.args, result)
+			}
+		})
+	}
+
+	err := L.DoString(`require("validation").is_vat("123456789", "US")`)
+	if err == nil || !strings.Contains(err.Error(), `unsupported VAT country "US"`) {
+		t.Errorf("Expected unsupported country error, got %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+package validation
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// defaultMaxXMLSize is the largest document is_xml parses unless a script
+// sets max_bytes.
+const defaultMaxXMLSize = 10 << 20
+
+// xmlOptions configures checkXML.
+type xmlOptions struct {
+	maxSize      int
+	allowDoctype bool
+}
+
+// defaultXMLOptions rejects document type declarations and documents over
+// 10 MiB.
+var defaultXMLOptions = xmlOptions{maxSize: defaultMaxXMLSize}
+
+// checkXML reports whether s is a well-formed XML document: a single root
+// element, optionally surrounded by comments, processing instructions and
+// whitespace. Only the predefined entities and character references are
+// expanded, so a document that uses entities declared in a DTD is rejected.
+func checkXML(s string, opts xmlOptions) error {
+	if opts.maxSize > 0 && len(s) > opts.maxSize {
+		return fmt.Errorf("document exceeds the maximum size of %d bytes", opts.maxSize)
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(s))
+	doc := xmlDocument{opts: opts}
+	for {
+		// Syntax errors are reported where the decoder stopped and
+		// structural errors where the offending token starts.
+		line, column := decoder.InputPos()
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var syntaxErr *xml.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			line, column = decoder.InputPos()
+			err = errors.New(syntaxErr.Msg)
+		} else if err == nil {
+			err = doc.add(token)
+		}
+		if err != nil {
+			return fmt.Errorf("line %d, column %d: %v", line, column, err)
+		}
+	}
+	if doc.roots == 0 {
+		return fmt.Errorf("no root element")
+	}
+	return nil
+}
+
+// xmlDocument tracks the structure of a document as its tokens are read.
+type xmlDocument struct {
+	opts   xmlOptions
+	tokens int
+	depth  int
+	roots  int
+}
+
+// add checks the next token of the document.
+func (d *xmlDocument) add(token xml.Token) error {
+	d.tokens++
+	switch t := token.(type) {
+	case xml.StartElement:
+		seen := make(map[xml.Name]bool, len(t.Attr))
+		for _, attr := range t.Attr {
+			if seen[attr.Name] {
+				return fmt.Errorf("duplicate attribute %q", attr.Name.Local)
+			}
+			seen[attr.Name] = true
+		}
+		if d.depth == 0 {
+			if d.roots++; d.roots > 1 {
+				return fmt.Errorf("more than one root element")
+			}
+		}
+		d.depth++
+	case xml.EndElement:
+		d.depth--
+	case xml.CharData:
+		if d.depth == 0 && strings.TrimSpace(string(t)) != "" {
+			return fmt.Errorf("text outside the root element")
+		}
+	case xml.ProcInst:
+		if t.Target == "xml" && d.tokens > 1 {
+			return fmt.Errorf("XML declaration not at the start of the document")
+		}
+	case xml.Directive:
+		if strings.HasPrefix(string(t), "DOCTYPE") {
+			if !d.opts.allowDoctype {
+				return fmt.Errorf("document type declarations are not allowed")
+			}
+			if d.roots > 0 {
+				return fmt.Errorf("document type declaration after the root element")
+			}
+		}
+	}
+	return nil
+}
+
+// IsXML reports whether s is a well-formed XML document of at most 10 MiB
+// without a document type declaration.
+func IsXML(s string) bool {
+	return checkXML(s, defaultXMLOptions) == nil
+}
+
+// isXML checks if a string is a well-formed XML document
+// Usage: validation.is_xml(str, {max_bytes=10485760, allow_doctype=false}) -> boolean, error?
+func isXML(L *lua.LState) int {
+	str := L.CheckString(1)
+	opts := L.OptTable(2, L.NewTable())
+
+	o := defaultXMLOptions
+	o.allowDoctype = lua.LVAsBool(opts.RawGetString("allow_doctype"))
+	if n, ok := opts.RawGetString("max_bytes").(lua.LNumber); ok {
+		o.maxSize = int(n)
+	}
+
+	if err := checkXML(str, o); err != nil {
+		L.Push(lua.LFalse)
+		L.Push(lua.LString(err.Error()))
+		return 2
+	}
+	L.Push(lua.LTrue)
+	return 1
+}
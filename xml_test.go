@@ -0,0 +1,59 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsXML(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	doctype := `<?xml version="1.0"?><!DOCTYPE note [<!ENTITY a "aaaaaaaaaa">]><note>&a;</note>`
+
+	tests := []struct {
+		input    string
+		opts     string
+		expected bool
+		err      string
+	}{
+		{`<?xml version="1.0" encoding="UTF-8"?>` + "\n<note id=\"1\"><to>Ana</to><!-- c --><body>a &amp; b &#x263A;</body></note>\n", `{}`, true, ""},
+		{`<a:root xmlns:a="urn:x"><a:child/></a:root>`, `{}`, true, ""},
+		{"", `{}`, false, "no root element"},
+		{"<a><b></a>", `{}`, false, "line 1, column 11: element <b> closed by </a>"},
+		{"<a>\n<b>", `{}`, false, "line 2, column 4: unexpected EOF"},
+		{"<a/><b/>", `{}`, false, "line 1, column 5: more than one root element"},
+		{"text<a/>", `{}`, false, "line 1, column 1: text outside the root element"},
+		{"<a>&nbsp;</a>", `{}`, false, "line 1, column 10: invalid character entity &nbsp;"},
+		{`<a x="1" x="2"/>`, `{}`, false, `line 1, column 1: duplicate attribute "x"`},
+		{`<a/><?xml version="1.0"?>`, `{}`, false, "line 1, column 5: XML declaration not at the start of the document"},
+		{doctype, `{}`, false, "line 1, column 22: document type declarations are not allowed"},
+		{doctype, `{allow_doctype = true}`, false, "line 1, column 73: invalid character entity &a;"},
+		{`<!DOCTYPE note><note/>`, `{allow_doctype = true}`, true, ""},
+		{"<a>" + strings.Repeat("x", 20) + "</a>", `{max_bytes = 16}`, false, "document exceeds the maximum size of 16 bytes"},
+	}
+
+	for _, tt := range tests {
+		L.SetGlobal("input", lua.LString(tt.input))
+		if err := L.DoString(`
+			local validation = require("validation")
+			local ok, err = validation.is_xml(input, ` + tt.opts + `)
+			return ok, err
+		`); err != nil {
+			t.Fatalf("is_xml(%q, %s): %v", tt.input, tt.opts, err)
+		}
+
+		ok, msg := L.Get(-2), L.Get(-1)
+		L.Pop(2)
+		if ok != lua.LBool(tt.expected) {
+			t.Errorf("is_xml(%q, %s) = %v, want %v", tt.input, tt.opts, ok, tt.expected)
+		}
+		if !tt.expected && msg.String() != tt.err {
+			t.Errorf("is_xml(%q, %s) error = %q, want %q", tt.input, tt.opts, msg, tt.err)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package validation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+	"gopkg.in/yaml.v3"
+)
+
+// decodeYAML decodes a YAML stream holding at most one document. An empty
+// stream decodes to nil. Duplicate mapping keys are rejected.
+func decodeYAML(data []byte) (any, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, yamlError(err)
+	}
+	var next any
+	if err := decoder.Decode(&next); !errors.Is(err, io.EOF) {
+		if err != nil {
+			return nil, yamlError(err)
+		}
+		return nil, fmt.Errorf("multiple documents are not supported")
+	}
+	return normalizeYAML(doc), nil
+}
+
+// yamlError strips the package prefix from a YAML error and joins the lines
+// of errors that list several problems.
+func yamlError(err error) error {
+	msg := strings.TrimPrefix(err.Error(), "yaml: ")
+	msg = strings.TrimPrefix(msg, "unmarshal errors:\n")
+	return errors.New(strings.Join(strings.Fields(strings.ReplaceAll(msg, "\n", ";")), " "))
+}
+
+// normalizeYAML replaces the timestamps of a decoded document with strings,
+// as dates only when they carry no time of day.
+func normalizeYAML(value any) any {
+	switch v := value.(type) {
+	case time.Time:
+		if v.Equal(v.Truncate(24*time.Hour)) && v.Location() == time.UTC {
+			return v.Format(time.DateOnly)
+		}
+		return v.Format(time.RFC3339Nano)
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeYAML(item)
+		}
+	case map[string]any:
+		for key, item := range v {
+			v[key] = normalizeYAML(item)
+		}
+	case map[any]any:
+		for key, item := range v {
+			v[key] = normalizeYAML(item)
+		}
+	}
+	return value
+}
+
+// IsYAML reports whether s is a well-formed YAML stream of at most one
+// document without duplicate keys.
+func IsYAML(s string) bool {
+	_, err := decodeYAML([]byte(s))
+	return err == nil
+}
+
+// isYAML checks if a string is a well-formed YAML document and optionally
+// converts it to a Lua value
+// Usage: validation.is_yaml(str, {to_table=false}) -> boolean, value_or_error?
+func isYAML(L *lua.LState) int {
+	str := L.CheckString(1)
+	opts := L.OptTable(2, L.NewTable())
+
+	doc, err := decodeYAML([]byte(str))
+	if err != nil {
+		L.Push(lua.LFalse)
+		L.Push(lua.LString(err.Error()))
+		return 2
+	}
+	L.Push(lua.LTrue)
+	if !lua.LVAsBool(opts.RawGetString("to_table")) {
+		return 1
+	}
+	L.Push(toLua(L, doc))
+	return 2
+}
@@ -0,0 +1,90 @@
+package validation
+
+import (
+	"testing"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestIsYAML(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	tests := []struct {
+		input    string
+		expected bool
+		err      string
+	}{
+		{"name: app\nport: 8080\ntags: [a, b]\n", true, ""},
+		{"- 1\n- two\n", true, ""},
+		{"", true, ""},
+		{"a: [1\n", false, "line 1: did not find expected ',' or ']'"},
+		{"a: 1\na: 2\n", false, `line 2: mapping key "a" already defined at line 1`},
+		{"\tfoo: 1", false, "found character that cannot start any token"},
+		{"a: 1\n---\nb: 2\n", false, "multiple documents are not supported"},
+	}
+
+	for _, tt := range tests {
+		L.SetGlobal("input", lua.LString(tt.input))
+		if err := L.DoString(`
+			local validation = require("validation")
+			local ok, err = validation.is_yaml(input)
+			return ok, err
+		`); err != nil {
+			t.Fatalf("is_yaml(%q): %v", tt.input, err)
+		}
+
+		ok, msg := L.Get(-2), L.Get(-1)
+		L.Pop(2)
+		if ok != lua.LBool(tt.expected) {
+			t.Errorf("is_yaml(%q) = %v, want %v", tt.input, ok, tt.expected)
+		}
+		if !tt.expected && msg.String() != tt.err {
+			t.Errorf("is_yaml(%q) error = %q, want %q", tt.input, msg, tt.err)
+		}
+	}
+}
+
+func TestIsYAMLToTable(t *testing.T) {
+	L := lua.NewState()
+	defer L.Close()
+
+	L.PreloadModule("validation", Loader)
+
+	L.SetGlobal("input", lua.LString(`
+server:
+  host: example.com
+  port: 8080
+  tls: true
+released: 2024-03-01
+updated: 2024-03-01T10:30:00Z
+defaults: &defaults
+  retries: 3
+worker:
+  <<: *defaults
+  name: mailer
+`))
+	if err := L.DoString(`
+		local validation = require("validation")
+		local ok, doc = validation.is_yaml(input, {to_table = true})
+		assert(ok, doc)
+		assert(doc.server.host == "example.com")
+		assert(doc.server.port == 8080)
+		assert(doc.server.tls == true)
+		assert(doc.released == "2024-03-01", doc.released)
+		assert(doc.updated == "2024-03-01T10:30:00Z", doc.updated)
+		assert(doc.worker.retries == 3)
+		assert(doc.worker.name == "mailer")
+
+		local ok, value = validation.is_yaml("a: 1")
+		assert(ok and value == nil)
+
+		local s = validation.schema({port = {type = "number", min = 1}})
+		local ok, doc = validation.is_yaml("port: 0", {to_table = true})
+		assert(not s:validate(doc))
+	`); err != nil {
+		t.Fatalf("is_yaml failed: %v", err)
+	}
+}